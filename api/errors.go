@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
 )
@@ -75,10 +77,12 @@ var (
 		40031,
 		"Self signature must be only one.",
 	)
-	ErrCSRVersionIsIncorrect = errors.NewHTTP400Error(
-		40011,
-		"Virgil Card version must be 5.0.",
-	)
+	ErrCSRVersionIsIncorrect = func(supportedVersions []string) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40011,
+			fmt.Sprintf("Virgil Card version must be one of: %s.", strings.Join(supportedVersions, ", ")),
+		)
+	}
 	ErrCSRPublicKeyDecoding = errors.NewHTTP400Error(
 		40012,
 		"Public key is not a base64-encoded string.",
@@ -103,6 +107,103 @@ var (
 		40037,
 		"Previous Virgil Card exists already.",
 	)
+	ErrCSRKeyAlgorithmIsIncorrect = errors.NewHTTP400Error(
+		40040,
+		"CSR key algorithm is incorrect.",
+	)
+	ErrCSRExpirationTimeIsIncorrect = errors.NewHTTP400Error(
+		40041,
+		"CSR expiration time is incorrect.",
+	)
+	ErrCSRLifetimeIsTooLong = func(maxLifetime time.Duration) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40042,
+			fmt.Sprintf("Virgil Card lifetime is limited to %s.", maxLifetime),
+		)
+	}
+	ErrCSRCreationTimeIsTooOld = func(pastSkew time.Duration) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40043,
+			fmt.Sprintf("CSR creation time mustn't be earlier than %s before the server time.", pastSkew),
+		)
+	}
+	ErrCSRCreationTimeIsInFuture = func(futureSkew time.Duration) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40044,
+			fmt.Sprintf("CSR creation time mustn't be later than %s after the server time.", futureSkew),
+		)
+	}
+	ErrCSRCreationTimeIsEarlierThanPreviousCard = errors.NewHTTP400Error(
+		40045,
+		"CSR creation time mustn't be earlier than the previous Virgil Card creation time.",
+	)
+	ErrRequiredCSRStampIsMissing = func(signer string) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40046,
+			fmt.Sprintf("Signature list must contain the (%s) signer entry required by the application.", signer),
+		)
+	}
+	ErrCSRStampSignerIsNotAllowed = func(signer string) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40047,
+			fmt.Sprintf("Signer (%s) is not allowed by the application.", signer),
+		)
+	}
+	ErrCSRStampSnapshotIsNotAllowed = errors.NewHTTP400Error(
+		40048,
+		"Signature extra snapshots are not allowed by the application.",
+	)
+	ErrCSRStampsCountViolatesPolicy = func(minLength, maxLength int) errors.HTTPError {
+		if 0 == maxLength {
+			return errors.NewHTTP400Error(
+				40049,
+				fmt.Sprintf("Signature list length must be at least %d required by the application.", minLength),
+			)
+		}
+
+		return errors.NewHTTP400Error(
+			40049,
+			fmt.Sprintf("Signature list length must be within [%d, %d] required by the application.", minLength, maxLength),
+		)
+	}
+	ErrDeleteCSRSelfStampIsMissing = errors.NewHTTP400Error(
+		40050,
+		"Self signature made with the private key of the Virgil Card being deleted is missing.",
+	)
+	ErrDeleteCSRSelfStampVerificationFailed = errors.NewHTTP400Error(
+		40051,
+		"Self signature verification failed with the public key of the Virgil Card being deleted.",
+	)
+	ErrDeleteCSRApplicationStampIsMissing = errors.NewHTTP400Error(
+		40052,
+		"Application signature is required to delete the Virgil Card.",
+	)
+	ErrCSRRevocationReasonIsIncorrect = errors.NewHTTP400Error(
+		40053,
+		"CSR revocation reason is incorrect.",
+	)
+	ErrCSRRevocationNoteIsTooLong = func(maxLength int) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40054,
+			fmt.Sprintf("CSR revocation note length mustn't exceed %d characters.", maxLength),
+		)
+	}
+	ErrCSRRevocationIsNotAllowed = errors.NewHTTP400Error(
+		40055,
+		"CSR revocation details are allowed in the delete CSR only.",
+	)
+	ErrCSRExpirationTimeIsRequired = func(maxLifetime time.Duration) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40056,
+			fmt.Sprintf("CSR expiration time is required as Virgil Card lifetime is limited to %s.", maxLifetime),
+		)
+	}
+	ErrCSRStampSignerIsNotRegistered = func(signer string) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40057,
+			fmt.Sprintf("Signer (%s) has no public key registered for the application.", signer),
+		)
+	}
 	ErrCSRIdentityIsIncorrect = errors.NewHTTP400Error(
 		40017,
 		"Identity is incorrect. It mustn't exceed 1024 bytes.",
@@ -171,6 +272,22 @@ var (
 		40100,
 		"Trying to get the Virgil Card that is scoped for another application.",
 	)
+	ErrFollowParameterIsIncorrect = errors.NewHTTP400Error(
+		40101,
+		"Follow parameter is incorrect. The only allowed value is 'latest'.",
+	)
+	ErrVirgilCardChainIsDeleted = errors.NewHTTP404Error(
+		40102,
+		"The latest Virgil Card of the chain is requested but the chain has been deleted.",
+	)
+	ErrIncludeExpiredParameterIsIncorrect = errors.NewHTTP400Error(
+		40103,
+		"Include expired parameter is incorrect. Boolean value is expected.",
+	)
+	ErrVirgilCardIsExpired = errors.NewHTTP404Error(
+		40104,
+		"Requested Virgil Card has expired.",
+	)
 )
 
 //
@@ -187,6 +304,40 @@ var (
 			fmt.Sprintf("Identities to search amount limited to %d.", searchIdentitiesLimit),
 		)
 	}
+	ErrSearchPageSizeIsLimited = func(searchPageSizeLimit int) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40201,
+			fmt.Sprintf("Search page size must be between 1 and %d.", searchPageSizeLimit),
+		)
+	}
+	ErrSearchCursorIsIncorrect = errors.NewHTTP400Error(
+		40202,
+		"Search cursor is incorrect.",
+	)
+	ErrSearchLatestOnlyIsNotPaginated = errors.NewHTTP400Error(
+		40203,
+		"Search of the latest cards only can not be paginated.",
+	)
+	ErrSearchCursorIdentitiesMismatch = errors.NewHTTP400Error(
+		40204,
+		"Search cursor was issued for another identities list.",
+	)
+)
+
+//
+// Card Batch Get handler errors.
+//
+var (
+	ErrCardIDsCannotBeEmpty = errors.NewHTTP400Error(
+		40500,
+		"Card IDs parameter cannot be empty.",
+	)
+	ErrCardIDsCountIsLimited = func(cardIDsLimit int) errors.HTTPError {
+		return errors.NewHTTP400Error(
+			40501,
+			fmt.Sprintf("Card IDs to get amount limited to %d.", cardIDsLimit),
+		)
+	}
 )
 
 //
@@ -207,3 +358,53 @@ var (
 		"Deleted card can not be deleted.",
 	)
 )
+
+//
+// Chain Restore and Force Delete errors.
+//
+var (
+	ErrChainOperatorIDIsEmpty = errors.NewHTTP400Error(
+		40600,
+		"Support operator identity is empty.",
+	)
+	ErrChainOperationReasonIsEmpty = errors.NewHTTP400Error(
+		40601,
+		"Reason of the chain operation is empty.",
+	)
+	ErrVirgilCardChainIsNotDeleted = errors.NewHTTP403Error(
+		40602,
+		"Not deleted chain can not be restored.",
+	)
+)
+
+//
+// Admin API errors.
+//
+var (
+	ErrAdminTokenIsIncorrect = errors.NewHTTP403Error(
+		40700,
+		"Admin token is missing or incorrect.",
+	)
+	ErrAdminIdentityIsEmpty = errors.NewHTTP400Error(
+		40701,
+		"Identity to list the chains of is empty.",
+	)
+	ErrAdminAuditFilterIsIncorrect = errors.NewHTTP400Error(
+		40702,
+		"Either identity or chain_id must be set to list the card audit records.",
+	)
+)
+
+//
+// Transparency log errors.
+//
+var (
+	ErrTransparencyTreeSizeIsIncorrect = errors.NewHTTP400Error(
+		40800,
+		"Tree size is not a positive number within the transparency log size.",
+	)
+	ErrTransparencyLogLeafIsNotFound = errors.NewHTTP404Error(
+		40801,
+		"Virgil Card is not in the transparency log.",
+	)
+)
@@ -0,0 +1,36 @@
+package api
+
+//
+// ChainForceDeleteRequest is a force delete Virgil Card chain request made by the support operator.
+// The chain is the one of the card given, it is deleted without the delete CSR.
+// The reason is kept as the note of the chain revocation record.
+//
+type ChainForceDeleteRequest struct {
+	CardID     string `json:"card_id"`
+	OperatorID string `json:"operator_id"`
+	Reason     string `json:"reason"`
+}
+
+//
+// GetCardID returns an ID of the card of the chain to delete.
+//
+func (r *ChainForceDeleteRequest) GetCardID() string {
+
+	return r.CardID
+}
+
+//
+// GetOperatorID returns the identity of the support operator deleting the chain.
+//
+func (r *ChainForceDeleteRequest) GetOperatorID() string {
+
+	return r.OperatorID
+}
+
+//
+// GetReason returns the reason the chain is deleted for.
+//
+func (r *ChainForceDeleteRequest) GetReason() string {
+
+	return r.Reason
+}
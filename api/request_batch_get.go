@@ -0,0 +1,12 @@
+package api
+
+//
+// CardBatchGetRequest is a get Virgil Cards by their IDs request object.
+//
+type CardBatchGetRequest struct {
+	*Headers
+	CardIDs []string `json:"card_ids"`
+
+	// IncludeExpired makes the request return the expired cards too.
+	IncludeExpired bool `json:"include_expired"`
+}
@@ -17,6 +17,10 @@ type CSR struct {
 	PreviousCardID string `json:"previous_card_id,omitempty"`
 	Version        string `json:"version,omitempty"`
 	CreatedAt      int64  `json:"created_at,omitempty"`
+
+	// RevocationReason and RevocationNote are accepted by the delete CSR only.
+	RevocationReason string `json:"revocation_reason,omitempty"`
+	RevocationNote   string `json:"revocation_note,omitempty"`
 }
 
 //
@@ -58,3 +62,19 @@ func (m *CSR) GetCreatedAt() int64 {
 
 	return m.CreatedAt
 }
+
+//
+// GetRevocationReason returns the delete CSR revocation reason.
+//
+func (m *CSR) GetRevocationReason() string {
+
+	return m.RevocationReason
+}
+
+//
+// GetRevocationNote returns the delete CSR revocation free-text note.
+//
+func (m *CSR) GetRevocationNote() string {
+
+	return m.RevocationNote
+}
@@ -0,0 +1,41 @@
+package api
+
+//
+// CSR 6.0 key algorithms.
+//
+const (
+	KeyAlgorithmED25519    = "ed25519"
+	KeyAlgorithmCurve25519 = "curve25519"
+	KeyAlgorithmSECP256R1  = "secp256r1"
+	KeyAlgorithmSECP384R1  = "secp384r1"
+	KeyAlgorithmSECP521R1  = "secp521r1"
+	KeyAlgorithmRSA2048    = "rsa2048"
+	KeyAlgorithmRSA4096    = "rsa4096"
+	KeyAlgorithmRSA8192    = "rsa8192"
+)
+
+//
+// CSRV6 is a Card Signing Request message of the 6.0 Virgil Card version.
+// It extends the base CSR with the optional typed fields.
+//
+type CSRV6 struct {
+	CSR
+	KeyAlgorithm string `json:"key_algorithm,omitempty"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+}
+
+//
+// GetKeyAlgorithm returns public key algorithm value.
+//
+func (m *CSRV6) GetKeyAlgorithm() string {
+
+	return m.KeyAlgorithm
+}
+
+//
+// GetExpiresAt returns Virgil Card expiration UTC Unix timestamp.
+//
+func (m *CSRV6) GetExpiresAt() int64 {
+
+	return m.ExpiresAt
+}
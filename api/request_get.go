@@ -0,0 +1,36 @@
+package api
+
+//
+// Card Get follow options.
+//
+const (
+	// FollowLatest makes the Card Get request return the latest card of the requested card chain.
+	FollowLatest = "latest"
+)
+
+//
+// CardGetRequest is a get Virgil Card request object.
+// it could contain "follow" query parameter to return another card of the requested card chain
+// and "include_expired" query parameter to return the card even if it has expired.
+//
+type CardGetRequest struct {
+	*Headers
+	Follow         string `json:"-"`
+	IncludeExpired bool   `json:"-"`
+}
+
+//
+// CardChainRequest is a get Virgil Card chain request object.
+// it could contain "include_expired" query parameter to return the expired cards of the chain too.
+//
+type CardChainRequest struct {
+	*Headers
+	IncludeExpired bool `json:"-"`
+}
+
+//
+// IsFollowLatest returns true if the latest card of the requested card chain is requested.
+//
+func (r *CardGetRequest) IsFollowLatest() bool {
+	return r.Follow == FollowLatest
+}
@@ -0,0 +1,35 @@
+package api
+
+//
+// ChainRestoreRequest is a restore deleted Virgil Card chain request made by the support operator.
+// The chain is the one of the card given, the request is not scoped to an application.
+//
+type ChainRestoreRequest struct {
+	CardID     string `json:"card_id"`
+	OperatorID string `json:"operator_id"`
+	Reason     string `json:"reason"`
+}
+
+//
+// GetCardID returns an ID of the card of the chain to restore.
+//
+func (r *ChainRestoreRequest) GetCardID() string {
+
+	return r.CardID
+}
+
+//
+// GetOperatorID returns the identity of the support operator restoring the chain.
+//
+func (r *ChainRestoreRequest) GetOperatorID() string {
+
+	return r.OperatorID
+}
+
+//
+// GetReason returns the reason the chain is restored for.
+//
+func (r *ChainRestoreRequest) GetReason() string {
+
+	return r.Reason
+}
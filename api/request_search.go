@@ -6,11 +6,34 @@ package api
 // 1. "identity" string field which contains single identity e-mail.
 // or
 // 2. "identities" strings array to handle multi-identities search.
+// The search is paginated in case "limit" or "cursor" field is set.
 //
 type CardSearchRequest struct {
 	*Headers
 	Identity   string   `json:"identity"`
 	Identities []string `json:"identities"`
+
+	// Limit is a page size of the paginated search.
+	Limit int `json:"limit"`
+
+	// Cursor is an opaque position of the paginated search returned with the previous page.
+	Cursor string `json:"cursor"`
+
+	// LatestOnly makes the search return the latest card of each chain only.
+	LatestOnly bool `json:"latest_only"`
+
+	// IncludeExpired makes the search return the expired cards too.
+	IncludeExpired bool `json:"include_expired"`
+
+	// IncludeRevoked makes the search return the cards of the deleted chains along with their revocation records.
+	IncludeRevoked bool `json:"include_revoked"`
+}
+
+//
+// IsPaginated returns true if the paginated search is requested.
+//
+func (r *CardSearchRequest) IsPaginated() bool {
+	return r.Limit != 0 || r.Cursor != ""
 }
 
 //
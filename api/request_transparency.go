@@ -0,0 +1,19 @@
+package api
+
+//
+// TransparencyInclusionRequest is a transparency log inclusion proof request object.
+// It could contain "tree_size" query parameter to prove the inclusion into the tree head seen before.
+//
+type TransparencyInclusionRequest struct {
+	*Headers
+	TreeSize int64 `json:"-"`
+}
+
+//
+// TransparencyConsistencyRequest is a transparency log consistency proof request object
+// made of "first" and "second" tree size query parameters. Zero second tree size means the current tree.
+//
+type TransparencyConsistencyRequest struct {
+	FirstTreeSize  int64 `json:"-"`
+	SecondTreeSize int64 `json:"-"`
+}
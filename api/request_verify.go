@@ -0,0 +1,14 @@
+package api
+
+import "github.com/VirgilSecurity/virgil-services-cards/src/model"
+
+//
+// CardVerifyRequest is a verify Virgil Card request object.
+// It holds the card the way it is returned by the service. The card ID is optional and is compared to the calculated one.
+//
+type CardVerifyRequest struct {
+	*Headers
+	CardID          string                    `json:"card_id"`
+	ContentSnapshot string                    `json:"content_snapshot"`
+	Signatures      []*model.CardSignatureDTO `json:"signatures"`
+}
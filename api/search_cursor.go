@@ -0,0 +1,80 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// EncodeSearchCursor returns an opaque string representation of the search cursor
+// bound to the identities of the search request.
+//
+func EncodeSearchCursor(cursor *model.CardSearchCursor, identities []string) string {
+
+	if nil == cursor {
+		return ""
+	}
+
+	c := *cursor
+	c.IdentitiesHash = hashSearchIdentities(identities)
+
+	// the cursor has only integer and string fields so the marshaling never fails.
+	data, _ := json.Marshal(c) // nolint
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+//
+// DecodeSearchCursor returns the search cursor by its opaque string representation.
+// The cursor is rejected unless it was issued for the same identities list.
+// Empty string means the first page of the search.
+//
+func DecodeSearchCursor(cursor string, identities []string) (*model.CardSearchCursor, error) {
+
+	if "" == cursor {
+		return &model.CardSearchCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if nil != err {
+		return nil, ErrSearchCursorIsIncorrect
+	}
+
+	var c model.CardSearchCursor
+	if err := json.Unmarshal(data, &c); nil != err {
+		return nil, ErrSearchCursorIsIncorrect
+	}
+
+	if 0 > c.IdentityOffset || 0 > c.CardOffset {
+		return nil, ErrSearchCursorIsIncorrect
+	}
+
+	if c.IdentitiesHash != hashSearchIdentities(identities) {
+		return nil, ErrSearchCursorIdentitiesMismatch
+	}
+
+	return &c, nil
+}
+
+//
+// hashSearchIdentities returns the hash of the ordered identities list the cursor offsets point into.
+// Every identity is prefixed with its length, so no two lists have the same hashed bytes.
+//
+func hashSearchIdentities(identities []string) string {
+
+	var (
+		h      = sha256.New()
+		length = make([]byte, 8)
+	)
+	for _, identity := range identities {
+		binary.BigEndian.PutUint64(length, uint64(len(identity)))
+		h.Write(length)
+		h.Write([]byte(identity))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
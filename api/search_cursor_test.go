@@ -0,0 +1,73 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// Testing variables.
+//
+var (
+	testSearchIdentities = []string{"alice", "bob"}
+)
+
+//
+// EncodeSearchCursor :: for a search cursor :: is decoded back by DecodeSearchCursor.
+//
+func TestEncodeSearchCursor(t *testing.T) {
+
+	cursor := &model.CardSearchCursor{IdentityOffset: 10, CardOffset: 3}
+
+	decoded, err := DecodeSearchCursor(EncodeSearchCursor(cursor, testSearchIdentities), testSearchIdentities)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor.IdentityOffset, decoded.IdentityOffset)
+	assert.Equal(t, cursor.CardOffset, decoded.CardOffset)
+}
+
+//
+// EncodeSearchCursor :: for an empty cursor :: returns an empty string.
+//
+func TestEncodeSearchCursorForAnEmptyCursor(t *testing.T) {
+
+	assert.Empty(t, EncodeSearchCursor(nil, testSearchIdentities))
+}
+
+//
+// DecodeSearchCursor :: for incorrect cursors :: returns an error.
+//
+func TestDecodeSearchCursorForIncorrectCursors(t *testing.T) {
+
+	for _, cursor := range []string{
+		"not a base64 string!",
+		"bm90IGEgSlNPTg",
+		EncodeSearchCursor(&model.CardSearchCursor{IdentityOffset: -1}, testSearchIdentities),
+	} {
+		_, err := DecodeSearchCursor(cursor, testSearchIdentities)
+
+		assert.Equal(t, ErrSearchCursorIsIncorrect, err, cursor)
+	}
+}
+
+//
+// DecodeSearchCursor :: for another identities list :: returns an error.
+//
+func TestDecodeSearchCursorForAnotherIdentities(t *testing.T) {
+
+	cursor := EncodeSearchCursor(&model.CardSearchCursor{IdentityOffset: 1}, testSearchIdentities)
+
+	for _, identities := range [][]string{
+		{"alice"},
+		{"bob", "alice"},
+		{"alice", "bob", "carol"},
+		{"alicebob"},
+	} {
+		_, err := DecodeSearchCursor(cursor, identities)
+
+		assert.Equal(t, ErrSearchCursorIdentitiesMismatch, err, identities)
+	}
+}
@@ -0,0 +1,389 @@
+package admin
+
+import (
+	"strings"
+	"time"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// Provider provides an interface to work with the Admin controller.
+//
+type Provider interface {
+	//
+	// CardGet is a handler for GET /admin/card/:card_id request.
+	//
+	CardGet(span tracer.Span, cardID string) (*model.AdminCard, error)
+
+	//
+	// IdentityChains is a handler for GET /admin/chains request.
+	//
+	IdentityChains(span tracer.Span, identity string) ([]*model.CardChainState, error)
+
+	//
+	// ChainForceDelete is a handler for POST /admin/chain/actions/delete request.
+	//
+	ChainForceDelete(span tracer.Span, request *api.ChainForceDeleteRequest) (*model.CardAuditRecord, error)
+
+	//
+	// ApplicationCardCounts is a handler for GET /admin/applications/card-counts request.
+	//
+	ApplicationCardCounts(span tracer.Span) ([]*model.ApplicationCardCount, error)
+
+	//
+	// CardAuditRecords is a handler for GET /admin/audit request.
+	//
+	CardAuditRecords(span tracer.Span, identity, chainID string) ([]*model.CardAuditRecord, error)
+}
+
+//
+// Controller serves the Admin API requests. The requests are not scoped to an application.
+//
+type Controller struct {
+	cardSigner     model.CardSigner
+	cardRepository dao.CardRepositoryProvider
+	cardAuditSink  dao.CardAuditSinkProvider
+}
+
+//
+// New returns an instance of the Admin controller.
+//
+func New(
+	cardSigner model.CardSigner,
+	cardRepository dao.CardRepositoryProvider,
+	cardAuditSink dao.CardAuditSinkProvider,
+) *Controller {
+
+	return &Controller{
+		cardSigner:     cardSigner,
+		cardRepository: cardRepository,
+		cardAuditSink:  cardAuditSink,
+	}
+}
+
+//
+// CardGet is a handler for GET /admin/card/:card_id request.
+// It returns the card of any application along with its chain state.
+//
+func (h *Controller) CardGet(span tracer.Span, cardID string) (*model.AdminCard, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	card, err := h.getCard(span, cardID)
+	if nil != err {
+		return nil, err
+	}
+
+	supersedingCardID, err := h.cardRepository.GetSupersedingCardID(span, card.GetID(), card.GetApplicationID())
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting superseding card ID of card(%s): %+v",
+			card.GetID(), err,
+		)
+	}
+
+	chainDeletedAt, err := h.cardRepository.GetChainDeletedAt(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error checking chain deleted state for chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	return &model.AdminCard{
+		ID:                 card.GetID(),
+		ApplicationID:      card.GetApplicationID(),
+		Identity:           card.GetIdentity(),
+		ChainID:            card.GetChainID(),
+		Version:            card.GetVersion(),
+		CreatedAt:          card.CreatedAt,
+		ExpiresAt:          card.GetExpiresAt(),
+		SuperseedingCardID: supersedingCardID,
+		ChainDeletedAt:     chainDeletedAt,
+		Card:               card,
+	}, nil
+}
+
+//
+// IdentityChains is a handler for GET /admin/chains request.
+// It returns the chains of the identity in all the applications.
+//
+func (h *Controller) IdentityChains(span tracer.Span, identity string) ([]*model.CardChainState, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if "" == identity {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrAdminIdentityIsEmpty)
+	}
+
+	chains, err := h.cardRepository.GetIdentityChains(span, identity)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting chains of identity(%s): %+v",
+			identity, err,
+		)
+	}
+
+	return chains, nil
+}
+
+//
+// ChainForceDelete is a handler for POST /admin/chain/actions/delete request.
+// It deletes the chain of the card without the delete CSR on behalf of the support operator.
+// The chain revocation record is countersigned with the operator reason as its note,
+// the deletion is recorded to the card audit once the chain is deleted with the revocation record saved.
+//
+func (h *Controller) ChainForceDelete(
+	span tracer.Span,
+	request *api.ChainForceDeleteRequest,
+) (*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if "" == strings.TrimSpace(request.GetOperatorID()) {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainOperatorIDIsEmpty)
+	}
+	if "" == strings.TrimSpace(request.GetReason()) {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainOperationReasonIsEmpty)
+	}
+
+	card, err := h.getCard(span, request.GetCardID())
+	if nil != err {
+		return nil, err
+	}
+
+	latestCard, err := h.getLatestChainCard(span, card)
+	if nil != err {
+		return nil, err
+	}
+
+	deletedAt := time.Now().Unix()
+	revocation := model.NewForcedCardRevocation(latestCard, request.GetReason(), deletedAt)
+	if err := h.cardSigner.SignRevocation(span, revocation); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"sign chain(%s) revocation error: %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	isDeletedNow, err := h.cardRepository.SetChainDeleted(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+		deletedAt,
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error trying to mark chain(%s) as deleted: %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	if !isDeletedNow {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainAlreadyDeleted)
+	}
+
+	if err := h.cardRepository.SaveChainRevocation(span, revocation); nil != err {
+		// the chain is not deleted without its revocation record, so its 'Deleted' time is cleared back.
+		if _, rollbackErr := h.cardRepository.RestoreChain(
+			span,
+			card.GetIdentity(),
+			card.GetApplicationID(),
+			card.GetChainID(),
+			nil,
+			deletedAt,
+		); nil != rollbackErr {
+			return nil, api.ErrInternalError.WithMessage(
+				"save chain(%s) revocation error: %+v, roll back chain deleted state error: %+v",
+				card.GetChainID(), err, rollbackErr,
+			)
+		}
+
+		return nil, api.ErrInternalError.WithMessage(
+			"save chain(%s) revocation error: %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	// the record is appended once the chain is deleted, so the audit holds the deletions made only.
+	record := &model.CardAuditRecord{
+		CardID:        latestCard.GetID(),
+		ChainID:       card.GetChainID(),
+		Identity:      card.GetIdentity(),
+		ApplicationID: card.GetApplicationID(),
+		Action:        model.CardAuditActionForceDelete,
+		OperatorID:    request.GetOperatorID(),
+		Reason:        request.GetReason(),
+		CreatedAt:     deletedAt,
+	}
+
+	if err := h.cardAuditSink.AppendCardAuditRecord(span, record); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"chain(%s) is deleted, append force delete audit record error: %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	return record, nil
+}
+
+//
+// getLatestChainCard returns the latest card of the chain of the card given.
+//
+func (h *Controller) getLatestChainCard(span tracer.Span, card *model.CardDTO) (*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	chainCards, err := h.cardRepository.GetChainCards(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting cards of chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	if 0 == len(chainCards) {
+		return card, nil
+	}
+
+	latestCard := *card
+	latestCard.ID = chainCards[len(chainCards)-1].GetID()
+
+	return &latestCard, nil
+}
+
+//
+// ApplicationCardCounts is a handler for GET /admin/applications/card-counts request.
+//
+func (h *Controller) ApplicationCardCounts(span tracer.Span) ([]*model.ApplicationCardCount, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	counts, err := h.cardRepository.CountCardsByApplications(span)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error counting cards by applications: %+v",
+			err,
+		)
+	}
+
+	return counts, nil
+}
+
+//
+// CardAuditRecords is a handler for GET /admin/audit request.
+// It returns the card audit records of either the identity in all the applications or the chain.
+//
+func (h *Controller) CardAuditRecords(
+	span tracer.Span,
+	identity string,
+	chainID string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if ("" == identity) == ("" == chainID) {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrAdminAuditFilterIsIncorrect)
+	}
+
+	if "" != chainID {
+		records, err := h.cardAuditSink.GetCardAuditRecordsByChain(span, chainID)
+		if nil != err {
+			return nil, api.ErrInternalError.WithMessage(
+				"error getting card audit records of chain(%s): %+v",
+				chainID, err,
+			)
+		}
+
+		return records, nil
+	}
+
+	records, err := h.cardAuditSink.GetCardAuditRecordsByIdentity(span, identity)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting card audit records of identity(%s): %+v",
+			identity, err,
+		)
+	}
+
+	return records, nil
+}
+
+//
+// getCard returns the card by its ID regardless of its application.
+//
+func (h *Controller) getCard(span tracer.Span, cardID string) (*model.CardDTO, error) {
+
+	card, err := h.cardRepository.GetCardByID(span, cardID)
+	if nil != err {
+		if err == cassandra.ErrEntityNotFound {
+			return nil, api.ErrNotFound
+		}
+
+		return nil, api.ErrInternalError.WithMessage(
+			"internal error for get card from the database by its ID(%s): %+v",
+			cardID, err,
+		)
+	}
+
+	return card, nil
+}
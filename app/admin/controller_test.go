@@ -0,0 +1,266 @@
+package admin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/test/helper"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// Testing constants.
+//
+const (
+	testAdminApplicationID = "application"
+	testAdminIdentity      = "alice"
+)
+
+//
+// CardGet :: for the card of any application :: returns the card with its chain state.
+//
+func TestCardGetReturnsTheCardOfAnyApplication(t *testing.T) {
+
+	repository := dao.NewMemoryCardRepository()
+	h := getAdminControllerUnderTest(repository)
+
+	card := &model.CardDTO{
+		ID:              "card1",
+		ContentSnapshot: "snapshot card1",
+		Identity:        testAdminIdentity,
+		ApplicationID:   "another application",
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key card1"),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+
+	adminCard, err := h.CardGet(mock.StartNoopSpan(), card.GetID())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "another application", adminCard.ApplicationID)
+	assert.Equal(t, card.GetChainID(), adminCard.ChainID)
+	assert.Zero(t, adminCard.ChainDeletedAt)
+
+	_, err = h.CardGet(mock.StartNoopSpan(), "card2")
+
+	assert.Equal(t, api.ErrNotFound, err)
+}
+
+//
+// IdentityChains :: for the chains of several applications :: returns the chains of the identity.
+//
+func TestIdentityChainsReturnsTheChainsOfAllApplications(t *testing.T) {
+
+	repository := dao.NewMemoryCardRepository()
+	h := getAdminControllerUnderTest(repository)
+
+	for i, applicationID := range []string{testAdminApplicationID, "another application"} {
+		card := &model.CardDTO{
+			ID:              fmt.Sprintf("card%d", i+1),
+			ContentSnapshot: fmt.Sprintf("snapshot card%d", i+1),
+			Identity:        testAdminIdentity,
+			ApplicationID:   applicationID,
+			Version:         model.CardVersion5,
+			PublicKey:       []byte(fmt.Sprintf("public key card%d", i+1)),
+		}
+		assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+		assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+	}
+
+	chains, err := h.IdentityChains(mock.StartNoopSpan(), testAdminIdentity)
+
+	assert.NoError(t, err)
+	if assert.Len(t, chains, 2) {
+		assert.Equal(t, "another application", chains[0].ApplicationID)
+		assert.Equal(t, testAdminApplicationID, chains[1].ApplicationID)
+	}
+
+	_, err = h.IdentityChains(mock.StartNoopSpan(), "")
+
+	assert.Equal(t, api.ErrAdminIdentityIsEmpty, helper.ExtractHTTPError(err))
+}
+
+//
+// ChainForceDelete :: for a not deleted chain :: deletes the chain with the countersigned revocation record once.
+//
+func TestChainForceDeleteDeletesTheChainWithTheRevocation(t *testing.T) {
+
+	repository := dao.NewMemoryCardRepository()
+	h := getAdminControllerUnderTest(repository)
+
+	card := &model.CardDTO{
+		ID:              "card1",
+		ContentSnapshot: "snapshot card1",
+		Identity:        testAdminIdentity,
+		ApplicationID:   testAdminApplicationID,
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key card1"),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+
+	record, err := h.ChainForceDelete(mock.StartNoopSpan(), &api.ChainForceDeleteRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     "reason",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardAuditActionForceDelete, record.Action)
+	assert.Equal(t, card.GetID(), record.CardID)
+	assert.NotEmpty(t, record.ID)
+
+	records, err := h.CardAuditRecords(mock.StartNoopSpan(), "", card.GetChainID())
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.CardAuditRecord{record}, records)
+
+	deletedAt, err := repository.GetChainDeletedAt(
+		mock.StartNoopSpan(),
+		testAdminIdentity,
+		testAdminApplicationID,
+		card.GetChainID(),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, record.CreatedAt, deletedAt)
+
+	revocation, err := repository.GetChainRevocation(
+		mock.StartNoopSpan(),
+		testAdminIdentity,
+		testAdminApplicationID,
+		card.GetChainID(),
+	)
+	assert.NoError(t, err)
+	if assert.NotNil(t, revocation) {
+		assert.Equal(t, card.GetID(), revocation.CardID)
+		assert.Equal(t, "reason", revocation.Note)
+		assert.Equal(t, deletedAt, revocation.RevokedAt)
+		assert.Equal(t, "virgil signature", revocation.Signature)
+	}
+
+	_, err = h.ChainForceDelete(mock.StartNoopSpan(), &api.ChainForceDeleteRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     "reason",
+	})
+
+	assert.Equal(t, api.ErrChainAlreadyDeleted, helper.ExtractHTTPError(err))
+
+	records, err = h.CardAuditRecords(mock.StartNoopSpan(), "", card.GetChainID())
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.CardAuditRecord{record}, records)
+}
+
+//
+// ChainForceDelete :: for an empty operator ID or reason :: returns the error.
+//
+func TestChainForceDeleteRequiresTheOperatorAndReason(t *testing.T) {
+
+	repository := dao.NewMemoryCardRepository()
+	h := getAdminControllerUnderTest(repository)
+
+	card := &model.CardDTO{
+		ID:              "card1",
+		ContentSnapshot: "snapshot card1",
+		Identity:        testAdminIdentity,
+		ApplicationID:   testAdminApplicationID,
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key card1"),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+
+	_, err := h.ChainForceDelete(mock.StartNoopSpan(), &api.ChainForceDeleteRequest{
+		CardID: card.GetID(),
+		Reason: "reason",
+	})
+
+	assert.Equal(t, api.ErrChainOperatorIDIsEmpty, helper.ExtractHTTPError(err))
+
+	_, err = h.ChainForceDelete(mock.StartNoopSpan(), &api.ChainForceDeleteRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     " ",
+	})
+
+	assert.Equal(t, api.ErrChainOperationReasonIsEmpty, helper.ExtractHTTPError(err))
+}
+
+//
+// ApplicationCardCounts :: for the cards of several applications :: returns the counts ordered by the application.
+//
+func TestApplicationCardCountsCountsTheCardsByApplications(t *testing.T) {
+
+	repository := dao.NewMemoryCardRepository()
+	h := getAdminControllerUnderTest(repository)
+
+	for i, applicationID := range []string{testAdminApplicationID, testAdminApplicationID, "another application"} {
+		card := &model.CardDTO{
+			ID:              fmt.Sprintf("card%d", i+1),
+			ContentSnapshot: fmt.Sprintf("snapshot card%d", i+1),
+			Identity:        testAdminIdentity,
+			ApplicationID:   applicationID,
+			Version:         model.CardVersion5,
+			PublicKey:       []byte(fmt.Sprintf("public key card%d", i+1)),
+		}
+		assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+		assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+	}
+
+	counts, err := h.ApplicationCardCounts(mock.StartNoopSpan())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.ApplicationCardCount{
+		{ApplicationID: "another application", Cards: 1},
+		{ApplicationID: testAdminApplicationID, Cards: 2},
+	}, counts)
+}
+
+//
+// CardAuditRecords :: for both or none of the filters :: returns the error.
+//
+func TestCardAuditRecordsRequiresASingleFilter(t *testing.T) {
+
+	h := getAdminControllerUnderTest(dao.NewMemoryCardRepository())
+
+	_, err := h.CardAuditRecords(mock.StartNoopSpan(), "", "")
+
+	assert.Equal(t, api.ErrAdminAuditFilterIsIncorrect, helper.ExtractHTTPError(err))
+
+	_, err = h.CardAuditRecords(mock.StartNoopSpan(), testAdminIdentity, "chain")
+
+	assert.Equal(t, api.ErrAdminAuditFilterIsIncorrect, helper.ExtractHTTPError(err))
+}
+
+//
+// getAdminControllerUnderTest returns the admin controller of the memory repository given.
+//
+func getAdminControllerUnderTest(repository dao.CardRepositoryProvider) *Controller {
+
+	return New(testCardSigner{}, repository, dao.NewMemoryCardAuditSink())
+}
+
+//
+// testCardSigner countersigns the revocations with the fake virgil signature.
+// The rest of the signatures are not made by the admin controller, so they are not implemented.
+//
+type testCardSigner struct {
+	model.CardSigner
+}
+
+//
+// SignRevocation sets the fake revocation signature.
+//
+func (testCardSigner) SignRevocation(span tracer.Span, r *model.CardRevocation) error {
+
+	r.Signature = "virgil signature"
+
+	return nil
+}
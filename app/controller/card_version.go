@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// CardAction is an action the Virgil Card request performs.
+//
+type CardAction string
+
+//
+// Card actions.
+//
+const (
+	CardActionCreate CardAction = "create"
+	CardActionDelete CardAction = "delete"
+)
+
+//
+// CardVersionSpec declares a Virgil Card version: its CSR schema, field validators and signature rules.
+//
+type CardVersionSpec struct {
+	Version string
+
+	//
+	// NewCSR returns a version specific CSR object the content snapshot is unmarshaled to.
+	// nil means the version has the base CSR fields only.
+	//
+	NewCSR func() interface{}
+
+	//
+	// ValidateCSR validates the version specific CSR fields for the card action.
+	// nil means there is no version specific fields to validate.
+	//
+	ValidateCSR func(csr interface{}, action CardAction) error
+
+	//
+	// PrepareCard prepares the stored card of the version to be returned by get.
+	// nil means the card is returned as it is stored.
+	//
+	PrepareCard func(card *model.CardDTO) error
+
+	// CSR stamps amount limits.
+	StampsMinLength int
+	StampsMaxLength int
+}
+
+//
+// CardVersionRegistry holds the supported Virgil Card versions.
+//
+type CardVersionRegistry struct {
+	mutex sync.RWMutex
+	specs map[string]*CardVersionSpec
+}
+
+//
+// NewCardVersionRegistry returns an instance of the card version registry with the versions given.
+//
+func NewCardVersionRegistry(specs ...*CardVersionSpec) (*CardVersionRegistry, error) {
+
+	r := &CardVersionRegistry{
+		specs: make(map[string]*CardVersionSpec, len(specs)),
+	}
+
+	for _, spec := range specs {
+		if err := r.Register(spec); nil != err {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+//
+// NewDefaultCardVersionRegistry returns an instance of the card version registry with all the supported versions.
+//
+func NewDefaultCardVersionRegistry() (*CardVersionRegistry, error) {
+
+	return NewCardVersionRegistry(NewCardVersion5Spec(), NewCardVersion6Spec())
+}
+
+//
+// Register registers the card version. The version replaces the registered one with the same name.
+//
+func (r *CardVersionRegistry) Register(spec *CardVersionSpec) error {
+
+	if nil == spec || "" == spec.Version {
+		return errors.New("card version spec is incomplete")
+	}
+	if 0 >= spec.StampsMinLength || spec.StampsMinLength > spec.StampsMaxLength {
+		return errors.New("card version (%s) stamps limits are incorrect", spec.Version)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.specs[spec.Version] = spec
+
+	return nil
+}
+
+//
+// Get returns the card version spec. Returns false if the version is not registered.
+//
+func (r *CardVersionRegistry) Get(version string) (*CardVersionSpec, bool) {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	spec, ok := r.specs[version]
+
+	return spec, ok
+}
+
+//
+// Versions returns the registered card versions in the ascending order.
+//
+func (r *CardVersionRegistry) Versions() []string {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	versions := make([]string, 0, len(r.specs))
+	for version := range r.specs {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	return versions
+}
+
+//
+// NewCardVersion5Spec returns the 5.0 card version spec.
+//
+func NewCardVersion5Spec() *CardVersionSpec {
+
+	return &CardVersionSpec{
+		Version:         model.CardVersion5,
+		StampsMinLength: CSRStampsListMinLength,
+		StampsMaxLength: CSRStampsListMaxLength,
+	}
+}
+
+//
+// NewCardVersion6Spec returns the 6.0 card version spec.
+//
+func NewCardVersion6Spec() *CardVersionSpec {
+
+	return &CardVersionSpec{
+		Version: model.CardVersion6,
+		NewCSR: func() interface{} {
+			return new(api.CSRV6)
+		},
+		ValidateCSR:     validateCSRV6,
+		PrepareCard:     prepareCardV6,
+		StampsMinLength: CSRStampsListMinLength,
+		StampsMaxLength: CSRStampsListMaxLength,
+	}
+}
+
+//
+// Card 6.0 key algorithms.
+//
+var csrV6KeyAlgorithms = map[string]struct{}{
+	api.KeyAlgorithmED25519:    {},
+	api.KeyAlgorithmCurve25519: {},
+	api.KeyAlgorithmSECP256R1:  {},
+	api.KeyAlgorithmSECP384R1:  {},
+	api.KeyAlgorithmSECP521R1:  {},
+	api.KeyAlgorithmRSA2048:    {},
+	api.KeyAlgorithmRSA4096:    {},
+	api.KeyAlgorithmRSA8192:    {},
+}
+
+//
+// validateCSRV6 validates the 6.0 card version CSR fields.
+//
+func validateCSRV6(csr interface{}, action CardAction) error {
+
+	csrV6, ok := csr.(*api.CSRV6)
+	if !ok {
+		return api.ErrInternalError.WithMessage("unexpected 6.0 CSR type %T", csr)
+	}
+
+	if CardActionDelete == action {
+		if "" != csrV6.GetKeyAlgorithm() {
+			return api.ErrCSRKeyAlgorithmIsIncorrect.WithMessage("key algorithm must be empty for the delete card")
+		}
+		if 0 != csrV6.GetExpiresAt() {
+			return api.ErrCSRExpirationTimeIsIncorrect.WithMessage("expiration time must be empty for the delete card")
+		}
+
+		return nil
+	}
+
+	if "" != csrV6.GetKeyAlgorithm() {
+		if _, ok := csrV6KeyAlgorithms[csrV6.GetKeyAlgorithm()]; !ok {
+			return api.ErrCSRKeyAlgorithmIsIncorrect
+		}
+	}
+	if 0 != csrV6.GetExpiresAt() && csrV6.GetExpiresAt() <= csrV6.GetCreatedAt() {
+		return api.ErrCSRExpirationTimeIsIncorrect
+	}
+
+	return nil
+}
+
+//
+// prepareCardV6 takes the 6.0 card expiration time from its content snapshot signed by the card owner,
+// so the expiration is enforced on get regardless of the stored column.
+//
+func prepareCardV6(card *model.CardDTO) error {
+
+	snapshot, err := base64.StdEncoding.DecodeString(card.GetContentSnapshot())
+	if nil != err {
+		return errors.Wrap(err, errors.New("card (%s) content snapshot decode error", card.GetID()))
+	}
+
+	csr := new(api.CSRV6)
+	if err := json.Unmarshal(snapshot, csr); nil != err {
+		return errors.Wrap(err, errors.New("card (%s) 6.0 content snapshot unmarshal error", card.GetID()))
+	}
+
+	card.ExpiresAt = csr.GetExpiresAt()
+
+	return nil
+}
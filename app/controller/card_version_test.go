@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// NewDefaultCardVersionRegistry :: for the supported card versions :: returns their specs.
+//
+func TestNewDefaultCardVersionRegistry(t *testing.T) {
+
+	registry, err := NewDefaultCardVersionRegistry()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{model.CardVersion5, model.CardVersion6}, registry.Versions())
+
+	for _, version := range []string{model.CardVersion5, model.CardVersion6} {
+		spec, ok := registry.Get(version)
+
+		assert.True(t, ok)
+		assert.Equal(t, version, spec.Version)
+	}
+
+	_, ok := registry.Get("4.0")
+
+	assert.False(t, ok)
+}
+
+//
+// Register :: for incomplete specs :: returns an error.
+//
+func TestCardVersionRegistryRegisterForIncompleteSpecs(t *testing.T) {
+
+	registry := getCardVersionRegistryUnderTest()
+
+	for _, spec := range []*CardVersionSpec{
+		nil,
+		{StampsMinLength: 1, StampsMaxLength: 1},
+		{Version: "7.0"},
+		{Version: "7.0", StampsMinLength: 2, StampsMaxLength: 1},
+	} {
+		assert.Error(t, registry.Register(spec))
+	}
+}
+
+//
+// validateCSRV6 :: for the card create fields :: behaves properly.
+//
+func TestValidateCSRV6ForCreate(t *testing.T) {
+
+	csr := func(keyAlgorithm string, expiresAt int64) *api.CSRV6 {
+		return &api.CSRV6{
+			CSR:          api.CSR{CreatedAt: 100},
+			KeyAlgorithm: keyAlgorithm,
+			ExpiresAt:    expiresAt,
+		}
+	}
+
+	assert.NoError(t, validateCSRV6(csr("", 0), CardActionCreate))
+	assert.NoError(t, validateCSRV6(csr(api.KeyAlgorithmRSA4096, 101), CardActionCreate))
+	assert.Equal(t, api.ErrCSRKeyAlgorithmIsIncorrect, validateCSRV6(csr("dsa", 0), CardActionCreate))
+	assert.Equal(t, api.ErrCSRExpirationTimeIsIncorrect, validateCSRV6(csr("", 100), CardActionCreate))
+}
+
+//
+// validateCSRV6 :: for the card delete fields :: requires them to be empty.
+//
+func TestValidateCSRV6ForDelete(t *testing.T) {
+
+	assert.NoError(t, validateCSRV6(&api.CSRV6{}, CardActionDelete))
+	assert.Error(t, validateCSRV6(&api.CSRV6{KeyAlgorithm: api.KeyAlgorithmED25519}, CardActionDelete))
+	assert.Error(t, validateCSRV6(&api.CSRV6{ExpiresAt: 100}, CardActionDelete))
+}
+
+//
+// prepareCardV6 :: for the 6.0 card :: takes the expiration time from the content snapshot.
+//
+func TestPrepareCardV6(t *testing.T) {
+
+	card := &model.CardDTO{
+		ContentSnapshot: base64.StdEncoding.EncodeToString([]byte(`{"version":"6.0","expires_at":200}`)),
+		ExpiresAt:       100,
+	}
+
+	assert.NoError(t, prepareCardV6(card))
+	assert.Equal(t, int64(200), card.GetExpiresAt())
+
+	assert.Error(t, prepareCardV6(&model.CardDTO{ContentSnapshot: "not a base64 string!"}))
+}
+
+//
+// getCardVersionRegistryUnderTest returns the registry of all the supported card versions.
+//
+func getCardVersionRegistryUnderTest() *CardVersionRegistry {
+
+	registry, err := NewDefaultCardVersionRegistry()
+	if nil != err {
+		panic(err)
+	}
+
+	return registry
+}
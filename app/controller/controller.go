@@ -1,14 +1,19 @@
 package controller
 
 import (
+	"strings"
 	"time"
 
 	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/log"
 	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
 
 	"github.com/VirgilSecurity/virgil-services-cards/src/api"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/events"
 	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/src/translog"
+	"github.com/VirgilSecurity/virgil-services-cards/src/verifier"
 )
 
 //
@@ -23,28 +28,87 @@ type Provider interface {
 	//
 	// CardGet is a handler for GET /card/:card_id request.
 	//
-	CardGet(span tracer.Span, request *api.CardBaseRequest, cardID string) (*model.CardDTO, error)
+	CardGet(span tracer.Span, request *api.CardGetRequest, cardID string) (*model.CardDTO, error)
+
+	//
+	// CardBatchGet is a handler for POST /card/actions/get request.
+	//
+	CardBatchGet(span tracer.Span, request *api.CardBatchGetRequest) (*model.CardBatchGetResult, error)
+
+	//
+	// CardChain is a handler for GET /card/:card_id/chain request.
+	//
+	CardChain(span tracer.Span, request *api.CardChainRequest, cardID string) ([]*model.CardDTO, error)
 
 	//
 	// CardSearch is a handler for POST /card/actions/search request.
 	//
 	CardSearch(span tracer.Span, request *api.CardSearchRequest) ([]*model.CardDTO, error)
 
+	//
+	// CardSearchPage is a handler for paginated POST /card/actions/search request.
+	//
+	CardSearchPage(span tracer.Span, request *api.CardSearchRequest) (*model.CardSearchPage, error)
+
 	//
 	// CardDelete is a handler for POST /card/actions/delete request.
 	//
 	CardDelete(span tracer.Span, request *api.CardDeleteRequest) (*model.CardDTO, error)
+
+	//
+	// CardServiceKeys is a handler for GET /card/service-keys request.
+	//
+	CardServiceKeys(span tracer.Span) ([]*model.ServiceKey, error)
+
+	//
+	// CardVerify is a handler for POST /card/actions/verify request.
+	//
+	CardVerify(span tracer.Span, request *api.CardVerifyRequest) (*model.CardVerifyResult, error)
+
+	//
+	// ChainRestore restores the deleted chain on behalf of the support operator.
+	//
+	ChainRestore(span tracer.Span, request *api.ChainRestoreRequest) (*model.CardAuditRecord, error)
+
+	//
+	// TransparencyTreeHead is a handler for GET /card/transparency/tree-head request.
+	//
+	TransparencyTreeHead(span tracer.Span) (*model.TreeHead, error)
+
+	//
+	// TransparencyInclusionProof is a handler for GET /card/:card_id/transparency/inclusion request.
+	//
+	TransparencyInclusionProof(
+		span tracer.Span,
+		request *api.TransparencyInclusionRequest,
+		cardID string,
+	) (*model.InclusionProof, error)
+
+	//
+	// TransparencyConsistencyProof is a handler for GET /card/transparency/consistency request.
+	//
+	TransparencyConsistencyProof(
+		span tracer.Span,
+		request *api.TransparencyConsistencyRequest,
+	) (*model.ConsistencyProof, error)
 }
 
 //
 // Controller serves the Cards requests.
 //
 type Controller struct {
-	cardSigner          model.CardSigner
-	cardRepository      dao.CardRepositoryProvider
-	createCardValidator CreateCardValidatorProvider
-	searchCardValidator SearchCardValidatorProvider
-	deleteCardValidator DeleteCardValidatorProvider
+	cardSigner            model.CardSigner
+	cardRepository        dao.CardRepositoryProvider
+	createCardValidator   CreateCardValidatorProvider
+	searchCardValidator   SearchCardValidatorProvider
+	deleteCardValidator   DeleteCardValidatorProvider
+	batchGetCardValidator BatchGetCardValidatorProvider
+	cardVersions          *CardVersionRegistry
+	eventMeter            events.EventProvider
+	cardVerifier          verifier.Provider
+	cardAuditSink         dao.CardAuditSinkProvider
+	transparencyLog       translog.Provider
+	logger                log.Logger
 }
 
 //
@@ -56,14 +120,28 @@ func New(
 	createCardValidator CreateCardValidatorProvider,
 	searchCardValidator SearchCardValidatorProvider,
 	deleteCardValidator DeleteCardValidatorProvider,
+	batchGetCardValidator BatchGetCardValidatorProvider,
+	cardVersions *CardVersionRegistry,
+	eventMeter events.EventProvider,
+	cardVerifier verifier.Provider,
+	cardAuditSink dao.CardAuditSinkProvider,
+	transparencyLog translog.Provider,
+	logger log.Logger,
 ) *Controller {
 
 	return &Controller{
-		cardSigner:          cardSigner,
-		cardRepository:      cardRepository,
-		createCardValidator: createCardValidator,
-		searchCardValidator: searchCardValidator,
-		deleteCardValidator: deleteCardValidator,
+		cardSigner:            cardSigner,
+		cardRepository:        cardRepository,
+		createCardValidator:   createCardValidator,
+		searchCardValidator:   searchCardValidator,
+		deleteCardValidator:   deleteCardValidator,
+		batchGetCardValidator: batchGetCardValidator,
+		cardVersions:          cardVersions,
+		eventMeter:            eventMeter,
+		cardVerifier:          cardVerifier,
+		cardAuditSink:         cardAuditSink,
+		transparencyLog:       transparencyLog,
+		logger:                logger,
 	}
 }
 
@@ -126,22 +204,108 @@ func (h *Controller) CardCreate(span tracer.Span, request *api.CardCreateRequest
 		)
 	}
 
+	// the card is kept pending before it is saved, so the drain appends the saved card its append fails for.
+	if err := h.transparencyLog.KeepCardPending(span, virgilCard); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"keep card(%s) pending in the transparency log error: %+v",
+			virgilCard.GetID(), err,
+		)
+	}
+
 	if err := h.cardRepository.SaveCard(span, virgilCard); nil != err {
+		if err == dao.ErrPreviousCardIsSuperseded {
+			return nil, tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardExistsAlready)
+		}
+
 		return nil, api.ErrInternalError.WithMessage(
 			"save card(%s) error: %+v",
 			virgilCard.GetID(), err,
 		)
 	}
 
+	auditAction := model.CardAuditActionCreate
+	if "" != virgilCard.GetPreviousCardID() {
+		auditAction = model.CardAuditActionOverride
+	}
+	h.appendCardAuditRecord(span, request, virgilCard, auditAction, time.Now().Unix())
+	h.appendTransparencyLogCard(span, request, virgilCard)
+
 	return virgilCard, nil
 }
 
+//
+// appendCardAuditRecord appends the audit record of the card mutation made by the request.
+// The mutation is stored already, so the error does not fail the request: it is logged and metered instead.
+//
+func (h *Controller) appendCardAuditRecord(
+	span tracer.Span,
+	request *api.CardBaseRequest,
+	card *model.CardDTO,
+	action string,
+	createdAt int64,
+) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	record := &model.CardAuditRecord{
+		CardID:         card.GetID(),
+		ChainID:        card.GetChainID(),
+		Identity:       card.GetIdentity(),
+		ApplicationID:  card.GetApplicationID(),
+		AccountID:      request.AccountID,
+		PreviousCardID: card.GetPreviousCardID(),
+		Action:         action,
+		CreatedAt:      createdAt,
+	}
+
+	if err := h.cardAuditSink.AppendCardAuditRecord(span, record); nil != err {
+		_ = tracer.SetSpanErrorAndReturn(span, err)
+		h.logger.Error("append card(%s) %s audit record error, the card is saved: %+v", card.GetID(), action, err)
+		h.eventMeter.IncCardAuditAppendError(request.AccountID, request.ApplicationID)
+	}
+}
+
+//
+// appendTransparencyLogCard appends the card signed by the VirgilCards service to the transparency log.
+// It is appended once the card is saved, so the log never holds a card which has not been issued.
+// The card is kept pending already, so the error does not fail the request: it is logged and metered instead,
+// the card is appended by the drain subcommand.
+//
+func (h *Controller) appendTransparencyLogCard(
+	span tracer.Span,
+	request *api.CardBaseRequest,
+	card *model.CardDTO,
+) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if err := h.transparencyLog.AppendCard(span, card); nil != err {
+		_ = tracer.SetSpanErrorAndReturn(span, err)
+		h.logger.Error("append card(%s) to the transparency log error, the card is saved: %+v", card.GetID(), err)
+		h.eventMeter.IncTransparencyLogAppendError(request.AccountID, request.ApplicationID)
+	}
+}
+
 //
 // CardGet is a handler for GET /card/:card_id request.
 //
 func (h *Controller) CardGet(
 	span tracer.Span,
-	request *api.CardBaseRequest,
+	request *api.CardGetRequest,
 	cardID string,
 ) (*model.CardDTO, error) {
 
@@ -173,18 +337,76 @@ func (h *Controller) CardGet(
 		)
 	}
 
+	if request.IsFollowLatest() {
+		if card, err = h.getLatestChainCard(span, card); nil != err {
+			return nil, err
+		}
+	}
+
+	if err := h.prepareCardVersion(card); nil != err {
+		return nil, err
+	}
+
+	if !request.IncludeExpired && card.IsExpired(time.Now()) {
+		h.eventMeter.IncCardExpiredHidden(request.AccountID, request.ApplicationID)
+
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrVirgilCardIsExpired)
+	}
+
 	card.IsSuperseeded, err = h.cardRepository.DoesCardExistByPreviousIDAndScopeID(span, card.ID, card.ApplicationID)
 	if err != nil {
 		return nil, err
 	}
 
+	if card.IsSuperseeded {
+		card.SuperseedingCardID, err = h.getSupersedingCardID(span, card)
+		if nil != err {
+			return nil, err
+		}
+	}
+
+	// Only the replaced cards and the delete cards without a public key may belong to a revoked chain.
+	if !request.IsFollowLatest() && (card.IsSuperseeded || 0 == len(card.GetPublicKey())) {
+		if card.Revocation, err = h.getChainRevocation(span, card); nil != err {
+			return nil, err
+		}
+	}
+
 	return card, nil
 }
 
 //
-// CardSearch is a handler for POST /card/actions/search request.
+// prepareCardVersion prepares the card to be returned by get the way its version declares.
+// The stored card of the version not registered is a data error.
 //
-func (h *Controller) CardSearch(span tracer.Span, request *api.CardSearchRequest) ([]*model.CardDTO, error) {
+func (h *Controller) prepareCardVersion(card *model.CardDTO) error {
+
+	spec, ok := h.cardVersions.Get(card.GetVersion())
+	if !ok {
+		return api.ErrInternalError.WithMessage(
+			"card(%s) version (%s) is not supported",
+			card.GetID(), card.GetVersion(),
+		)
+	}
+
+	if nil == spec.PrepareCard {
+		return nil
+	}
+
+	if err := spec.PrepareCard(card); nil != err {
+		return api.ErrInternalError.WithMessage(
+			"prepare card(%s) of version (%s) error: %+v",
+			card.GetID(), card.GetVersion(), err,
+		)
+	}
+
+	return nil
+}
+
+//
+// getChainRevocation returns the revocation record of the card chain or nil if the chain has not been revoked.
+//
+func (h *Controller) getChainRevocation(span tracer.Span, card *model.CardDTO) (*model.CardRevocation, error) {
 
 	span = span.Tracer().StartSpan(
 		tracer.GetCallerInfo(),
@@ -195,29 +417,26 @@ func (h *Controller) CardSearch(span tracer.Span, request *api.CardSearchRequest
 	)
 	defer span.Finish()
 
-	if err := h.searchCardValidator.Validate(span, request); nil != err {
-		return nil, err
-	}
-
-	virgilCards, err := h.cardRepository.SearchCardsByIdentities(
+	revocation, err := h.cardRepository.GetChainRevocation(
 		span,
-		request.GetIdentities(),
-		request.ApplicationID,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
 	)
 	if nil != err {
 		return nil, api.ErrInternalError.WithMessage(
-			"error searching cards by identities: %+v",
-			err,
+			"error getting revocation of chain(%s): %+v",
+			card.GetChainID(), err,
 		)
 	}
 
-	return virgilCards, nil
+	return revocation, nil
 }
 
 //
-// CardDelete is a handler for POST /card/actions/delete request.
+// getLatestChainCard returns the latest card of the not deleted chain of the card given.
 //
-func (h *Controller) CardDelete(span tracer.Span, request *api.CardDeleteRequest) (*model.CardDTO, error) {
+func (h *Controller) getLatestChainCard(span tracer.Span, card *model.CardDTO) (*model.CardDTO, error) {
 
 	span = span.Tracer().StartSpan(
 		tracer.GetCallerInfo(),
@@ -228,55 +447,845 @@ func (h *Controller) CardDelete(span tracer.Span, request *api.CardDeleteRequest
 	)
 	defer span.Finish()
 
-	// TODO just for backward compatibility for JS SDK. Should be removed in future!.
-	//  This check should be in transport/helper.go:63
-	if request.UserID == "" {
-		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrIdentityHeaderNotSet)
+	isDeleted, err := h.cardRepository.IsChainDeleted(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error checking chain deleted state for chain(%s): %+v",
+			card.GetChainID(), err,
+		)
 	}
 
-	virgilCard := model.NewCardDTO()
-	if err := h.deleteCardValidator.Validate(span, request, virgilCard); nil != err {
-		return nil, err
+	if isDeleted {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrVirgilCardChainIsDeleted)
 	}
 
-	if err := h.cardRepository.SetCardChainID(span, virgilCard); nil != err {
+	chainCards, err := h.cardRepository.GetChainCards(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
 		return nil, api.ErrInternalError.WithMessage(
-			"error trying set chainID for card(%s): %+v",
-			virgilCard.GetID(), err,
+			"error getting cards of chain(%s): %+v",
+			card.GetChainID(), err,
 		)
 	}
 
-	if err := h.cardSigner.SignCardByCardsService(span, virgilCard); nil != err {
+	// the card itself is the latest one until its replacement is stored to the chain.
+	if 0 == len(chainCards) || chainCards[len(chainCards)-1].GetID() == card.GetID() {
+		return card, nil
+	}
+
+	// the chain cards have neither public keys nor versions, so the latest card is read as a whole.
+	latestCardID := chainCards[len(chainCards)-1].GetID()
+	latestCard, err := h.cardRepository.GetCardByID(span, latestCardID)
+	if nil != err {
 		return nil, api.ErrInternalError.WithMessage(
-			"sign card(%s) error: %+v",
-			virgilCard.GetID(), err,
+			"internal error for get card from the database by its ID(%s): %+v",
+			latestCardID, err,
 		)
 	}
 
-	isDeletedNow, err := h.cardRepository.SetChainDeleted(
+	return latestCard, nil
+}
+
+//
+// getSupersedingCardID returns an ID of the card that replaced the card given.
+// The chain is looked through in case the replacement ID has not been stored with the previous card ID.
+//
+func (h *Controller) getSupersedingCardID(span tracer.Span, card *model.CardDTO) (string, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	supersedingCardID, err := h.cardRepository.GetSupersedingCardID(span, card.GetID(), card.GetApplicationID())
+	if nil != err {
+		return "", api.ErrInternalError.WithMessage(
+			"error getting superseding card ID of card(%s): %+v",
+			card.GetID(), err,
+		)
+	}
+
+	if "" != supersedingCardID {
+		return supersedingCardID, nil
+	}
+
+	chainCards, err := h.cardRepository.GetChainCards(
 		span,
-		request.UserID,
-		request.ApplicationID,
-		virgilCard.GetChainID(),
-		time.Now().Unix(),
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return "", api.ErrInternalError.WithMessage(
+			"error getting cards of chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	for _, chainCard := range chainCards {
+		if chainCard.GetPreviousCardID() == card.GetID() {
+			return chainCard.GetID(), nil
+		}
+	}
+
+	return "", nil
+}
+
+//
+// CardBatchGet is a handler for POST /card/actions/get request.
+// It returns the cards found in the request scope and the errors for the rest of the requested card IDs.
+//
+func (h *Controller) CardBatchGet(
+	span tracer.Span,
+	request *api.CardBatchGetRequest,
+) (*model.CardBatchGetResult, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
 	)
+	defer span.Finish()
+
+	if err := h.batchGetCardValidator.Validate(span, request); nil != err {
+		return nil, err
+	}
+
+	cards, err := h.cardRepository.GetCardsByIDs(span, request.CardIDs)
 	if nil != err {
 		return nil, api.ErrInternalError.WithMessage(
-			"error trying to mark chain(%s) as deleted: %+v",
-			virgilCard.GetChainID(), err,
+			"error getting cards by IDs: %+v",
+			err,
 		)
 	}
 
-	if !isDeletedNow {
-		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainAlreadyDeleted)
+	foundCards := make(map[string]*model.CardDTO, len(cards))
+	for _, card := range cards {
+		foundCards[card.GetID()] = card
 	}
 
-	if err := h.cardRepository.SaveCard(span, virgilCard); nil != err {
+	result := &model.CardBatchGetResult{
+		Cards:  make(map[string]*model.CardDTO, len(cards)),
+		Errors: make([]*model.CardBatchGetError, 0),
+	}
+	now := time.Now()
+	isExpiredHidden := false
+	processed := make(map[string]struct{}, len(request.CardIDs))
+	for _, cardID := range request.CardIDs {
+		if _, ok := processed[cardID]; ok {
+			continue
+		}
+		processed[cardID] = struct{}{}
+
+		card, ok := foundCards[cardID]
+		switch {
+		case !ok:
+			result.Errors = append(result.Errors, &model.CardBatchGetError{
+				CardID: cardID,
+				Error:  model.CardBatchGetErrorNotFound,
+			})
+		case !card.DoesScopeMatch(request.ApplicationID):
+			result.Errors = append(result.Errors, &model.CardBatchGetError{
+				CardID: cardID,
+				Error:  model.CardBatchGetErrorWrongScope,
+			})
+		case !request.IncludeExpired && card.IsExpired(now):
+			result.Errors = append(result.Errors, &model.CardBatchGetError{
+				CardID: cardID,
+				Error:  model.CardBatchGetErrorExpired,
+			})
+			isExpiredHidden = true
+		default:
+			result.Cards[cardID] = card
+		}
+	}
+
+	// the event counts the responses the expired cards are hidden from, so it is pushed once.
+	if isExpiredHidden {
+		h.eventMeter.IncCardExpiredHidden(request.AccountID, request.ApplicationID)
+	}
+
+	return result, nil
+}
+
+//
+// CardChain is a handler for GET /card/:card_id/chain request.
+// It returns the whole chain of the card ordered from the root card to the latest one.
+// The expired cards of the chain are returned only if they are requested.
+//
+func (h *Controller) CardChain(
+	span tracer.Span,
+	request *api.CardChainRequest,
+	cardID string,
+) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	card, err := h.cardRepository.GetCardByID(span, cardID)
+	if nil != err {
+		if err == cassandra.ErrEntityNotFound {
+			return nil, api.ErrNotFound
+		}
+
 		return nil, api.ErrInternalError.WithMessage(
-			"save card(%s) error: %+v",
-			virgilCard.GetID(), err,
+			"internal error for get card from the database by its ID(%s): %+v",
+			cardID, err,
 		)
 	}
 
-	return virgilCard, nil
+	if !card.DoesScopeMatch(request.ApplicationID) {
+		return nil, tracer.SetSpanErrorAndReturn(
+			span,
+			api.ErrVirgilCardApplicationIDIsNotInTheAuthApplicationList,
+		)
+	}
+
+	chainCards, err := h.cardRepository.GetChainCards(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting cards of chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	revocation, err := h.getChainRevocation(span, card)
+	if nil != err {
+		return nil, err
+	}
+	chainCards = h.filterExpiredCards(request.Headers, request.IncludeExpired, chainCards)
+	for _, chainCard := range chainCards {
+		chainCard.Revocation = revocation
+	}
+
+	return chainCards, nil
+}
+
+//
+// CardSearch is a handler for POST /card/actions/search request.
+//
+func (h *Controller) CardSearch(span tracer.Span, request *api.CardSearchRequest) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if err := h.searchCardValidator.Validate(span, request); nil != err {
+		return nil, err
+	}
+
+	if request.LatestOnly {
+		latestCards, err := h.cardRepository.SearchLatestCardsByIdentities(
+			span,
+			request.GetIdentities(),
+			request.ApplicationID,
+			request.IncludeRevoked,
+		)
+		if nil != err {
+			return nil, api.ErrInternalError.WithMessage(
+				"error searching latest cards by identities: %+v",
+				err,
+			)
+		}
+
+		latestCards = h.filterExpiredCards(request.Headers, request.IncludeExpired, latestCards)
+		if err := h.attachChainRevocations(span, request, latestCards); nil != err {
+			return nil, err
+		}
+
+		return latestCards, nil
+	}
+
+	virgilCards, err := h.cardRepository.SearchCardsByIdentities(
+		span,
+		request.GetIdentities(),
+		request.ApplicationID,
+		request.IncludeRevoked,
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error searching cards by identities: %+v",
+			err,
+		)
+	}
+
+	virgilCards = h.filterExpiredCards(request.Headers, request.IncludeExpired, virgilCards)
+	if err := h.attachChainRevocations(span, request, virgilCards); nil != err {
+		return nil, err
+	}
+
+	return virgilCards, nil
+}
+
+//
+// CardSearchPage is a handler for paginated POST /card/actions/search request.
+//
+func (h *Controller) CardSearchPage(span tracer.Span, request *api.CardSearchRequest) (*model.CardSearchPage, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if err := h.searchCardValidator.Validate(span, request); nil != err {
+		return nil, err
+	}
+
+	cursor, err := api.DecodeSearchCursor(request.Cursor, request.GetIdentities())
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	limit := request.Limit
+	if 0 == limit {
+		limit = searchPageSizeDefault
+	}
+
+	page, err := h.cardRepository.SearchCardsByIdentitiesPage(
+		span,
+		request.GetIdentities(),
+		request.ApplicationID,
+		request.IncludeRevoked,
+		cursor,
+		limit,
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error searching cards page by identities: %+v",
+			err,
+		)
+	}
+	page.Cards = h.filterExpiredCards(request.Headers, request.IncludeExpired, page.Cards)
+	page.NextCursor = api.EncodeSearchCursor(page.Next, request.GetIdentities())
+
+	if err := h.attachChainRevocations(span, request, page.Cards); nil != err {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+//
+// attachChainRevocations sets the revocation records of the deleted chains to the found cards of the chains.
+// The cards of the deleted chains are found if the revoked cards are requested only.
+// The revocations are searched by the identities of the cards found, not by all the identities requested.
+//
+func (h *Controller) attachChainRevocations(
+	span tracer.Span,
+	request *api.CardSearchRequest,
+	cards []*model.CardDTO,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if !request.IncludeRevoked || 0 == len(cards) {
+		return nil
+	}
+
+	var (
+		identities   = make([]string, 0, len(cards))
+		isIdentityIn = make(map[string]bool, len(cards))
+	)
+	for _, card := range cards {
+		if !isIdentityIn[card.GetIdentity()] {
+			isIdentityIn[card.GetIdentity()] = true
+			identities = append(identities, card.GetIdentity())
+		}
+	}
+
+	revocations, err := h.cardRepository.SearchChainRevocationsByIdentities(
+		span,
+		identities,
+		request.ApplicationID,
+	)
+	if nil != err {
+		return api.ErrInternalError.WithMessage(
+			"error searching chain revocations by identities: %+v",
+			err,
+		)
+	}
+
+	chainRevocations := make(map[string]*model.CardRevocation, len(revocations))
+	for _, revocation := range revocations {
+		chainRevocations[revocation.ChainID] = revocation
+	}
+	for _, card := range cards {
+		card.Revocation = chainRevocations[card.GetChainID()]
+	}
+
+	return nil
+}
+
+//
+// filterExpiredCards returns the cards without the expired ones unless they are requested.
+// The hidden expired cards are metered once per response.
+//
+func (h *Controller) filterExpiredCards(
+	headers *api.Headers,
+	includeExpired bool,
+	cards []*model.CardDTO,
+) []*model.CardDTO {
+
+	if includeExpired {
+		return cards
+	}
+
+	var (
+		now          = time.Now()
+		currentCards = make([]*model.CardDTO, 0, len(cards))
+	)
+	for _, card := range cards {
+		if card.IsExpired(now) {
+			continue
+		}
+		currentCards = append(currentCards, card)
+	}
+
+	if len(currentCards) != len(cards) {
+		h.eventMeter.IncCardExpiredHidden(headers.AccountID, headers.ApplicationID)
+	}
+
+	return currentCards
+}
+
+//
+// CardDelete is a handler for POST /card/actions/delete request.
+//
+func (h *Controller) CardDelete(span tracer.Span, request *api.CardDeleteRequest) (*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	// TODO just for backward compatibility for JS SDK. Should be removed in future!.
+	//  This check should be in transport/helper.go:63
+	if request.UserID == "" {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrIdentityHeaderNotSet)
+	}
+
+	virgilCard := model.NewCardDTO()
+	if err := h.deleteCardValidator.Validate(span, request, virgilCard); nil != err {
+		return nil, err
+	}
+
+	if err := h.cardRepository.SetCardChainID(span, virgilCard); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error trying set chainID for card(%s): %+v",
+			virgilCard.GetID(), err,
+		)
+	}
+
+	if err := h.cardSigner.SignCardByCardsService(span, virgilCard); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"sign card(%s) error: %+v",
+			virgilCard.GetID(), err,
+		)
+	}
+
+	revocation := model.NewCardRevocation(virgilCard, time.Now().Unix())
+	if err := h.cardSigner.SignRevocation(span, revocation); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"sign chain(%s) revocation error: %+v",
+			virgilCard.GetChainID(), err,
+		)
+	}
+	// the revocation record is saved along with the delete card.
+	virgilCard.Revocation = revocation
+
+	// the card is kept pending before the chain is changed, so the drain appends the card its append fails for.
+	if err := h.transparencyLog.KeepCardPending(span, virgilCard); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"keep card(%s) pending in the transparency log error: %+v",
+			virgilCard.GetID(), err,
+		)
+	}
+
+	// the previous card is replaced before the chain is deleted, so the superseded delete card never deletes the chain.
+	if err := h.cardRepository.ReplacePreviousCard(span, virgilCard); nil != err {
+		if err == dao.ErrPreviousCardIsSuperseded {
+			return nil, tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardExistsAlready)
+		}
+
+		return nil, api.ErrInternalError.WithMessage(
+			"error trying to replace previous card(%s) by card(%s): %+v",
+			virgilCard.GetPreviousCardID(), virgilCard.GetID(), err,
+		)
+	}
+
+	isDeletedNow, err := h.cardRepository.SetChainDeleted(
+		span,
+		request.UserID,
+		request.ApplicationID,
+		virgilCard.GetChainID(),
+		revocation.RevokedAt,
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error trying to mark chain(%s) as deleted: %+v",
+			virgilCard.GetChainID(), err,
+		)
+	}
+
+	if !isDeletedNow {
+		// the chain has not been deleted by the card, so the previous card is left to be replaced by another card.
+		if err := h.cardRepository.RevertPreviousCardReplacement(span, virgilCard); nil != err {
+			return nil, api.ErrInternalError.WithMessage(
+				"error trying to revert previous card(%s) replacement by card(%s): %+v",
+				virgilCard.GetPreviousCardID(), virgilCard.GetID(), err,
+			)
+		}
+
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainAlreadyDeleted)
+	}
+
+	// the previous card is replaced by the card already, so the card save is not superseded.
+	if err := h.cardRepository.SaveCard(span, virgilCard); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"save card(%s) error: %+v",
+			virgilCard.GetID(), err,
+		)
+	}
+
+	h.appendCardAuditRecord(span, request, virgilCard, model.CardAuditActionDelete, revocation.RevokedAt)
+	h.appendTransparencyLogCard(span, request, virgilCard)
+
+	return virgilCard, nil
+}
+
+//
+// CardServiceKeys is a handler for GET /card/service-keys request.
+// It returns the active service key the cards are signed with and the retired ones to verify the older cards.
+//
+func (h *Controller) CardServiceKeys(span tracer.Span) ([]*model.ServiceKey, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	return h.cardSigner.GetServiceKeys(), nil
+}
+
+//
+// CardVerify is a handler for POST /card/actions/verify request.
+// It verifies the card given without looking it up in the database.
+//
+func (h *Controller) CardVerify(
+	span tracer.Span,
+	request *api.CardVerifyRequest,
+) (*model.CardVerifyResult, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	card := model.NewCardDTO()
+	card.ID = request.CardID
+	card.ContentSnapshot = request.ContentSnapshot
+	card.Signatures = request.Signatures
+
+	result, err := h.cardVerifier.Verify(card)
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	return result, nil
+}
+
+//
+// TransparencyTreeHead is a handler for GET /card/transparency/tree-head request.
+// It returns the head of the current transparency log tree signed by the VirgilCards service.
+//
+func (h *Controller) TransparencyTreeHead(span tracer.Span) (*model.TreeHead, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	content, err := h.transparencyLog.TreeHead(span)
+	if nil != err {
+		return nil, err
+	}
+	content.Timestamp = time.Now().Unix()
+
+	treeHead := &model.TreeHead{TreeHeadContent: *content}
+	if err := h.cardSigner.SignTreeHead(span, treeHead); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"sign tree head (%d) error: %+v",
+			treeHead.TreeSize, err,
+		)
+	}
+
+	return treeHead, nil
+}
+
+//
+// TransparencyInclusionProof is a handler for GET /card/:card_id/transparency/inclusion request.
+// It returns the proof of the card inclusion into the transparency log tree of the requested size.
+//
+func (h *Controller) TransparencyInclusionProof(
+	span tracer.Span,
+	request *api.TransparencyInclusionRequest,
+	cardID string,
+) (*model.InclusionProof, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	proof, err := h.transparencyLog.InclusionProof(span, cardID, request.TreeSize)
+	if nil != err {
+		return nil, err
+	}
+
+	if proof.ApplicationID != request.ApplicationID {
+		return nil, tracer.SetSpanErrorAndReturn(
+			span,
+			api.ErrVirgilCardApplicationIDIsNotInTheAuthApplicationList,
+		)
+	}
+
+	return proof, nil
+}
+
+//
+// TransparencyConsistencyProof is a handler for GET /card/transparency/consistency request.
+// It returns the proof the transparency log tree of the first size is a prefix of the tree of the second size,
+// so the clients holding both tree heads detect the split view.
+//
+func (h *Controller) TransparencyConsistencyProof(
+	span tracer.Span,
+	request *api.TransparencyConsistencyRequest,
+) (*model.ConsistencyProof, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	return h.transparencyLog.ConsistencyProof(span, request.FirstTreeSize, request.SecondTreeSize)
+}
+
+//
+// ChainRestore restores the deleted chain of the card on behalf of the support operator.
+// The delete card is unlinked from the chain, so the card it deleted becomes the latest one again.
+// The restore is recorded to the card audit along with the operator identity and reason once the chain is restored.
+//
+func (h *Controller) ChainRestore(
+	span tracer.Span,
+	request *api.ChainRestoreRequest,
+) (*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	if "" == strings.TrimSpace(request.GetOperatorID()) {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainOperatorIDIsEmpty)
+	}
+	if "" == strings.TrimSpace(request.GetReason()) {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrChainOperationReasonIsEmpty)
+	}
+
+	card, err := h.cardRepository.GetCardByID(span, request.GetCardID())
+	if nil != err {
+		if err == cassandra.ErrEntityNotFound {
+			return nil, api.ErrNotFound
+		}
+
+		return nil, api.ErrInternalError.WithMessage(
+			"internal error for get card from the database by its ID(%s): %+v",
+			request.GetCardID(), err,
+		)
+	}
+
+	deletedAt, err := h.cardRepository.GetChainDeletedAt(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error checking chain deleted state for chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	if 0 >= deletedAt {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrVirgilCardChainIsNotDeleted)
+	}
+
+	deleteCard, err := h.getChainDeleteCard(span, card)
+	if nil != err {
+		return nil, err
+	}
+
+	isRestoredNow, err := h.cardRepository.RestoreChain(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+		deleteCard,
+		deletedAt,
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error trying to restore chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	// the chain has been restored by another operator since its deleted state was read.
+	if !isRestoredNow {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrVirgilCardChainIsNotDeleted)
+	}
+
+	// the record is appended once the chain is restored, so the audit holds the restores made only.
+	record := &model.CardAuditRecord{
+		CardID:        card.GetID(),
+		ChainID:       card.GetChainID(),
+		Identity:      card.GetIdentity(),
+		ApplicationID: card.GetApplicationID(),
+		Action:        model.CardAuditActionRestore,
+		OperatorID:    request.GetOperatorID(),
+		Reason:        request.GetReason(),
+		CreatedAt:     time.Now().Unix(),
+	}
+	if nil != deleteCard {
+		record.CardID, record.PreviousCardID = deleteCard.GetID(), deleteCard.GetPreviousCardID()
+	}
+
+	if err := h.cardAuditSink.AppendCardAuditRecord(span, record); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"chain(%s) is restored, append restore audit record error: %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	return record, nil
+}
+
+//
+// getChainDeleteCard returns the delete card of the deleted chain of the card given.
+// Returns nil if the chain was deleted without the delete card being saved.
+//
+func (h *Controller) getChainDeleteCard(span tracer.Span, card *model.CardDTO) (*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentController,
+		},
+	)
+	defer span.Finish()
+
+	chainCards, err := h.cardRepository.GetChainCards(
+		span,
+		card.GetIdentity(),
+		card.GetApplicationID(),
+		card.GetChainID(),
+	)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting cards of chain(%s): %+v",
+			card.GetChainID(), err,
+		)
+	}
+
+	// the delete card goes the last one in the deleted chain.
+	if 0 == len(chainCards) || "" == chainCards[len(chainCards)-1].GetPreviousCardID() {
+		return nil, nil
+	}
+	latestCard := chainCards[len(chainCards)-1]
+
+	// the chain cards have no public keys, the delete card is the one without it.
+	latestCardWithKey, err := h.cardRepository.GetCardByID(span, latestCard.GetID())
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"internal error for get card from the database by its ID(%s): %+v",
+			latestCard.GetID(), err,
+		)
+	}
+	if 0 != len(latestCardWithKey.GetPublicKey()) {
+		return nil, nil
+	}
+
+	return latestCard, nil
 }
@@ -0,0 +1,906 @@
+package controller
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/log"
+	"github.com/VirgilSecurity/virgil-services-core-kit/test/helper"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/events"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/src/translog"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// Testing constants.
+//
+const (
+	testControllerApplicationID = "application"
+	testControllerIdentity      = "alice"
+)
+
+//
+// CardBatchGet :: for found, foreign and not found cards :: returns the found cards keyed by their IDs.
+//
+func TestCardBatchGetKeysCardsByTheirIDs(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	saveTestControllerCard(t, deps.cardRepository, "card2", "another application")
+
+	result, err := h.CardBatchGet(mock.StartNoopSpan(), &api.CardBatchGetRequest{
+		Headers: &api.Headers{ApplicationID: testControllerApplicationID},
+		CardIDs: []string{"card1", "card2", "card3", "card1"},
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Cards, 1) {
+		assert.Equal(t, "snapshot card1", result.Cards["card1"].GetContentSnapshot())
+	}
+	assert.Equal(t, []*model.CardBatchGetError{
+		{CardID: "card2", Error: model.CardBatchGetErrorWrongScope},
+		{CardID: "card3", Error: model.CardBatchGetErrorNotFound},
+	}, result.Errors)
+}
+
+//
+// CardGet :: for the 6.0 card expired by its content snapshot :: dispatches on the version and hides the card.
+//
+func TestCardGetDispatchesOnTheCardVersion(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	card.Version = model.CardVersion6
+	card.ContentSnapshot = base64.StdEncoding.EncodeToString([]byte(`{"version":"6.0","expires_at":100}`))
+	assert.NoError(t, deps.cardRepository.SaveCard(mock.StartNoopSpan(), card))
+
+	request := &api.CardGetRequest{Headers: &api.Headers{ApplicationID: testControllerApplicationID}}
+	_, err := h.CardGet(mock.StartNoopSpan(), request, card.GetID())
+
+	assert.Equal(t, api.ErrVirgilCardIsExpired, helper.ExtractHTTPError(err))
+
+	request.IncludeExpired = true
+	expiredCard, err := h.CardGet(mock.StartNoopSpan(), request, card.GetID())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), expiredCard.GetExpiresAt())
+}
+
+//
+// CardBatchGet :: for an expired card :: reports the card expired unless the expired cards are requested.
+//
+func TestCardBatchGetHidesExpiredCards(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	card.ExpiresAt = 100
+	assert.NoError(t, deps.cardRepository.SaveCard(mock.StartNoopSpan(), card))
+
+	request := &api.CardBatchGetRequest{
+		Headers: &api.Headers{ApplicationID: testControllerApplicationID},
+		CardIDs: []string{"card1"},
+	}
+	result, err := h.CardBatchGet(mock.StartNoopSpan(), request)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Cards)
+	assert.Equal(t, []*model.CardBatchGetError{
+		{CardID: "card1", Error: model.CardBatchGetErrorExpired},
+	}, result.Errors)
+	assert.Equal(t, 1, deps.eventMeter.counts["CardExpiredHidden"])
+
+	request.IncludeExpired = true
+	result, err = h.CardBatchGet(mock.StartNoopSpan(), request)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Cards, 1)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, deps.eventMeter.counts["CardExpiredHidden"])
+}
+
+//
+// CardChain :: for a chain with an expired card :: hides the card unless the expired cards are requested.
+//
+func TestCardChainHidesExpiredCards(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	card.ExpiresAt = 100
+	assert.NoError(t, deps.cardRepository.SaveCard(mock.StartNoopSpan(), card))
+
+	request := &api.CardChainRequest{Headers: &api.Headers{ApplicationID: testControllerApplicationID}}
+	cards, err := h.CardChain(mock.StartNoopSpan(), request, card.GetID())
+
+	assert.NoError(t, err)
+	assert.Empty(t, cards)
+
+	request.IncludeExpired = true
+	cards, err = h.CardChain(mock.StartNoopSpan(), request, card.GetID())
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 1)
+}
+
+//
+// CardServiceKeys :: for the active and retired service keys :: returns the keys of the signer.
+//
+func TestCardServiceKeysReturnsTheSignerKeys(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	deps.cardSigner.serviceKeys = []*model.ServiceKey{
+		{KeyID: "active key id", PublicKey: "active public key", Status: model.ServiceKeyStatusActive},
+		{KeyID: "retired key id", PublicKey: "retired public key", Status: model.ServiceKeyStatusRetired},
+	}
+	h := deps.getControllerUnderTest()
+
+	serviceKeys, err := h.CardServiceKeys(mock.StartNoopSpan())
+
+	assert.NoError(t, err)
+	assert.Equal(t, deps.cardSigner.serviceKeys, serviceKeys)
+}
+
+//
+// CardDelete :: for the previous card replaced already :: returns the conflict and does not delete the chain.
+//
+func TestCardDeleteDoesNotDeleteTheChainOfSupersededCard(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	replacement := *card
+	replacement.ID = "card2"
+	replacement.PreviousCardID = card.GetID()
+	assert.NoError(t, deps.cardRepository.SaveCard(mock.StartNoopSpan(), &replacement))
+
+	deleteCard := replacement
+	deleteCard.ID = "card3"
+	deleteCard.PublicKey = nil
+	deps.deleteValidator.card = &deleteCard
+
+	_, err := h.CardDelete(mock.StartNoopSpan(), &api.CardDeleteRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+
+	assert.Equal(t, api.ErrPreviousVirgilCardExistsAlready, helper.ExtractHTTPError(err))
+
+	isDeleted, err := deps.cardRepository.IsChainDeleted(
+		mock.StartNoopSpan(),
+		testControllerIdentity,
+		testControllerApplicationID,
+		card.GetChainID(),
+	)
+	assert.NoError(t, err)
+	assert.False(t, isDeleted)
+}
+
+//
+// CardDelete :: for an already deleted chain :: returns the conflict and reverts the previous card replacement.
+//
+func TestCardDeleteRevertsThePreviousCardReplacementOfDeletedChain(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	_, err := deps.cardRepository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testControllerIdentity,
+		testControllerApplicationID,
+		card.GetChainID(),
+		1515686245,
+	)
+	assert.NoError(t, err)
+
+	deleteCard := *card
+	deleteCard.ID = "card2"
+	deleteCard.PreviousCardID = card.GetID()
+	deleteCard.PublicKey = nil
+	deps.deleteValidator.card = &deleteCard
+
+	_, err = h.CardDelete(mock.StartNoopSpan(), &api.CardDeleteRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+
+	assert.Equal(t, api.ErrChainAlreadyDeleted, helper.ExtractHTTPError(err))
+
+	supersedingCardID, err := deps.cardRepository.GetSupersedingCardID(
+		mock.StartNoopSpan(),
+		card.GetID(),
+		testControllerApplicationID,
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, supersedingCardID)
+}
+
+//
+// CardSearch :: for a deleted chain :: returns its cards with the revocation record if the revoked cards are requested.
+//
+func TestCardSearchAttachesTheRevocationsOfDeletedChains(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	deleteCard := *card
+	deleteCard.ID = "card2"
+	deleteCard.PreviousCardID = card.GetID()
+	deleteCard.PublicKey = nil
+	deleteCard.RevocationReason = model.RevocationReasonKeyCompromise
+	deps.deleteValidator.card = &deleteCard
+
+	deleted, err := h.CardDelete(mock.StartNoopSpan(), &api.CardDeleteRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+	assert.NoError(t, err)
+
+	request := &api.CardSearchRequest{
+		Headers:  &api.Headers{ApplicationID: testControllerApplicationID},
+		Identity: testControllerIdentity,
+	}
+	cards, err := h.CardSearch(mock.StartNoopSpan(), request)
+
+	assert.NoError(t, err)
+	assert.Empty(t, cards)
+
+	request.IncludeRevoked = true
+	cards, err = h.CardSearch(mock.StartNoopSpan(), request)
+
+	assert.NoError(t, err)
+	if assert.Len(t, cards, 2) {
+		for _, c := range cards {
+			assert.Equal(t, deleted.Revocation, c.Revocation)
+		}
+		assert.Equal(t, model.RevocationReasonKeyCompromise, cards[0].Revocation.Reason)
+	}
+}
+
+//
+// CardSearchPage :: for the revoked cards of many identities :: searches the revocations of the page identities only.
+//
+func TestCardSearchPageSearchesTheRevocationsOfThePageIdentities(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+
+	page, err := h.CardSearchPage(mock.StartNoopSpan(), &api.CardSearchRequest{
+		Headers:        &api.Headers{ApplicationID: testControllerApplicationID},
+		Identities:     []string{testControllerIdentity, "bob", "carol"},
+		IncludeRevoked: true,
+		Limit:          1,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Cards, 1)
+	assert.Equal(t, []string{testControllerIdentity}, deps.repository.revocationIdentities)
+}
+
+//
+// ChainRestore :: for a deleted chain :: restores the chain and records the restore.
+//
+func TestChainRestoreRestoresTheDeletedChain(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := deleteTestControllerChain(t, deps, h)
+
+	record, err := h.ChainRestore(mock.StartNoopSpan(), &api.ChainRestoreRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     "reason",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardAuditActionRestore, record.Action)
+	assert.Equal(t, "card2", record.CardID)
+	assert.Equal(t, card.GetID(), record.PreviousCardID)
+	assert.Equal(t, "operator", record.OperatorID)
+
+	records, err := deps.cardAuditSink.GetCardAuditRecordsByChain(mock.StartNoopSpan(), card.GetChainID())
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, record, records[1])
+	}
+
+	isDeleted, err := deps.cardRepository.IsChainDeleted(
+		mock.StartNoopSpan(),
+		testControllerIdentity,
+		testControllerApplicationID,
+		card.GetChainID(),
+	)
+	assert.NoError(t, err)
+	assert.False(t, isDeleted)
+}
+
+//
+// ChainRestore :: for a restore error :: returns the error without recording the restore.
+//
+func TestChainRestoreDoesNotRecordTheRestoreOnRestoreError(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := deleteTestControllerChain(t, deps, h)
+	deps.repository.restoreErr = errors.New("restore error")
+
+	_, err := h.ChainRestore(mock.StartNoopSpan(), &api.ChainRestoreRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     "reason",
+	})
+
+	assert.Error(t, err)
+
+	records, err := deps.cardAuditSink.GetCardAuditRecordsByChain(mock.StartNoopSpan(), card.GetChainID())
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+//
+// ChainRestore :: for the chain restored by another operator :: returns the conflict without recording the restore.
+//
+func TestChainRestoreDoesNotRecordTheRestoreOfAnotherOperator(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := deleteTestControllerChain(t, deps, h)
+	deps.repository.isRestoredConcurrently = true
+
+	_, err := h.ChainRestore(mock.StartNoopSpan(), &api.ChainRestoreRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     "reason",
+	})
+
+	assert.Equal(t, api.ErrVirgilCardChainIsNotDeleted, helper.ExtractHTTPError(err))
+
+	records, err := deps.cardAuditSink.GetCardAuditRecordsByChain(mock.StartNoopSpan(), card.GetChainID())
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+//
+// ChainRestore :: for an audit append error :: returns the error with the chain restored.
+//
+func TestChainRestoreReturnsTheAuditAppendErrorOfRestoredChain(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := deleteTestControllerChain(t, deps, h)
+	deps.cardAuditSink.appendErr = errors.New("append error")
+
+	_, err := h.ChainRestore(mock.StartNoopSpan(), &api.ChainRestoreRequest{
+		CardID:     card.GetID(),
+		OperatorID: "operator",
+		Reason:     "reason",
+	})
+
+	assert.Error(t, err)
+
+	isDeleted, err := deps.cardRepository.IsChainDeleted(
+		mock.StartNoopSpan(),
+		testControllerIdentity,
+		testControllerApplicationID,
+		card.GetChainID(),
+	)
+	assert.NoError(t, err)
+	assert.False(t, isDeleted)
+}
+
+//
+// CardCreate :: for an audit append error :: saves the card and logs and meters the error.
+//
+func TestCardCreateSavesTheCardOnAuditAppendError(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	deps.createValidator.card = &model.CardDTO{
+		ID:              "card1",
+		ContentSnapshot: "snapshot card1",
+		Identity:        testControllerIdentity,
+		ApplicationID:   testControllerApplicationID,
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key card1"),
+	}
+	deps.cardAuditSink.appendErr = errors.New("append error")
+
+	card, err := h.CardCreate(mock.StartNoopSpan(), &api.CardCreateRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "card1", card.GetID())
+	assert.Equal(t, 1, deps.eventMeter.counts["CardAuditAppendError"])
+	assert.Len(t, deps.logger.errors, 1)
+
+	_, err = deps.cardRepository.GetCardByID(mock.StartNoopSpan(), "card1")
+	assert.NoError(t, err)
+}
+
+//
+// CardCreate :: for a superseded previous card :: does not append the card not saved to the transparency log.
+//
+func TestCardCreateDoesNotAppendTheCardNotSavedToTheTransparencyLog(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	replacement := *card
+	replacement.ID = "card2"
+	replacement.PreviousCardID = card.GetID()
+	assert.NoError(t, deps.cardRepository.SaveCard(mock.StartNoopSpan(), &replacement))
+
+	superseding := replacement
+	superseding.ID = "card3"
+	deps.createValidator.card = &superseding
+
+	_, err := h.CardCreate(mock.StartNoopSpan(), &api.CardCreateRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+
+	assert.Equal(t, api.ErrPreviousVirgilCardExistsAlready, helper.ExtractHTTPError(err))
+
+	treeHead, err := deps.transparencyLog.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), treeHead.TreeSize)
+}
+
+//
+// CardDelete :: for a deleted chain :: appends the saved delete card to the transparency log.
+//
+func TestCardDeleteAppendsTheDeleteCardToTheTransparencyLog(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	deleteTestControllerChain(t, deps, h)
+
+	proof, err := deps.transparencyLog.InclusionProof(mock.StartNoopSpan(), "card2", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), proof.LeafIndex)
+	assert.Equal(t, int64(1), proof.TreeSize)
+	assert.Equal(t, "virgil signature", proof.Leaf.Signature)
+}
+
+//
+// CardCreate :: for the card not kept pending in the transparency log :: fails before the card is saved.
+//
+func TestCardCreateFailsForTheCardNotKeptPendingInTheTransparencyLog(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	deps.createValidator.card = &model.CardDTO{
+		ID:              "card1",
+		ContentSnapshot: "snapshot card1",
+		Identity:        testControllerIdentity,
+		ApplicationID:   testControllerApplicationID,
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key card1"),
+	}
+	deps.transparencyLogRepository.pendingErr = errors.New("pending error")
+
+	_, err := h.CardCreate(mock.StartNoopSpan(), &api.CardCreateRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+
+	assert.Error(t, err)
+
+	_, err = deps.cardRepository.GetCardByID(mock.StartNoopSpan(), "card1")
+	assert.Equal(t, cassandra.ErrEntityNotFound, err)
+
+	records, err := deps.cardAuditSink.GetCardAuditRecordsByIdentity(mock.StartNoopSpan(), testControllerIdentity)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+//
+// CardCreate :: for the card append error :: returns the saved card, meters the error and keeps the card pending.
+//
+func TestCardCreateKeepsTheCardPendingForTheTransparencyLogAppendError(t *testing.T) {
+
+	deps := newTestControllerDeps()
+	h := deps.getControllerUnderTest()
+
+	deps.createValidator.card = &model.CardDTO{
+		ID:              "card1",
+		ContentSnapshot: "snapshot card1",
+		Identity:        testControllerIdentity,
+		ApplicationID:   testControllerApplicationID,
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key card1"),
+	}
+	deps.transparencyLogRepository.appendErr = errors.New("append error")
+
+	card, err := h.CardCreate(mock.StartNoopSpan(), &api.CardCreateRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "card1", card.GetID())
+	assert.Equal(t, 1, deps.eventMeter.counts["TransparencyLogAppendError"])
+	assert.Len(t, deps.logger.errors, 1)
+
+	entries, err := deps.transparencyLogRepository.GetPendingTransparencyLogEntries(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "card1", entries[0].CardID)
+}
+
+//
+// testControllerDeps holds the controller dependencies kept in memory.
+//
+type testControllerDeps struct {
+	cardSigner      testCardSigner
+	cardRepository  *dao.MemoryCardRepository
+	repository      *testCardRepository
+	cardAuditSink   *testCardAuditSink
+	transparencyLog *translog.Log
+	eventMeter      *testEventMeter
+	createValidator *testCardValidator
+	deleteValidator *testCardValidator
+	logger          *testLogger
+
+	transparencyLogRepository *testTransparencyLogRepository
+}
+
+//
+// newTestControllerDeps returns the empty memory controller dependencies.
+//
+func newTestControllerDeps() *testControllerDeps {
+
+	cardRepository := dao.NewMemoryCardRepository()
+	transparencyLogRepository := &testTransparencyLogRepository{
+		MemoryTransparencyLogRepository: dao.NewMemoryTransparencyLogRepository(),
+	}
+
+	return &testControllerDeps{
+		cardRepository:  cardRepository,
+		repository:      &testCardRepository{MemoryCardRepository: cardRepository},
+		cardAuditSink:   &testCardAuditSink{MemoryCardAuditSink: dao.NewMemoryCardAuditSink()},
+		transparencyLog: translog.New(transparencyLogRepository, cardRepository),
+		eventMeter:      newTestEventMeter(),
+		createValidator: new(testCardValidator),
+		deleteValidator: new(testCardValidator),
+		logger:          new(testLogger),
+
+		transparencyLogRepository: transparencyLogRepository,
+	}
+}
+
+//
+// getControllerUnderTest returns the controller of the dependencies.
+//
+func (d *testControllerDeps) getControllerUnderTest() *Controller {
+
+	return New(
+		d.cardSigner,
+		d.repository,
+		d.createValidator,
+		NewSearchCardValidator(),
+		d.deleteValidator,
+		NewBatchGetCardValidator(),
+		getCardVersionRegistryUnderTest(),
+		d.eventMeter,
+		nil,
+		d.cardAuditSink,
+		d.transparencyLog,
+		d.logger,
+	)
+}
+
+//
+// saveTestControllerCard saves the root card of the test identity to the application given.
+//
+func saveTestControllerCard(
+	t *testing.T,
+	repository dao.CardRepositoryProvider,
+	cardID string,
+	applicationID string,
+) *model.CardDTO {
+
+	card := &model.CardDTO{
+		ID:              cardID,
+		ContentSnapshot: "snapshot " + cardID,
+		Identity:        testControllerIdentity,
+		ApplicationID:   applicationID,
+		Version:         model.CardVersion5,
+		PublicKey:       []byte("public key " + cardID),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+
+	return card
+}
+
+//
+// testCardRepository is the memory card repository failing the chain restore with the error given
+// or restoring the chain by another operator first. The identities the revocations are searched by are kept.
+//
+type testCardRepository struct {
+	*dao.MemoryCardRepository
+
+	restoreErr             error
+	isRestoredConcurrently bool
+	revocationIdentities   []string
+}
+
+//
+// SearchChainRevocationsByIdentities keeps the identities the revocations are searched by.
+//
+func (r *testCardRepository) SearchChainRevocationsByIdentities(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+) ([]*model.CardRevocation, error) {
+
+	r.revocationIdentities = append(r.revocationIdentities, identities...)
+
+	return r.MemoryCardRepository.SearchChainRevocationsByIdentities(span, identities, scopeID)
+}
+
+//
+// RestoreChain restores the chain unless the error is set.
+//
+func (r *testCardRepository) RestoreChain(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+	deleteCard *model.CardDTO,
+	deletedAt int64,
+) (bool, error) {
+
+	if nil != r.restoreErr {
+		return false, r.restoreErr
+	}
+
+	if r.isRestoredConcurrently {
+		if _, err := r.MemoryCardRepository.RestoreChain(
+			span,
+			identity,
+			scopeID,
+			chainID,
+			deleteCard,
+			deletedAt,
+		); nil != err {
+			return false, err
+		}
+	}
+
+	return r.MemoryCardRepository.RestoreChain(span, identity, scopeID, chainID, deleteCard, deletedAt)
+}
+
+//
+// testCardAuditSink is the memory card audit sink failing the append with the error given.
+//
+type testCardAuditSink struct {
+	*dao.MemoryCardAuditSink
+
+	appendErr error
+}
+
+//
+// AppendCardAuditRecord appends the card audit record unless the error is set.
+//
+func (s *testCardAuditSink) AppendCardAuditRecord(span tracer.Span, record *model.CardAuditRecord) error {
+
+	if nil != s.appendErr {
+		return s.appendErr
+	}
+
+	return s.MemoryCardAuditSink.AppendCardAuditRecord(span, record)
+}
+
+//
+// testTransparencyLogRepository is the memory transparency log repository failing the pending entry save
+// and the entry append with the errors given.
+//
+type testTransparencyLogRepository struct {
+	*dao.MemoryTransparencyLogRepository
+
+	pendingErr error
+	appendErr  error
+}
+
+//
+// AppendTransparencyLogEntry appends the entry unless the error is set.
+//
+func (r *testTransparencyLogRepository) AppendTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	if nil != r.appendErr {
+		return r.appendErr
+	}
+
+	return r.MemoryTransparencyLogRepository.AppendTransparencyLogEntry(span, entry)
+}
+
+//
+// SavePendingTransparencyLogEntry keeps the entry pending unless the error is set.
+//
+func (r *testTransparencyLogRepository) SavePendingTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	if nil != r.pendingErr {
+		return r.pendingErr
+	}
+
+	return r.MemoryTransparencyLogRepository.SavePendingTransparencyLogEntry(span, entry)
+}
+
+//
+// deleteTestControllerChain saves the root card of the test identity and deletes its chain with the "card2" card.
+//
+func deleteTestControllerChain(t *testing.T, deps *testControllerDeps, h *Controller) *model.CardDTO {
+
+	card := saveTestControllerCard(t, deps.cardRepository, "card1", testControllerApplicationID)
+	deleteCard := *card
+	deleteCard.ID = "card2"
+	deleteCard.PreviousCardID = card.GetID()
+	deleteCard.PublicKey = nil
+	deps.deleteValidator.card = &deleteCard
+
+	_, err := h.CardDelete(mock.StartNoopSpan(), &api.CardDeleteRequest{
+		Headers: &api.Headers{UserID: testControllerIdentity, ApplicationID: testControllerApplicationID},
+	})
+	assert.NoError(t, err)
+
+	return card
+}
+
+//
+// testCardValidator fills the card being created or deleted with the card given.
+//
+type testCardValidator struct {
+	card *model.CardDTO
+	err  error
+}
+
+//
+// Validate fills the card with the card given unless the error is set.
+//
+func (v *testCardValidator) Validate(
+	span tracer.Span,
+	request *api.CardBaseRequest,
+	virgilCard *model.CardDTO,
+) error {
+
+	if nil != v.err {
+		return v.err
+	}
+
+	*virgilCard = *v.card
+	virgilCard.Signatures = append([]*model.CardSignatureDTO(nil), v.card.Signatures...)
+
+	return nil
+}
+
+//
+// testCardSigner signs the cards with the fake virgil signature.
+//
+type testCardSigner struct {
+	serviceKeys []*model.ServiceKey
+}
+
+//
+// SignCardByCardsService appends the fake virgil signature.
+//
+func (testCardSigner) SignCardByCardsService(span tracer.Span, c *model.CardDTO) error {
+
+	c.AppendSignature(&model.CardSignatureDTO{Signer: model.VirgilSignatureType, Signature: "virgil signature"})
+
+	return nil
+}
+
+//
+// SignRevocation sets the fake revocation signature.
+//
+func (testCardSigner) SignRevocation(span tracer.Span, r *model.CardRevocation) error {
+
+	r.Signature = "virgil signature"
+
+	return nil
+}
+
+//
+// SignTreeHead sets the fake tree head signature.
+//
+func (testCardSigner) SignTreeHead(span tracer.Span, h *model.TreeHead) error {
+
+	h.Signature = "virgil signature"
+
+	return nil
+}
+
+//
+// GetServiceKeys returns the service keys given.
+//
+func (s testCardSigner) GetServiceKeys() []*model.ServiceKey {
+
+	return s.serviceKeys
+}
+
+//
+// testEventMeter counts the events the controller pushes by their names.
+// The rest of the events are pushed by the transport, so they are not implemented.
+//
+type testEventMeter struct {
+	events.EventProvider
+
+	counts map[string]int
+}
+
+//
+// newTestEventMeter returns the event meter with no events counted.
+//
+func newTestEventMeter() *testEventMeter {
+
+	return &testEventMeter{counts: make(map[string]int)}
+}
+
+//
+// IncCardExpiredHidden counts the event of the expired card hidden from the response.
+//
+func (m *testEventMeter) IncCardExpiredHidden(accountID, applicationID string) {
+	m.counts["CardExpiredHidden"]++
+}
+
+//
+// IncCardAuditAppendError counts the event of the card audit record not appended after the card is saved.
+//
+func (m *testEventMeter) IncCardAuditAppendError(accountID, applicationID string) {
+	m.counts["CardAuditAppendError"]++
+}
+
+//
+// IncTransparencyLogAppendError counts the event of the card not appended to the transparency log
+// after the card is saved.
+//
+func (m *testEventMeter) IncTransparencyLogAppendError(accountID, applicationID string) {
+	m.counts["TransparencyLogAppendError"]++
+}
+
+//
+// testLogger keeps the error messages the controller logs.
+// The rest of the levels are not logged by the controller, so they are not implemented.
+//
+type testLogger struct {
+	log.Logger
+
+	errors []string
+}
+
+//
+// Error keeps the error message.
+//
+func (l *testLogger) Error(format string, args ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
@@ -17,6 +17,48 @@ type ParametersStore struct {
 	publicKey     []byte
 	csr           []byte
 	extraSnapshot []byte
+
+	// version is the card version spec of the CSR.
+	version *CardVersionSpec
+	// extension is the version specific CSR object.
+	extension interface{}
+	// expiresAt is the validated card expiration UTC Unix timestamp.
+	expiresAt int64
+}
+
+//
+// getExpiresAt returns the validated card expiration UTC Unix timestamp. Zero means the card never expires.
+//
+func (p *ParametersStore) getExpiresAt() int64 {
+
+	return p.expiresAt
+}
+
+//
+// getCSRExpiresAt returns the CSR expiration UTC Unix timestamp
+// and whether the card version supports the expiration time at all.
+//
+func (p *ParametersStore) getCSRExpiresAt() (expiresAt int64, expirable bool) {
+
+	if csr, ok := p.extension.(interface {
+		GetExpiresAt() int64
+	}); ok {
+		return csr.GetExpiresAt(), true
+	}
+
+	return 0, false
+}
+
+//
+// getStampsLimits returns the CSR stamps amount limits of the card version.
+//
+func (p *ParametersStore) getStampsLimits() (minLength, maxLength int) {
+
+	if nil == p.version {
+		return CSRStampsListMinLength, CSRStampsListMaxLength
+	}
+
+	return p.version.StampsMinLength, p.version.StampsMaxLength
 }
 
 //
@@ -36,7 +78,7 @@ func (v *BaseCardValidator) Validate(
 	span tracer.Span,
 	cardBaseRequest *api.CardBaseRequest,
 	virgilCard *model.CardDTO,
-	verifySignatures bool,
+	action CardAction,
 ) error {
 
 	span = span.Tracer().StartSpan(
@@ -52,12 +94,22 @@ func (v *BaseCardValidator) Validate(
 		csr = new(api.CSR)
 	)
 
-	decodedParams, err := v.csrValidator.Validate(span, cardBaseRequest.CSR, csr, cardBaseRequest.UserID)
+	decodedParams, err := v.csrValidator.Validate(span, cardBaseRequest.CSR, csr, cardBaseRequest.UserID, action)
 	if nil != err {
 		return err
 	}
 
-	if verifySignatures {
+	if nil != decodedParams.version && nil != decodedParams.version.ValidateCSR {
+		if err := decodedParams.version.ValidateCSR(decodedParams.extension, action); nil != err {
+			return tracer.SetSpanErrorAndReturn(span, err)
+		}
+	}
+
+	if err := v.validateCSRRevocation(csr, action); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	if CardActionCreate == action {
 		if err := v.csrStampsValidator.Validate(
 			span,
 			cardBaseRequest.CSRStamps,
@@ -68,15 +120,30 @@ func (v *BaseCardValidator) Validate(
 		}
 	}
 
-	if err := v.validatePreviousCardID(
+	previousCard, err := v.validatePreviousCardID(
 		span,
 		csr.GetPreviousCardID(),
 		cardBaseRequest.ApplicationID,
 		csr.GetIdentity(),
-	); nil != err {
+		csr.GetCreatedAt(),
+	)
+	if nil != err {
 		return err
 	}
 
+	// The card to delete is the previous card of the delete CSR, an empty one is rejected by the delete validator.
+	if CardActionDelete == action && nil != previousCard {
+		if err := v.csrStampsValidator.ValidateDelete(
+			span,
+			cardBaseRequest.CSRStamps,
+			cardBaseRequest.ApplicationID,
+			decodedParams,
+			previousCard.GetPublicKey(),
+		); nil != err {
+			return err
+		}
+	}
+
 	if err := v.fillVirgilCardModel(
 		span,
 		virgilCard,
@@ -116,14 +183,17 @@ func (v *BaseCardValidator) fillVirgilCardModel(
 	)
 	defer span.Finish()
 
-	card.ID = v.crypto.CalculateCardID(decodedParams.csr)
+	card.ID = v.crypto.CalculateCardID(csr.GetVersion(), decodedParams.csr)
 	card.ContentSnapshot = request.GetCSR()
 	card.PublicKey = decodedParams.publicKey
 	card.Identity = csr.GetIdentity()
 	card.Version = csr.GetVersion()
 	card.CreatedAt = csr.GetCreatedAt()
+	card.ExpiresAt = decodedParams.getExpiresAt()
 	card.PreviousCardID = csr.GetPreviousCardID()
 	card.ApplicationID = scopeID
+	card.RevocationReason = csr.GetRevocationReason()
+	card.RevocationNote = csr.GetRevocationNote()
 
 	// Convert the CSR stamps.
 	for _, s := range request.CSRStamps {
@@ -138,9 +208,38 @@ func (v *BaseCardValidator) fillVirgilCardModel(
 }
 
 //
-// validatePreviousCardID performs the validation of the previous card ID parameter.
+// validateCSRRevocation validates the CSR revocation reason and note.
+// They are accepted by the delete CSR only, an empty reason is recorded as unspecified.
 //
-func (v *BaseCardValidator) validatePreviousCardID(span tracer.Span, previousCardID, scopeID, identity string) error {
+func (v *BaseCardValidator) validateCSRRevocation(csr *api.CSR, action CardAction) error {
+
+	if CardActionDelete != action {
+		if "" != csr.GetRevocationReason() || "" != csr.GetRevocationNote() {
+			return api.ErrCSRRevocationIsNotAllowed
+		}
+
+		return nil
+	}
+
+	if "" != csr.GetRevocationReason() && !model.IsRevocationReasonValid(csr.GetRevocationReason()) {
+		return api.ErrCSRRevocationReasonIsIncorrect
+	}
+	if RevocationNoteMaxLength < len(csr.GetRevocationNote()) {
+		return api.ErrCSRRevocationNoteIsTooLong(RevocationNoteMaxLength)
+	}
+
+	return nil
+}
+
+//
+// validatePreviousCardID performs the validation of the previous card ID parameter and returns the previous card.
+// The card must not be created earlier than the previous one.
+//
+func (v *BaseCardValidator) validatePreviousCardID(
+	span tracer.Span,
+	previousCardID, scopeID, identity string,
+	createdAt int64,
+) (*model.CardDTO, error) {
 
 	span = span.Tracer().StartSpan(
 		tracer.GetCallerInfo(),
@@ -152,31 +251,34 @@ func (v *BaseCardValidator) validatePreviousCardID(span tracer.Span, previousCar
 	defer span.Finish()
 
 	if "" == previousCardID {
-		return nil
+		return nil, nil
 	}
 
 	// search by previous card ID
 	isSuperseeded, err := v.cardRepository.DoesCardExistByPreviousIDAndScopeID(span, previousCardID, scopeID)
 	if err != nil {
-		return api.ErrInternalError.WithMessage("error checking if card already exists: %+v", err)
+		return nil, api.ErrInternalError.WithMessage("error checking if card already exists: %+v", err)
 	}
 
 	if isSuperseeded {
-		return tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardExistsAlready)
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardExistsAlready)
 	}
 
 	card, err := v.cardRepository.GetCardByID(span, previousCardID)
 	if nil != err {
-		return api.ErrPreviousVirgilCardDoesNotExist.WithMessage("error: %+v", err)
+		return nil, api.ErrPreviousVirgilCardDoesNotExist.WithMessage("error: %+v", err)
 	}
 	if scopeID != card.GetApplicationID() {
-		return tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardIsRegisteredForAnotherScope)
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardIsRegisteredForAnotherScope)
 	}
 	if identity != card.Identity {
-		return tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardIdentityIsIncorrect)
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrPreviousVirgilCardIdentityIsIncorrect)
+	}
+	if createdAt < card.CreatedAt {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrCSRCreationTimeIsEarlierThanPreviousCard)
 	}
 
-	return nil
+	return card, nil
 }
 
 //
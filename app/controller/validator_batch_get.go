@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+)
+
+//
+// Validator performs card batch get request validations.
+//
+const (
+	batchGetCardIDsLimit = 200
+)
+
+//
+// BatchGetCardValidatorProvider provider and interface to work with card batch get validator.
+//
+type BatchGetCardValidatorProvider interface {
+	//
+	// Validate validates Virgil Cards batch get request.
+	//
+	Validate(span tracer.Span, cardBatchGetRequest *api.CardBatchGetRequest) (err error)
+}
+
+//
+// BatchGetCardValidator performs card batch get request validations.
+//
+type BatchGetCardValidator struct{}
+
+//
+// NewBatchGetCardValidator creates new instance of Batch Get Card validator.
+//
+func NewBatchGetCardValidator() *BatchGetCardValidator {
+
+	return &BatchGetCardValidator{}
+}
+
+//
+// Validate performs a validation of the batch get Virgil Cards request object.
+//
+func (v *BatchGetCardValidator) Validate(span tracer.Span, cardBatchGetRequest *api.CardBatchGetRequest) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentValidator,
+		},
+	)
+	defer span.Finish()
+
+	cardIDsCount := len(cardBatchGetRequest.CardIDs)
+	if cardIDsCount == 0 {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrCardIDsCannotBeEmpty)
+	} else if batchGetCardIDsLimit < cardIDsCount {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrCardIDsCountIsLimited(batchGetCardIDsLimit))
+	}
+
+	for _, cardID := range cardBatchGetRequest.CardIDs {
+		if "" == cardID {
+			return tracer.SetSpanErrorAndReturn(span, api.ErrCardIDsCannotBeEmpty)
+		}
+	}
+
+	return nil
+}
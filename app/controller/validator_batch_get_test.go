@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// TestValidateBatchGetRequest :: for valid Batch Get request :: passes.
+//
+func TestValidateBatchGetRequest(t *testing.T) {
+
+	validator := NewBatchGetCardValidator()
+
+	err := validator.Validate(mock.StartNoopSpan(), &api.CardBatchGetRequest{
+		CardIDs: []string{"card1", "card2"},
+	})
+
+	assert.NoError(t, err)
+}
+
+//
+// TestValidateBatchGetRequestWithEmptyCardIDs :: for empty Batch Get request :: returns an error.
+//
+func TestValidateBatchGetRequestWithEmptyCardIDs(t *testing.T) {
+
+	validator := NewBatchGetCardValidator()
+
+	for _, cardIDs := range [][]string{nil, {"card1", ""}} {
+		err := validator.Validate(mock.StartNoopSpan(), &api.CardBatchGetRequest{
+			CardIDs: cardIDs,
+		})
+
+		assert.Equal(t, api.ErrCardIDsCannotBeEmpty, err)
+	}
+}
+
+//
+// TestValidateBatchGetRequestWithWrongCountOfCardIDs :: for wrong count of Batch Get card IDs :: returns an error.
+//
+func TestValidateBatchGetRequestWithWrongCountOfCardIDs(t *testing.T) {
+
+	validator := NewBatchGetCardValidator()
+
+	err := validator.Validate(mock.StartNoopSpan(), &api.CardBatchGetRequest{
+		CardIDs: func() []string {
+			var cardIDs []string
+			for i := 0; i <= batchGetCardIDsLimit; i++ {
+				cardIDs = append(cardIDs, "card")
+			}
+			return cardIDs
+		}(),
+	})
+
+	assert.Equal(t, api.ErrCardIDsCountIsLimited(batchGetCardIDsLimit), err)
+}
@@ -68,7 +68,7 @@ func (v *CreateCardValidator) Validate(
 	)
 	defer span.Finish()
 
-	if err := v.BaseCardValidator.Validate(span, request, virgilCard, true); nil != err {
+	if err := v.BaseCardValidator.Validate(span, request, virgilCard, CardActionCreate); nil != err {
 		return err
 	}
 
@@ -170,7 +170,7 @@ func TestValidateForAllValidParameters(t *testing.T) {
 	crypto.On("ValidateVirgilCardSignature",
 		[]byte(scr), []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
-	crypto.On("CalculateCardID", []byte(scr)).Return(cardID)
+	crypto.On("CalculateCardID", validCardVersion, []byte(scr)).Return(cardID)
 
 	cardRepositoryMock := new(mock.CardRepository)
 	cardRepositoryMock.On("GetCardByID", cardID).Return(new(model.CardDTO), errCardGet)
@@ -223,7 +223,7 @@ func TestValidateForACardThatHasDuplicates(t *testing.T) {
 	crypto.On("ValidateVirgilCardSignature",
 		[]byte(scr), []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
-	crypto.On("CalculateCardID", []byte(scr)).Return(cardID)
+	crypto.On("CalculateCardID", validCardVersion, []byte(scr)).Return(cardID)
 
 	cardRepositoryMock := new(mock.CardRepository)
 	cardRepositoryMock.On("GetCardByID", cardID).Return(new(model.CardDTO), nil)
@@ -258,7 +258,7 @@ func TestValidatePreviousCardIDForAnEmptyID(t *testing.T) {
 	emptyID := ""
 	validator := getCreateCardValidatorUnderTest(validatorDeps{})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), emptyID, emptyString, emptyString)
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), emptyID, emptyString, emptyString, 0)
 
 	assert.Empty(t, err)
 }
@@ -279,7 +279,7 @@ func TestValidatePreviousCardIDForAnExistingPreviousCard(t *testing.T) {
 
 	validator := getCreateCardValidatorUnderTest(validatorDeps{cardRepository: cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), alreadyClaimedPreviousCardID, scopeID, "")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), alreadyClaimedPreviousCardID, scopeID, "", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrPreviousVirgilCardExistsAlready, err)
@@ -303,7 +303,7 @@ func TestValidatePreviousCardIDForANotExistingCardID(t *testing.T) {
 
 	validator := getCreateCardValidatorUnderTest(validatorDeps{cardRepository: cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), nonExistingCardID, scopeID, "")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), nonExistingCardID, scopeID, "", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrPreviousVirgilCardDoesNotExist, helper.ExtractHTTPError(err))
@@ -334,7 +334,7 @@ func TestValidatePreviousCardIDForAPreviousCardIDRegisteredForAnotherScope(t *te
 
 	validator := getCreateCardValidatorUnderTest(validatorDeps{cardRepository: cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, "")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, "", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, api.ErrPreviousVirgilCardIsRegisteredForAnotherScope)
@@ -361,7 +361,7 @@ func TestValidatePreviousCardIDForAPreviousCardIdentityNotEqualsCurrentOne(t *te
 
 	validator := getCreateCardValidatorUnderTest(validatorDeps{cardRepository: &cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, scopeID, "identity")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, scopeID, "identity", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrPreviousVirgilCardIdentityIsIncorrect, err)
@@ -388,11 +388,40 @@ func TestValidatePreviousCardIDWithAValidPreviousCardIDPasses(t *testing.T) {
 
 	validator := getCreateCardValidatorUnderTest(validatorDeps{cardRepository: &cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, validIdentity)
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, validIdentity, 0)
 
 	assert.Empty(t, err)
 }
 
+//
+// validatePreviousCardID :: for a card created earlier than the previous one :: returns an error.
+//
+func TestValidatePreviousCardIDForACardCreatedEarlierThanThePreviousOne(t *testing.T) {
+
+	previousCardID := "Some previous card ID"
+	scopeID := "Some scope ID"
+	createdAt := time.Now().UTC().Unix()
+	cardRepositoryMock := mock.CardRepository{}
+	cardRepositoryMock.On(
+		"DoesCardExistByPreviousIDAndScopeID",
+		mock.Anything,
+		mock.Anything,
+	).Return(false)
+	cardRepositoryMock.On("GetCardByID", previousCardID).Return(&model.CardDTO{
+		ID:            previousCardID,
+		Identity:      validIdentity,
+		ApplicationID: scopeID,
+		CreatedAt:     createdAt + 1,
+	}, nil)
+
+	validator := getCreateCardValidatorUnderTest(validatorDeps{cardRepository: &cardRepositoryMock})
+
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, scopeID, validIdentity, createdAt)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrCSRCreationTimeIsEarlierThanPreviousCard, err)
+}
+
 //
 // TestValidateVirgilCardDuplicatesForAnExistingCardID :: for an existing card ID :: returns an error.
 //
@@ -461,7 +490,7 @@ func TestValidateWithTooShortPublicKey(t *testing.T) {
 	crypto.On("ValidateVirgilCardSignature",
 		[]byte(scr), []byte{}, []byte(tooShortPK), signatureBytes,
 	).Return(nil)
-	crypto.On("CalculateCardID", []byte(scr)).Return(cardID)
+	crypto.On("CalculateCardID", validCardVersion, []byte(scr)).Return(cardID)
 
 	cardRepositoryMock := new(mock.CardRepository)
 	cardRepositoryMock.On("GetCardByID", cardID).Return(new(model.CardDTO), errCardGet)
@@ -487,6 +516,30 @@ func TestValidateWithTooShortPublicKey(t *testing.T) {
 	assert.Equal(t, err, api.ErrCSRPublicKeyIsTooShort)
 }
 
+//
+// validate :: for a CSR with a revocation reason :: returns an error.
+//
+func TestValidateForACSRWithARevocationReason(t *testing.T) {
+
+	scr, err := getRevocationCSRMessageAsJSON(model.RevocationReasonKeyCompromise, emptyString)
+
+	assert.Nil(t, err)
+
+	encoder, encodedCSR := presetEncoder(scr)
+	validator := getCreateCardValidatorUnderTest(validatorDeps{encoder: encoder})
+
+	err = validator.Validate(mock.StartNoopSpan(), &api.CardCreateRequest{
+		Headers: &api.Headers{
+			UserID:        validIdentity,
+			ApplicationID: emptyScopeID,
+		},
+		CSR: encodedCSR,
+	}, new(model.CardDTO))
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrCSRRevocationIsNotAllowed, err)
+}
+
 //
 // getCreateCardValidatorUnderTest returns validator instance.
 //
@@ -504,10 +557,13 @@ func getCreateCardValidatorUnderTest(deps validatorDeps) *CreateCardValidator {
 		deps.crypto = &mock.Crypto{}
 	}
 
+	if nil == deps.idGenerator {
+		deps.idGenerator = getIDGeneratorUnderTest()
+	}
+
 	return NewCreateCardValidator(deps.cardRepository, deps.crypto, &CSRValidator{
-		encoder: deps.encoder,
-	}, &CSRStampsValidator{
-		crypto:  deps.crypto,
-		encoder: deps.encoder,
-	})
+		encoder:      deps.encoder,
+		idGenerator:  deps.idGenerator,
+		cardVersions: getCardVersionRegistryUnderTest(),
+	}, getCSRStampsValidatorUnderTest(deps.crypto, deps.encoder))
 }
@@ -2,14 +2,14 @@ package controller
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
-	"github.com/VirgilSecurity/virgil-services-core-kit/models"
 	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
 
 	"github.com/VirgilSecurity/virgil-services-cards/src/api"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
-	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/generator"
 )
 
 //
@@ -21,6 +21,8 @@ const (
 
 	IdentityMinLength = 1
 	IdentityMaxLength = 1024
+
+	RevocationNoteMaxLength = 1024
 )
 
 //
@@ -30,22 +32,47 @@ type CSRValidatorProvider interface {
 	//
 	// Validate validates Virgil Card CSR.
 	//
-	Validate(span tracer.Span, csrData string, csr *api.CSR, identity string) (params *ParametersStore, err error)
+	Validate(
+		span tracer.Span,
+		csrData string,
+		csr *api.CSR,
+		identity string,
+		action CardAction,
+	) (params *ParametersStore, err error)
 }
 
 //
 // CSRValidator represents the CSR validator.
 //
 type CSRValidator struct {
-	encoder encoder.Provider
+	encoder         encoder.Provider
+	idGenerator     generator.IDProvider
+	cardVersions    *CardVersionRegistry
+	cardMaxLifetime time.Duration
+
+	createdAtPastSkew   time.Duration
+	createdAtFutureSkew time.Duration
 }
 
 //
 // NewCSRValidator creates new instance of CSR validator.
 //
-func NewCSRValidator(encoder encoder.Provider) *CSRValidator {
+func NewCSRValidator(
+	encoder encoder.Provider,
+	idGenerator generator.IDProvider,
+	cardVersions *CardVersionRegistry,
+	cardMaxLifetime time.Duration,
+	createdAtPastSkew time.Duration,
+	createdAtFutureSkew time.Duration,
+) *CSRValidator {
 	return &CSRValidator{
-		encoder: encoder,
+		encoder:         encoder,
+		idGenerator:     idGenerator,
+		cardVersions:    cardVersions,
+		cardMaxLifetime: cardMaxLifetime,
+
+		createdAtPastSkew:   createdAtPastSkew,
+		createdAtFutureSkew: createdAtFutureSkew,
 	}
 }
 
@@ -57,6 +84,7 @@ func (v *CSRValidator) Validate(
 	csrData string,
 	csr *api.CSR,
 	identity string,
+	action CardAction,
 ) (params *ParametersStore, err error) {
 
 	span = span.Tracer().StartSpan(
@@ -93,10 +121,41 @@ func (v *CSRValidator) Validate(
 	if err := v.validateCSRVersion(csr.GetVersion()); nil != err {
 		return nil, tracer.SetSpanErrorAndReturn(span, err)
 	}
+	if err := v.parseVersionCSR(csr.GetVersion(), params); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
+	}
+	if err := v.validateCSRLifetime(csr.GetCreatedAt(), params, action); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
+	}
 
 	return params, nil
 }
 
+//
+// parseVersionCSR parses the decoded CSR message to the version specific CSR object.
+//
+func (v *CSRValidator) parseVersionCSR(version string, params *ParametersStore) (err error) {
+
+	spec, ok := v.cardVersions.Get(version)
+	if !ok {
+		return api.ErrCSRVersionIsIncorrect(v.cardVersions.Versions())
+	}
+
+	params.version = spec
+	if nil == spec.NewCSR {
+		return nil
+	}
+
+	params.extension = spec.NewCSR()
+	if err = json.Unmarshal(params.csr, params.extension); nil != err {
+		return errors.Wrap(err, api.ErrContentSnapshotIsNotAJSONMessage.WithMessage(
+			"unmarshal decoded content_snapshot to the %s version CSR", version,
+		))
+	}
+
+	return nil
+}
+
 //
 // parseCSR parses CSR message string.
 //
@@ -167,13 +226,14 @@ func (v *CSRValidator) validateCSRIdentity(identity, requestIdentity string) (er
 
 //
 // validateCSRPreviousCardID validates previous card id value.
+// The previous card could be created with any registered ID scheme.
 //
 func (v *CSRValidator) validateCSRPreviousCardID(id string) (err error) {
 
 	if "" == id {
 		return
 	}
-	if models.IDLength != len(id) {
+	if !v.idGenerator.IsValidCardID(id) {
 		return api.ErrCSRPreviousCardIDIsIncorrect
 	}
 
@@ -181,7 +241,8 @@ func (v *CSRValidator) validateCSRPreviousCardID(id string) (err error) {
 }
 
 //
-// validateCSRCreatedAt validates creation timestamp value.
+// validateCSRCreatedAt validates creation timestamp value is within the server time skew window.
+// Zero skew means the skew is unlimited.
 //
 func (v *CSRValidator) validateCSRCreatedAt(createdAt int64) (err error) {
 
@@ -189,6 +250,45 @@ func (v *CSRValidator) validateCSRCreatedAt(createdAt int64) (err error) {
 		return api.ErrCSRCreationTimeIsIncorrect
 	}
 
+	// the skew is compared in seconds, the client value converted to a duration may overflow.
+	now := time.Now().Unix()
+	if 0 < v.createdAtPastSkew && now-createdAt > int64(v.createdAtPastSkew/time.Second) {
+		return api.ErrCSRCreationTimeIsTooOld(v.createdAtPastSkew)
+	}
+	if 0 < v.createdAtFutureSkew && createdAt-now > int64(v.createdAtFutureSkew/time.Second) {
+		return api.ErrCSRCreationTimeIsInFuture(v.createdAtFutureSkew)
+	}
+
+	return nil
+}
+
+//
+// validateCSRLifetime validates the card lifetime does not exceed the maximum one and sets the card expiration time.
+// Zero expiration time means the card never expires, so it is allowed only if the lifetime is unlimited.
+// The card version without the expiration time expires in the maximum lifetime.
+// The delete card does not expire.
+//
+func (v *CSRValidator) validateCSRLifetime(createdAt int64, params *ParametersStore, action CardAction) (err error) {
+
+	expiresAt, expirable := params.getCSRExpiresAt()
+	params.expiresAt = expiresAt
+
+	if 0 == v.cardMaxLifetime || CardActionDelete == action {
+		return nil
+	}
+	if 0 == expiresAt {
+		if expirable {
+			return api.ErrCSRExpirationTimeIsRequired(v.cardMaxLifetime)
+		}
+		params.expiresAt = createdAt + int64(v.cardMaxLifetime/time.Second)
+
+		return nil
+	}
+	// the lifetime is compared in seconds, the client value converted to a duration may overflow.
+	if expiresAt-createdAt > int64(v.cardMaxLifetime/time.Second) {
+		return api.ErrCSRLifetimeIsTooLong(v.cardMaxLifetime)
+	}
+
 	return nil
 }
 
@@ -197,8 +297,8 @@ func (v *CSRValidator) validateCSRCreatedAt(createdAt int64) (err error) {
 //
 func (v *CSRValidator) validateCSRVersion(ver string) (err error) {
 
-	if model.CardVersion5 != ver {
-		return api.ErrCSRVersionIsIncorrect
+	if _, ok := v.cardVersions.Get(ver); !ok {
+		return api.ErrCSRVersionIsIncorrect(v.cardVersions.Versions())
 	}
 
 	return nil
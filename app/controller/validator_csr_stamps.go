@@ -5,6 +5,7 @@ import (
 	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
 
 	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
 	"github.com/VirgilSecurity/virgil-services-cards/src/model"
@@ -29,23 +30,52 @@ type CSRStampsValidatorProvider interface {
 	// Validate validates the SCR stamps collection.
 	//
 	Validate(span tracer.Span, scrStamps []api.CSRStamp, scopeID string, csrParams *ParametersStore) (err error)
+
+	//
+	// ValidateDelete validates the delete SCR stamps collection.
+	// The self stamp is verified with the public key of the card being deleted.
+	//
+	ValidateDelete(
+		span tracer.Span,
+		scrStamps []api.CSRStamp,
+		scopeID string,
+		csrParams *ParametersStore,
+		deletedCardPublicKey []byte,
+	) (err error)
 }
 
 //
 // CSRStampsValidator represents the CSR stamps validator.
 //
 type CSRStampsValidator struct {
-	crypto  crypto.Provider
-	encoder encoder.Provider
+	crypto        crypto.Provider
+	encoder       encoder.Provider
+	signerKeys    dao.SignerKeyRepositoryProvider
+	stampPolicies dao.StampPolicyRepositoryProvider
+
+	deleteAppStampRequired     bool
+	unregisteredSignersAllowed bool
 }
 
 //
 // NewCSRStampsValidator creates new instance of CSR stamps validator.
 //
-func NewCSRStampsValidator(crypto crypto.Provider, encoder encoder.Provider) *CSRStampsValidator {
+func NewCSRStampsValidator(
+	crypto crypto.Provider,
+	encoder encoder.Provider,
+	signerKeys dao.SignerKeyRepositoryProvider,
+	stampPolicies dao.StampPolicyRepositoryProvider,
+	deleteAppStampRequired bool,
+	unregisteredSignersAllowed bool,
+) *CSRStampsValidator {
 	return &CSRStampsValidator{
-		crypto:  crypto,
-		encoder: encoder,
+		crypto:        crypto,
+		encoder:       encoder,
+		signerKeys:    signerKeys,
+		stampPolicies: stampPolicies,
+
+		deleteAppStampRequired:     deleteAppStampRequired,
+		unregisteredSignersAllowed: unregisteredSignersAllowed,
 	}
 }
 
@@ -68,16 +98,17 @@ func (v *CSRStampsValidator) Validate(
 	)
 	defer span.Finish()
 
-	if CSRStampsListMinLength > len(scrStamps) {
+	stampsMinLength, stampsMaxLength := csrParams.getStampsLimits()
+	if stampsMinLength > len(scrStamps) {
 		return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampsListIsTooSmall)
 	}
-	if CSRStampsListMaxLength < len(scrStamps) {
+	if stampsMaxLength < len(scrStamps) {
 		return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampsListIsTooLarge)
 	}
 
 	var doesSelfStampExist bool
 	for _, csrStamp := range scrStamps {
-		if err = v.validateCSRStamp(span, csrStamp, csrParams); nil != err {
+		if err = v.validateCSRStamp(span, csrStamp, scopeID, csrParams); nil != err {
 			return
 		}
 		if csrStamp.IsSelf() {
@@ -91,6 +122,121 @@ func (v *CSRStampsValidator) Validate(
 		return tracer.SetSpanErrorAndReturn(span, api.ErrSelfCSRStampIsMissing)
 	}
 
+	return v.validatePolicy(span, scrStamps, scopeID)
+}
+
+//
+// ValidateDelete validates the delete SCR stamps collection.
+// The self stamp is verified with the public key of the card being deleted.
+//
+func (v *CSRStampsValidator) ValidateDelete(
+	span tracer.Span,
+	scrStamps []api.CSRStamp,
+	scopeID string,
+	csrParams *ParametersStore,
+	deletedCardPublicKey []byte,
+) (err error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentValidator,
+		},
+	)
+	defer span.Finish()
+
+	if _, stampsMaxLength := csrParams.getStampsLimits(); stampsMaxLength < len(scrStamps) {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampsListIsTooLarge)
+	}
+
+	// The delete CSR has no public key, so the self stamp is not verified along with the other stamps.
+	var (
+		selfStamp         *api.CSRStamp
+		doesAppStampExist bool
+	)
+	for i, csrStamp := range scrStamps {
+		if err = v.validateCSRStamp(span, csrStamp, scopeID, csrParams); nil != err {
+			return
+		}
+		if csrStamp.IsSelf() {
+			if nil != selfStamp {
+				return tracer.SetSpanErrorAndReturn(span, api.ErrSelfCSRStampMustBeUnique)
+			}
+			selfStamp = &scrStamps[i]
+		}
+		if csrStamp.IsApplication() {
+			doesAppStampExist = true
+		}
+	}
+	if nil == selfStamp {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrDeleteCSRSelfStampIsMissing)
+	}
+	if v.deleteAppStampRequired && !doesAppStampExist {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrDeleteCSRApplicationStampIsMissing)
+	}
+
+	if 0 == len(deletedCardPublicKey) {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrDeleteCSRSelfStampVerificationFailed)
+	}
+	if err = v.verifyCSRStampSignature(*selfStamp, csrParams.csr, deletedCardPublicKey); nil != err {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			api.ErrDeleteCSRSelfStampVerificationFailed.WithMessage(`self-signature is invalid: %+v`, err),
+		)
+	}
+
+	return v.validatePolicy(span, scrStamps, scopeID)
+}
+
+//
+// validatePolicy validates the SCR stamps collection against the application stamp policy.
+// Applications without a policy accept any stamps.
+//
+func (v *CSRStampsValidator) validatePolicy(span tracer.Span, scrStamps []api.CSRStamp, scopeID string) (err error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentValidator,
+		},
+	)
+	defer span.Finish()
+
+	policy, err := v.stampPolicies.GetStampPolicy(span, scopeID)
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			api.ErrInternalError.WithMessage("error getting application (%s) stamp policy: %+v", scopeID, err),
+		)
+	}
+	if nil == policy {
+		return nil
+	}
+
+	if (0 < policy.MinStamps && policy.MinStamps > len(scrStamps)) ||
+		(0 < policy.MaxStamps && policy.MaxStamps < len(scrStamps)) {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampsCountViolatesPolicy(policy.MinStamps, policy.MaxStamps))
+	}
+
+	signers := make(map[string]struct{}, len(scrStamps))
+	for _, csrStamp := range scrStamps {
+		if !policy.IsSignerAllowed(csrStamp.GetSigner()) {
+			return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampSignerIsNotAllowed(csrStamp.GetSigner()))
+		}
+		if policy.ExtraSnapshotsForbidden && "" != csrStamp.GetSnapshot() {
+			return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampSnapshotIsNotAllowed)
+		}
+		signers[csrStamp.GetSigner()] = struct{}{}
+	}
+
+	for _, requiredSigner := range policy.RequiredSigners {
+		if _, ok := signers[requiredSigner]; !ok {
+			return tracer.SetSpanErrorAndReturn(span, api.ErrRequiredCSRStampIsMissing(requiredSigner))
+		}
+	}
+
 	return nil
 }
 
@@ -100,6 +246,7 @@ func (v *CSRStampsValidator) Validate(
 func (v *CSRStampsValidator) validateCSRStamp(
 	span tracer.Span,
 	csrStamp api.CSRStamp,
+	scopeID string,
 	csrParams *ParametersStore,
 ) (err error) {
 
@@ -130,9 +277,79 @@ func (v *CSRStampsValidator) validateCSRStamp(
 		return tracer.SetSpanErrorAndReturn(span, err)
 	}
 
+	if !csrStamp.IsSelf() {
+		return v.validateRegisteredSignerStamp(span, csrStamp, scopeID, csrParams)
+	}
+
 	return nil
 }
 
+//
+// validateRegisteredSignerStamp verifies the stamp signature with the signer public key registered for the application.
+// Stamps of not registered signers are rejected unless they are explicitly allowed to be accepted unverified.
+//
+func (v *CSRStampsValidator) validateRegisteredSignerStamp(
+	span tracer.Span,
+	csrStamp api.CSRStamp,
+	scopeID string,
+	csrParams *ParametersStore,
+) (err error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentValidator,
+		},
+	)
+	defer span.Finish()
+
+	signerPublicKey, err := v.signerKeys.GetSignerPublicKey(span, scopeID, csrStamp.GetSigner())
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			api.ErrInternalError.WithMessage("error getting signer (%s) public key: %+v", csrStamp.GetSigner(), err),
+		)
+	}
+	if 0 == len(signerPublicKey) {
+		if v.unregisteredSignersAllowed {
+			return nil
+		}
+
+		return tracer.SetSpanErrorAndReturn(span, api.ErrCSRStampSignerIsNotRegistered(csrStamp.GetSigner()))
+	}
+
+	if err = v.verifyCSRStampSignature(csrStamp, csrParams.csr, signerPublicKey); nil != err {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			api.ErrSignatureVerificationFailed.WithMessage(`(%s) signature is invalid: %+v`, csrStamp.GetSigner(), err),
+		)
+	}
+
+	return nil
+}
+
+//
+// verifyCSRStampSignature verifies the stamp signature of the CSR with the public key given.
+// The stamp signature and snapshot encoding is expected to be validated already.
+//
+func (v *CSRStampsValidator) verifyCSRStampSignature(csrStamp api.CSRStamp, csr, publicKey []byte) error {
+
+	signatureBytes, err := v.encoder.DecodeString(csrStamp.GetSignature())
+	if nil != err {
+		return err
+	}
+
+	extraSnapshot := []byte{}
+	if "" != csrStamp.GetSnapshot() {
+		if extraSnapshot, err = v.encoder.DecodeString(csrStamp.GetSnapshot()); nil != err {
+			return err
+		}
+	}
+
+	return v.crypto.ValidateVirgilCardSignature(csr, extraSnapshot, publicKey, signatureBytes)
+}
+
 //
 // validateCSRStampSignature validates the signature correctness.
 //
@@ -13,6 +13,9 @@ import (
 	"github.com/VirgilSecurity/virgil-services-core-kit/test/helper"
 
 	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
 	"github.com/VirgilSecurity/virgil-services-cards/src/model"
 	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
 )
@@ -23,7 +26,7 @@ import (
 func TestValidateCSRStampsForAnEmptyStampList(t *testing.T) {
 
 	var csrStampList []api.CSRStamp
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.Validate(mock.StartNoopSpan(), csrStampList, emptyString, new(ParametersStore))
 
@@ -41,7 +44,7 @@ func TestValidateCSRStampsForATooLongStampList(t *testing.T) {
 		stampList = append(stampList, api.CSRStamp{})
 	}
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.Validate(mock.StartNoopSpan(), stampList, emptyString, new(ParametersStore))
 
@@ -56,7 +59,7 @@ func TestValidateCSRStampsForAStampListWithoutSelfStamp(t *testing.T) {
 
 	encoder, _ := presetEncoder("")
 	encoder.On("DecodeString", encodedString).Return([]byte(originalString), nil)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 	stampList := []api.CSRStamp{{
 		Signer:    model.ApplicationSignatureType,
 		Signature: encodedString,
@@ -75,7 +78,7 @@ func TestValidateCSRStampsForATooLargeStampList(t *testing.T) {
 
 	encoder, _ := presetEncoder("")
 	encoder.On("DecodeString", encodedString).Return([]byte(originalString), nil)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 	stampList := []api.CSRStamp{{
 		Signer:    model.ApplicationSignatureType,
 		Signature: encodedString,
@@ -114,7 +117,7 @@ func TestValidateCSRStampsForAnApplicationCSRStampNotInScopeID(t *testing.T) {
 		csrParameters.csr, []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
 	encoder, _ := presetEncoder(string(signatureBytes))
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 	signatureList := []api.CSRStamp{
 		{
 			Signature: encodedSignature,
@@ -160,7 +163,7 @@ func TestValidateCSRStampsForAnApplicationCSRStampInScopeID(t *testing.T) {
 		csrParameters.csr, []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
 	encoder, _ := presetEncoder(string(signatureBytes))
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 	signatureList := []api.CSRStamp{
 		{
 			Signature: encodedSignature,
@@ -204,7 +207,7 @@ func TestValidateCSRStampsForTwoSelfSignedCSRStamps(t *testing.T) {
 		csrParameters.csr, []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
 	encoder, _ := presetEncoder(string(signatureBytes))
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 	signatureList := []api.CSRStamp{
 		{
 			Signature: encodedSignature,
@@ -251,7 +254,7 @@ func TestValidateSignatureList_WithTwoApplicationSignatures_ReturnsAnError(t *te
 		csrParameters.csr, []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
 	encoder, _ := presetEncoder(string(signatureBytes))
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 	signatureList := []api.CSRStamp{
 		{
 			Signature: encodedSignature,
@@ -278,10 +281,10 @@ func TestValidateSignatureList_WithTwoApplicationSignatures_ReturnsAnError(t *te
 func TestValidateCSRStampForAnInvalidSnapshot(t *testing.T) {
 
 	encoder := presetEncoderWithError(originalString, errors.New("error"))
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 	csrStamp := api.CSRStamp{Snapshot: encodedString}
 
-	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, new(ParametersStore))
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, emptyScopeID, new(ParametersStore))
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRStampExtraSnapshotDecoding, helper.ExtractHTTPError(err))
@@ -293,13 +296,13 @@ func TestValidateCSRStampForAnInvalidSnapshot(t *testing.T) {
 func TestValidateCSRStampForAnInvalidSignature(t *testing.T) {
 
 	encoder, _ := presetEncoder(originalString)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 	csrStamp := api.CSRStamp{
 		Snapshot: encodedString,
 		Signer:   model.ApplicationSignatureType,
 	}
 
-	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, new(ParametersStore))
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, emptyScopeID, new(ParametersStore))
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRStampSignatureIsMissing, err)
@@ -311,13 +314,13 @@ func TestValidateCSRStampForAnInvalidSignature(t *testing.T) {
 func TestValidateCSRStampForAnInvalidSignerType(t *testing.T) {
 
 	encoder, _ := presetEncoder(originalString)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 	csrStamp := api.CSRStamp{
 		Snapshot:  encodedString,
 		Signature: encodedString,
 	}
 
-	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, new(ParametersStore))
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, emptyScopeID, new(ParametersStore))
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRStampSignerIsEmpty, err)
@@ -329,14 +332,252 @@ func TestValidateCSRStampForAnInvalidSignerType(t *testing.T) {
 func TestValidateCSRStampForAllValidParameters(t *testing.T) {
 
 	encoder, _ := presetEncoder(originalString)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 	csrStamp := api.CSRStamp{
 		Snapshot:  encodedString,
 		Signature: encodedString,
 		Signer:    model.ApplicationSignatureType,
 	}
 
-	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, new(ParametersStore))
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, emptyScopeID, new(ParametersStore))
+
+	assert.NoError(t, err)
+}
+
+//
+// validateCSRStamp :: for a registered signer stamp with an invalid signature :: returns an error.
+//
+func TestValidateCSRStampForARegisteredSignerWithAnInvalidSignature(t *testing.T) {
+
+	csrParams := ParametersStore{csr: []byte("csr bytes")}
+	signerPublicKey := []byte("signer public key")
+	signerKeys := dao.NewMemorySignerKeyRepository()
+	signerKeys.SetSignerPublicKey(validID, model.ApplicationSignatureType, signerPublicKey)
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature",
+		csrParams.csr, []byte{}, signerPublicKey, []byte(originalString),
+	).Return(errors.New("invalid signature"))
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorWithSignerKeysUnderTest(crypto, encoder, signerKeys)
+	csrStamp := api.CSRStamp{
+		Signature: encodedString,
+		Signer:    model.ApplicationSignatureType,
+	}
+
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, validID, &csrParams)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrSignatureVerificationFailed, helper.ExtractHTTPError(err))
+}
+
+//
+// validateCSRStamp :: for a registered signer stamp with a valid signature :: passes.
+//
+func TestValidateCSRStampForARegisteredSignerWithAValidSignature(t *testing.T) {
+
+	csrParams := ParametersStore{csr: []byte("csr bytes")}
+	signerPublicKey := []byte("signer public key")
+	signerKeys := dao.NewMemorySignerKeyRepository()
+	signerKeys.SetSignerPublicKey(validID, "custom signer", signerPublicKey)
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature",
+		csrParams.csr, []byte{}, signerPublicKey, []byte(originalString),
+	).Return(nil)
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorWithSignerKeysUnderTest(crypto, encoder, signerKeys)
+	csrStamp := api.CSRStamp{
+		Signature: encodedString,
+		Signer:    "custom signer",
+	}
+
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, validID, &csrParams)
+
+	assert.NoError(t, err)
+	crypto.AssertExpectations(t)
+}
+
+//
+// validateCSRStamp :: for a not registered signer stamp not allowed unverified :: returns an error.
+//
+func TestValidateCSRStampForANotRegisteredSigner(t *testing.T) {
+
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
+	validator.unregisteredSignersAllowed = false
+	csrStamp := api.CSRStamp{
+		Signature: encodedString,
+		Signer:    "custom signer",
+	}
+
+	err := validator.validateCSRStamp(mock.StartNoopSpan(), csrStamp, validID, new(ParametersStore))
+
+	assert.Equal(t, api.ErrCSRStampSignerIsNotRegistered("custom signer"), err)
+}
+
+//
+// ValidateDelete :: for stamps without a self stamp :: returns an error.
+//
+func TestValidateDeleteForStampsWithoutASelfStamp(t *testing.T) {
+
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
+	stampList := []api.CSRStamp{{
+		Signer:    model.ApplicationSignatureType,
+		Signature: encodedString,
+	}}
+
+	err := validator.ValidateDelete(mock.StartNoopSpan(), stampList, emptyScopeID, new(ParametersStore), publicKeyBytes)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrDeleteCSRSelfStampIsMissing, err)
+}
+
+//
+// ValidateDelete :: for a self stamp not made with the deleted card key :: returns an error.
+//
+func TestValidateDeleteForASelfStampOfAnotherKey(t *testing.T) {
+
+	csrParams := ParametersStore{csr: []byte("csr bytes")}
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature",
+		csrParams.csr, []byte{}, publicKeyBytes, []byte(originalString),
+	).Return(errors.New("invalid signature"))
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
+	stampList := []api.CSRStamp{{
+		Signer:    model.SelfSignatureType,
+		Signature: encodedString,
+	}}
+
+	err := validator.ValidateDelete(mock.StartNoopSpan(), stampList, emptyScopeID, &csrParams, publicKeyBytes)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrDeleteCSRSelfStampVerificationFailed, helper.ExtractHTTPError(err))
+}
+
+//
+// ValidateDelete :: for a required application stamp missing :: returns an error.
+//
+func TestValidateDeleteForAMissingRequiredApplicationStamp(t *testing.T) {
+
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
+	validator.deleteAppStampRequired = true
+	stampList := []api.CSRStamp{{
+		Signer:    model.SelfSignatureType,
+		Signature: encodedString,
+	}}
+
+	err := validator.ValidateDelete(mock.StartNoopSpan(), stampList, emptyScopeID, new(ParametersStore), publicKeyBytes)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrDeleteCSRApplicationStampIsMissing, err)
+}
+
+//
+// ValidateDelete :: for a self stamp made with the deleted card key :: passes.
+//
+func TestValidateDeleteForAValidSelfStamp(t *testing.T) {
+
+	csrParams := ParametersStore{csr: []byte("csr bytes")}
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature",
+		csrParams.csr, []byte{}, publicKeyBytes, []byte(originalString),
+	).Return(nil)
+	encoder, _ := presetEncoder(originalString)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
+	stampList := []api.CSRStamp{{
+		Signer:    model.SelfSignatureType,
+		Signature: encodedString,
+	}}
+
+	err := validator.ValidateDelete(mock.StartNoopSpan(), stampList, emptyScopeID, &csrParams, publicKeyBytes)
+
+	assert.NoError(t, err)
+	crypto.AssertExpectations(t)
+}
+
+//
+// validatePolicy :: for stamps without a required signer :: returns an error.
+//
+func TestValidateStampPolicyForStampsWithoutARequiredSigner(t *testing.T) {
+
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
+	stampPolicies := dao.NewMemoryStampPolicyRepository()
+	stampPolicies.SetStampPolicy(validID, &model.StampPolicy{
+		RequiredSigners: []string{model.SelfSignatureType, model.ApplicationSignatureType},
+	})
+	validator.stampPolicies = stampPolicies
+
+	err := validator.validatePolicy(mock.StartNoopSpan(), []api.CSRStamp{{Signer: model.SelfSignatureType}}, validID)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrRequiredCSRStampIsMissing(model.ApplicationSignatureType), err)
+}
+
+//
+// validatePolicy :: for stamps violating the application policy :: returns an error.
+//
+func TestValidateStampPolicyForStampsViolatingThePolicy(t *testing.T) {
+
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
+	stampPolicies := dao.NewMemoryStampPolicyRepository()
+	stampPolicies.SetStampPolicy(validID, &model.StampPolicy{
+		AllowedSigners:          []string{model.ApplicationSignatureType},
+		MaxStamps:               2,
+		ExtraSnapshotsForbidden: true,
+	})
+	validator.stampPolicies = stampPolicies
+
+	err := validator.validatePolicy(mock.StartNoopSpan(), []api.CSRStamp{
+		{Signer: model.SelfSignatureType},
+		{Signer: "custom signer"},
+	}, validID)
+
+	assert.Equal(t, api.ErrCSRStampSignerIsNotAllowed("custom signer"), err)
+
+	err = validator.validatePolicy(mock.StartNoopSpan(), []api.CSRStamp{
+		{Signer: model.SelfSignatureType, Snapshot: encodedString},
+	}, validID)
+
+	assert.Equal(t, api.ErrCSRStampSnapshotIsNotAllowed, err)
+
+	err = validator.validatePolicy(mock.StartNoopSpan(), []api.CSRStamp{
+		{Signer: model.SelfSignatureType},
+		{Signer: model.ApplicationSignatureType},
+		{Signer: model.ApplicationSignatureType},
+	}, validID)
+
+	assert.Equal(t, api.ErrCSRStampsCountViolatesPolicy(0, 2), err)
+}
+
+//
+// validatePolicy :: for too few stamps and a policy without the max stamps count :: returns an error without the upper bound.
+//
+func TestValidateStampPolicyForTooFewStampsWithoutMaxStamps(t *testing.T) {
+
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
+	stampPolicies := dao.NewMemoryStampPolicyRepository()
+	stampPolicies.SetStampPolicy(validID, &model.StampPolicy{MinStamps: 2})
+	validator.stampPolicies = stampPolicies
+
+	err := validator.validatePolicy(mock.StartNoopSpan(), []api.CSRStamp{{Signer: model.SelfSignatureType}}, validID)
+
+	if assert.Equal(t, api.ErrCSRStampsCountViolatesPolicy(2, 0), err) {
+		assert.NotContains(t, err.Error(), "[2, 0]")
+	}
+}
+
+//
+// validatePolicy :: for an application without a policy :: passes.
+//
+func TestValidateStampPolicyForAnApplicationWithoutAPolicy(t *testing.T) {
+
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
+
+	err := validator.validatePolicy(mock.StartNoopSpan(), []api.CSRStamp{{Signer: "custom signer"}}, validID)
 
 	assert.NoError(t, err)
 }
@@ -346,7 +587,7 @@ func TestValidateCSRStampForAllValidParameters(t *testing.T) {
 //
 func TestValidateCSRStampSignatureForAnEmptyValue(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -366,7 +607,7 @@ func TestValidateCSRStampSignatureForAnEmptyValue(t *testing.T) {
 func TestValidateCSRStampSignatureForAnIncorrectlyEncodedValue(t *testing.T) {
 
 	encoder := presetEncoderWithError(originalString, errors.New("error"))
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 
 	err := validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -386,7 +627,7 @@ func TestValidateCSRStampSignatureForAnIncorrectlyEncodedValue(t *testing.T) {
 func TestValidateCSRStampSignatureForACorrectlyEncodedValueAndNotASelfSignature(t *testing.T) {
 
 	encoder, _ := presetEncoder(originalString)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 
 	err := validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -418,7 +659,7 @@ func TestValidateCSRStampSignatureForAnIncorrectSignatureAndSelfSignatureWithout
 		csrBytes, []byte{}, publicKeyBytes, []byte(originalString),
 	).Return(errors.New("error"))
 	encoder, _ := presetEncoder(invalidSignature)
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 
 	err := validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -454,7 +695,7 @@ func TestValidateCSRStampSignatureForAnIncorrectSignatureAndSelfSignatureWithSna
 	).Return(errors.New("error"))
 	encoder, _ := presetEncoder(invalidSignature)
 	encoder.On("DecodeString", encodedExtraSnapshot).Return(extraSnapshotBytes, nil)
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 
 	err := validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -490,7 +731,7 @@ func TestValidateCSRStampSignatureForAllValidParameters(t *testing.T) {
 	).Return(nil)
 	encoder, _ := presetEncoder(validSignature)
 	encoder.On("DecodeString", encodedExtraSnapshot).Return(extraSnapshotBytes, nil)
-	validator := NewCSRStampsValidator(crypto, encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, encoder)
 
 	err := validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -539,7 +780,7 @@ func TestValidateCSRStampSignatureForAllValidParametersAndWithoutMocks(t *testin
 	encoder.On("DecodeString", encodedSignature).Return(signatureBytes, nil)
 	encoder.On("DecodeString", encodedExtraCSR).Return(extraCSRBytes, nil)
 
-	validator := NewCSRStampsValidator(crypto, &encoder)
+	validator := getCSRStampsValidatorUnderTest(crypto, &encoder)
 
 	err = validator.validateCSRStampSignature(
 		mock.StartNoopSpan(),
@@ -558,7 +799,7 @@ func TestValidateCSRStampSignatureForAllValidParametersAndWithoutMocks(t *testin
 //
 func TestValidateCSRStampTypeWithAToLongSignerType(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRSigner(strings.Repeat("a", CSRStampSignerMaxLength+1))
 
@@ -571,7 +812,7 @@ func TestValidateCSRStampTypeWithAToLongSignerType(t *testing.T) {
 //
 func TestValidateCSRStampTypeWithAnEmptySignatureType(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRSigner("")
 
@@ -584,7 +825,7 @@ func TestValidateCSRStampTypeWithAnEmptySignatureType(t *testing.T) {
 //
 func TestValidateCSRStampTypeWithACustomSignerType(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRSigner("INCORRECT TYPE")
 
@@ -596,7 +837,7 @@ func TestValidateCSRStampTypeWithACustomSignerType(t *testing.T) {
 //
 func TestValidateCSRStampTypeWithAnApplicationSignatureType(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRSigner(model.ApplicationSignatureType)
 
@@ -608,7 +849,7 @@ func TestValidateCSRStampTypeWithAnApplicationSignatureType(t *testing.T) {
 //
 func TestValidateCSRStampTypeWithASelfSignatureType(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRSigner(model.SelfSignatureType)
 
@@ -620,7 +861,7 @@ func TestValidateCSRStampTypeWithASelfSignatureType(t *testing.T) {
 //
 func TestValidateCSRStampTypeWithAVirgilSignatureType(t *testing.T) {
 
-	validator := NewCSRStampsValidator(&mock.Crypto{}, &mock.Base64Encoder{})
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, &mock.Base64Encoder{})
 
 	err := validator.validateCSRSigner(model.VirgilSignatureType)
 
@@ -635,7 +876,7 @@ func TestValidateCSRStampSnapshotForIncorrectExtraSnapshot(t *testing.T) {
 
 	decodeErr := errors.New("decoding error")
 	encoder := presetEncoderWithError(originalString, decodeErr)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 
 	err := validator.validateCSRStampSnapshot(encodedString)
 
@@ -650,7 +891,7 @@ func TestValidateCSRStampSnapshotForATooLongExtraSnapshot(t *testing.T) {
 
 	tooLongExtraSnapshot := make([]byte, CSRStampSnapshotMaxLength+1)
 	encoder, tooLongEncodedExtraSnapshot := presetEncoder(string(tooLongExtraSnapshot))
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 
 	err := validator.validateCSRStampSnapshot(tooLongEncodedExtraSnapshot)
 
@@ -664,9 +905,38 @@ func TestValidateCSRStampSnapshotForATooLongExtraSnapshot(t *testing.T) {
 func TestValidateCSRStampSnapshotForAValidExtraSnapshot(t *testing.T) {
 
 	encoder, _ := presetEncoder(originalString)
-	validator := NewCSRStampsValidator(&mock.Crypto{}, encoder)
+	validator := getCSRStampsValidatorUnderTest(&mock.Crypto{}, encoder)
 
 	err := validator.validateCSRStampSnapshot(encodedString)
 
 	assert.NoError(t, err)
 }
+
+//
+// getCSRStampsValidatorUnderTest returns validator instance without registered signers and stamp policies.
+// It accepts the stamps of the not registered signers unverified.
+//
+func getCSRStampsValidatorUnderTest(crypto crypto.Provider, encoder encoder.Provider) *CSRStampsValidator {
+
+	return getCSRStampsValidatorWithSignerKeysUnderTest(crypto, encoder, dao.NewMemorySignerKeyRepository())
+}
+
+//
+// getCSRStampsValidatorWithSignerKeysUnderTest returns validator instance with the registered signers given
+// and without stamp policies. It accepts the stamps of the not registered signers unverified.
+//
+func getCSRStampsValidatorWithSignerKeysUnderTest(
+	crypto crypto.Provider,
+	encoder encoder.Provider,
+	signerKeys dao.SignerKeyRepositoryProvider,
+) *CSRStampsValidator {
+
+	return NewCSRStampsValidator(
+		crypto,
+		encoder,
+		signerKeys,
+		dao.NewMemoryStampPolicyRepository(),
+		false,
+		true,
+	)
+}
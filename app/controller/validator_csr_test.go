@@ -17,6 +17,8 @@ import (
 	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/generator"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/hasher"
 	"github.com/VirgilSecurity/virgil-services-cards/src/model"
 	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
 )
@@ -53,7 +55,13 @@ func TestValidateCSRWithEmptyCSRMessage(t *testing.T) {
 
 	validator := getCSRValidatorUnderTest(validatorDeps{})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), emptyString, &api.CSR{}, emptyRequestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		emptyString,
+		&api.CSR{},
+		emptyRequestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRIsEmpty, err)
@@ -75,6 +83,7 @@ func TestValidateCSRWithMalformedCSRMessage(t *testing.T) {
 		invalidBase64String,
 		&api.CSR{},
 		emptyRequestIdentity,
+		CardActionCreate,
 	)
 
 	assert.Error(t, err)
@@ -95,6 +104,7 @@ func TestValidateCSRWithANotJSONCSRMessage(t *testing.T) {
 		encodedMsg,
 		&api.CSR{},
 		emptyRequestIdentity,
+		CardActionCreate,
 	)
 
 	assert.Error(t, err)
@@ -111,7 +121,13 @@ func TestValidateCSRWithInvalidCreatedAtFieldType(t *testing.T) {
 	encoder, encodedMsg := presetEncoder(incorrectMessage)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, validIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		validIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRCreationTimeIsIncorrect, helper.ExtractHTTPError(err))
@@ -126,7 +142,13 @@ func TestValidateCSRForAnEmptyJSONMessage(t *testing.T) {
 	encoder, encodedMsg := presetEncoder(emptyJSONMessage)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, emptyRequestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		emptyRequestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Empty(t, decodedCSRParams)
@@ -147,7 +169,13 @@ func TestValidateCSRForAnInvalidPublicKey(t *testing.T) {
 	encoder.On("DecodeString", invalidBase64String).Return([]byte{}, errPublicKeyDecode)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, emptyRequestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		emptyRequestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRPublicKeyDecoding, helper.ExtractHTTPError(err))
@@ -184,7 +212,13 @@ func TestValidateCSRForATooLongIdentity(t *testing.T) {
 	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, emptyRequestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		emptyRequestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRIdentityIsIncorrect, err)
@@ -206,7 +240,13 @@ func TestValidateCSRForAnInvalidPreviousCardID(t *testing.T) {
 	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, requestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		requestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRPreviousCardIDIsIncorrect, err)
@@ -227,7 +267,13 @@ func TestValidateCSRWithAnInvalidCreateAtValue(t *testing.T) {
 	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, requestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		requestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRCreationTimeIsIncorrect, err)
@@ -250,10 +296,16 @@ func TestValidateCSRForAnInvalidVersion(t *testing.T) {
 	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, requestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		requestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
-	assert.Equal(t, api.ErrCSRVersionIsIncorrect, err)
+	assert.Equal(t, api.ErrCSRVersionIsIncorrect([]string{model.CardVersion5, model.CardVersion6}), err)
 	assert.Empty(t, decodedCSRParams)
 }
 
@@ -270,7 +322,13 @@ func TestValidateCSRForAnEmptyIdentity(t *testing.T) {
 	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, emptyRequestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		emptyRequestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrCSRIdentityIsEmpty, err)
@@ -306,7 +364,13 @@ func TestValidateCSRForAllValidParameters(t *testing.T) {
 	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
 	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
 
-	decodedCSRParams, err := validator.Validate(mock.StartNoopSpan(), encodedMsg, &api.CSR{}, requestIdentity)
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		requestIdentity,
+		CardActionCreate,
+	)
 
 	assert.Empty(t, err)
 	assert.Equal(t, []byte(csrMsg), decodedCSRParams.csr)
@@ -353,6 +417,45 @@ func TestValidateCSRIdentityForAnEmptyValue(t *testing.T) {
 	assert.Equal(t, api.ErrCSRIdentityIsEmpty, err)
 }
 
+//
+// validateCSR :: for all valid 6.0 version parameters :: passes and parses the version specific fields.
+//
+func TestValidateCSRForAllValidVersion6Parameters(t *testing.T) {
+
+	now := time.Now().UTC().Unix()
+	jsonMessage, err := json.Marshal(api.CSRV6{
+		CSR: api.CSR{
+			PublicKey: encodedPublicKey,
+			Identity:  validIdentity,
+			Version:   model.CardVersion6,
+			CreatedAt: now,
+		},
+		KeyAlgorithm: api.KeyAlgorithmED25519,
+		ExpiresAt:    now + 1,
+	})
+
+	assert.NoError(t, err)
+
+	encoder, encodedMsg := presetEncoder(string(jsonMessage))
+	encoder.On("DecodeString", encodedPublicKey).Return(publicKeyBytes, nil)
+	validator := getCSRValidatorUnderTest(validatorDeps{encoder: encoder})
+
+	decodedCSRParams, err := validator.Validate(
+		mock.StartNoopSpan(),
+		encodedMsg,
+		&api.CSR{},
+		validIdentity,
+		CardActionCreate,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardVersion6, decodedCSRParams.version.Version)
+	if assert.IsType(t, &api.CSRV6{}, decodedCSRParams.extension) {
+		assert.Equal(t, api.KeyAlgorithmED25519, decodedCSRParams.extension.(*api.CSRV6).GetKeyAlgorithm())
+		assert.Equal(t, now+1, decodedCSRParams.extension.(*api.CSRV6).GetExpiresAt())
+	}
+}
+
 //
 // validateCSRPreviousCardID :: for an empty value :: passes.
 //
@@ -391,6 +494,114 @@ func TestValidateCSRCreatedAtForAValidValue(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+//
+// validateCSRCreatedAt :: for a value out of the skew window :: returns an error.
+//
+func TestValidateCSRCreatedAtForAValueOutOfTheSkewWindow(t *testing.T) {
+
+	now := time.Now().UTC().Unix()
+	skew := int64(time.Hour / time.Second)
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	validator.createdAtPastSkew = time.Hour
+	validator.createdAtFutureSkew = time.Hour
+
+	assert.Equal(t, api.ErrCSRCreationTimeIsTooOld(time.Hour), validator.validateCSRCreatedAt(now-skew-60))
+	assert.Equal(t, api.ErrCSRCreationTimeIsInFuture(time.Hour), validator.validateCSRCreatedAt(now+skew+60))
+	assert.NoError(t, validator.validateCSRCreatedAt(now-skew+60))
+	assert.NoError(t, validator.validateCSRCreatedAt(now+skew-60))
+
+	// the skew of the values converted to nanoseconds overflows to a fraction of a second.
+	assert.Equal(t, api.ErrCSRCreationTimeIsInFuture(time.Hour), validator.validateCSRCreatedAt(now+18446744074))
+}
+
+//
+// validateCSRLifetime :: for a lifetime exceeding the maximum one :: returns an error.
+//
+func TestValidateCSRLifetimeForATooLongLifetime(t *testing.T) {
+
+	createdAt := time.Now().UTC().Unix()
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	validator.cardMaxLifetime = time.Hour
+	params := &ParametersStore{extension: &api.CSRV6{ExpiresAt: createdAt + int64(time.Hour/time.Second) + 1}}
+
+	err := validator.validateCSRLifetime(createdAt, params, CardActionCreate)
+
+	assert.Equal(t, api.ErrCSRLifetimeIsTooLong(time.Hour), helper.ExtractHTTPError(err))
+}
+
+//
+// validateCSRLifetime :: for a lifetime overflowing the duration :: returns an error.
+//
+func TestValidateCSRLifetimeForALifetimeOverflowingTheDuration(t *testing.T) {
+
+	createdAt := time.Now().UTC().Unix()
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	validator.cardMaxLifetime = time.Hour
+	params := &ParametersStore{extension: &api.CSRV6{ExpiresAt: createdAt + 18446744074}}
+
+	err := validator.validateCSRLifetime(createdAt, params, CardActionCreate)
+
+	assert.Equal(t, api.ErrCSRLifetimeIsTooLong(time.Hour), helper.ExtractHTTPError(err))
+}
+
+//
+// validateCSRLifetime :: for a not expiring card of the expirable version and a limited lifetime :: returns an error.
+//
+func TestValidateCSRLifetimeForANotExpiringCardAndALimitedLifetime(t *testing.T) {
+
+	createdAt := time.Now().UTC().Unix()
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	validator.cardMaxLifetime = time.Hour
+
+	err := validator.validateCSRLifetime(createdAt, &ParametersStore{extension: &api.CSRV6{}}, CardActionCreate)
+
+	assert.Equal(t, api.ErrCSRExpirationTimeIsRequired(time.Hour), helper.ExtractHTTPError(err))
+}
+
+//
+// validateCSRLifetime :: for the version without expiration time and a limited lifetime :: expires in the lifetime.
+//
+func TestValidateCSRLifetimeForANotExpirableVersionAndALimitedLifetime(t *testing.T) {
+
+	createdAt := time.Now().UTC().Unix()
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	validator.cardMaxLifetime = time.Hour
+	params := new(ParametersStore)
+
+	assert.NoError(t, validator.validateCSRLifetime(createdAt, params, CardActionCreate))
+	assert.Equal(t, createdAt+int64(time.Hour/time.Second), params.getExpiresAt())
+}
+
+//
+// validateCSRLifetime :: for a delete card and a limited lifetime :: does not expire the card.
+//
+func TestValidateCSRLifetimeForADeleteCard(t *testing.T) {
+
+	createdAt := time.Now().UTC().Unix()
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	validator.cardMaxLifetime = time.Hour
+	params := &ParametersStore{extension: &api.CSRV6{}}
+
+	assert.NoError(t, validator.validateCSRLifetime(createdAt, params, CardActionDelete))
+	assert.Zero(t, params.getExpiresAt())
+}
+
+//
+// validateCSRLifetime :: for a not expiring card and an unlimited lifetime :: passes.
+//
+func TestValidateCSRLifetimeForValidValues(t *testing.T) {
+
+	createdAt := time.Now().UTC().Unix()
+	validator := getCSRValidatorUnderTest(validatorDeps{})
+	expiresAt := createdAt + int64(time.Hour/time.Second)
+
+	assert.NoError(t, validator.validateCSRLifetime(createdAt, &ParametersStore{extension: &api.CSRV6{}}, CardActionCreate))
+
+	params := &ParametersStore{extension: &api.CSRV6{ExpiresAt: expiresAt}}
+	assert.NoError(t, validator.validateCSRLifetime(createdAt, params, CardActionCreate))
+	assert.Equal(t, expiresAt, params.getExpiresAt())
+}
+
 //
 // validateCSRVersion :: for a valid value :: passes.
 //
@@ -408,7 +619,19 @@ func TestValidateCSRVersionForAValidValue(t *testing.T) {
 //
 func getCSRValidatorUnderTest(deps validatorDeps) *CSRValidator {
 
-	return NewCSRValidator(deps.encoder)
+	if nil == deps.idGenerator {
+		deps.idGenerator = getIDGeneratorUnderTest()
+	}
+
+	return NewCSRValidator(deps.encoder, deps.idGenerator, getCardVersionRegistryUnderTest(), 0, 0, 0)
+}
+
+//
+// getIDGeneratorUnderTest returns an ID generator with the default ID scheme.
+//
+func getIDGeneratorUnderTest() generator.IDProvider {
+
+	return generator.NewID(hasher.NewSHA512(), encoder.NewHex())
 }
 
 //
@@ -467,5 +690,6 @@ func getCSRMessageAsJSON(publicKey, identity, version, previousCardID string, cr
 type validatorDeps struct {
 	crypto         crypto.Provider
 	encoder        encoder.Provider
+	idGenerator    generator.IDProvider
 	cardRepository dao.CardRepositoryProvider
 }
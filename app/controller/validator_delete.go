@@ -68,7 +68,7 @@ func (v *DeleteCardValidator) Validate(
 	)
 	defer span.Finish()
 
-	if err := v.BaseCardValidator.Validate(span, request, virgilCard, false); nil != err {
+	if err := v.BaseCardValidator.Validate(span, request, virgilCard, CardActionDelete); nil != err {
 		return err
 	}
 
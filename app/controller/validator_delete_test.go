@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -100,7 +101,7 @@ func TestDeleteValidateForEmptyPreviousCardID(t *testing.T) {
 	encoder, encodedCSR := presetEncoder(scr)
 
 	crypto := new(mock.Crypto)
-	crypto.On("CalculateCardID", []byte(scr)).Return(cardID)
+	crypto.On("CalculateCardID", validCardVersion, []byte(scr)).Return(cardID)
 
 	cardRepositoryMock := new(mock.CardRepository)
 	cardRepositoryMock.On("GetCardByID", cardID).Return(new(model.CardDTO), errCardGet)
@@ -151,7 +152,7 @@ func TestDeleteValidateForACardThatHasDuplicates(t *testing.T) {
 	crypto.On("ValidateVirgilCardSignature",
 		[]byte(scr), []byte{}, publicKeyBytes, signatureBytes,
 	).Return(nil)
-	crypto.On("CalculateCardID", []byte(scr)).Return(cardID)
+	crypto.On("CalculateCardID", validCardVersion, []byte(scr)).Return(cardID)
 
 	cardRepositoryMock := new(mock.CardRepository)
 	cardRepositoryMock.On("GetCardByID", cardID).Return(new(model.CardDTO), nil)
@@ -187,7 +188,7 @@ func TestDeleteValidatePreviousCardIDForAnEmptyID(t *testing.T) {
 	emptyID := ""
 	validator := getDeleteCardValidatorUnderTest(validatorDeps{})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), emptyID, emptyString, emptyString)
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), emptyID, emptyString, emptyString, 0)
 
 	assert.Empty(t, err)
 }
@@ -208,7 +209,7 @@ func TestDeleteValidatePreviousCardIDForAnExistingPreviousCard(t *testing.T) {
 
 	validator := getDeleteCardValidatorUnderTest(validatorDeps{cardRepository: cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), alreadyClaimedPreviousCardID, scopeID, "")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), alreadyClaimedPreviousCardID, scopeID, "", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrPreviousVirgilCardExistsAlready, err)
@@ -232,7 +233,7 @@ func TestDeleteValidatePreviousCardIDForANotExistingCardID(t *testing.T) {
 
 	validator := getDeleteCardValidatorUnderTest(validatorDeps{cardRepository: cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), nonExistingCardID, scopeID, "")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), nonExistingCardID, scopeID, "", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrPreviousVirgilCardDoesNotExist, helper.ExtractHTTPError(err))
@@ -263,7 +264,7 @@ func TestDeleteValidatePreviousCardIDForAPreviousCardIDRegisteredForAnotherScope
 
 	validator := getDeleteCardValidatorUnderTest(validatorDeps{cardRepository: cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, "")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, "", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, err, api.ErrPreviousVirgilCardIsRegisteredForAnotherScope)
@@ -290,7 +291,7 @@ func TestDeleteValidatePreviousCardIDForAPreviousCardIdentityNotEqualsCurrentOne
 
 	validator := getDeleteCardValidatorUnderTest(validatorDeps{cardRepository: &cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, scopeID, "identity")
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, scopeID, "identity", 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrPreviousVirgilCardIdentityIsIncorrect, err)
@@ -317,7 +318,7 @@ func TestDeleteValidatePreviousCardIDWithAValidPreviousCardIDPasses(t *testing.T
 
 	validator := getDeleteCardValidatorUnderTest(validatorDeps{cardRepository: &cardRepositoryMock})
 
-	err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, validIdentity)
+	_, err := validator.validatePreviousCardID(mock.StartNoopSpan(), previousCardID, correctScopeID, validIdentity, 0)
 
 	assert.Empty(t, err)
 }
@@ -390,11 +391,14 @@ func TestDeleteValidateWithNotEmptyPublicKey(t *testing.T) {
 	crypto.On("ValidateVirgilCardSignature",
 		[]byte(scr), []byte{}, []byte(validPublicKey), signatureBytes,
 	).Return(nil)
-	crypto.On("CalculateCardID", []byte(scr)).Return(cardID)
+	crypto.On("CalculateCardID", validCardVersion, []byte(scr)).Return(cardID)
 
 	cardRepositoryMock := new(mock.CardRepository)
 	cardRepositoryMock.On("GetCardByID", cardID).Return(new(model.CardDTO), errCardGet)
-	cardRepositoryMock.On("GetCardByID", validID).Return(&model.CardDTO{Identity: validIdentity}, nil)
+	cardRepositoryMock.On("GetCardByID", validID).Return(&model.CardDTO{
+		Identity:  validIdentity,
+		PublicKey: []byte(validPublicKey),
+	}, nil)
 	cardRepositoryMock.On("DoesCardExistByPreviousIDAndScopeID",
 		validID,
 		emptyScopeID,
@@ -411,13 +415,84 @@ func TestDeleteValidateWithNotEmptyPublicKey(t *testing.T) {
 			ApplicationID: emptyScopeID,
 			UserID:        validIdentity,
 		},
-		CSR:       encodedCSR,
-		CSRStamps: []api.CSRStamp{},
+		CSR: encodedCSR,
+		CSRStamps: []api.CSRStamp{{
+			Signer:    model.SelfSignatureType,
+			Signature: signatureEncoded,
+		}},
 	}, new(model.CardDTO))
 
 	assert.Equal(t, err, api.ErrCSRPublicKeyMustBeEmpty)
 }
 
+//
+// validate :: for an incorrect revocation reason :: returns an error.
+//
+func TestDeleteValidateForAnIncorrectRevocationReason(t *testing.T) {
+
+	scr, err := getRevocationCSRMessageAsJSON("lost", emptyString)
+
+	assert.Nil(t, err)
+
+	err = validateDeleteRevocationCSR(scr)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrCSRRevocationReasonIsIncorrect, err)
+}
+
+//
+// validate :: for a too long revocation note :: returns an error.
+//
+func TestDeleteValidateForATooLongRevocationNote(t *testing.T) {
+
+	scr, err := getRevocationCSRMessageAsJSON(
+		model.RevocationReasonKeyCompromise,
+		strings.Repeat("a", RevocationNoteMaxLength+1),
+	)
+
+	assert.Nil(t, err)
+
+	err = validateDeleteRevocationCSR(scr)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrCSRRevocationNoteIsTooLong(RevocationNoteMaxLength), err)
+}
+
+//
+// getRevocationCSRMessageAsJSON returns a serialized delete CSR message with the revocation details given.
+//
+func getRevocationCSRMessageAsJSON(reason, note string) (string, error) {
+
+	jsonMessage, err := json.Marshal(api.CSR{
+		Identity:         validIdentity,
+		PreviousCardID:   validID,
+		Version:          validCardVersion,
+		CreatedAt:        time.Now().UTC().Unix(),
+		RevocationReason: reason,
+		RevocationNote:   note,
+	})
+
+	return string(jsonMessage), err
+}
+
+//
+// validateDeleteRevocationCSR validates the delete request of the CSR message given.
+//
+func validateDeleteRevocationCSR(scr string) error {
+
+	encoder, encodedCSR := presetEncoder(scr)
+	validator := getDeleteCardValidatorUnderTest(validatorDeps{encoder: encoder})
+
+	return validator.Validate(mock.StartNoopSpan(), &api.CardDeleteRequest{
+		Headers: &api.Headers{
+			UserID:        validIdentity,
+			ApplicationID: emptyScopeID,
+		},
+		CSR:       encodedCSR,
+		CSRStamps: []api.CSRStamp{},
+	}, new(model.CardDTO))
+}
+
 //
 // getDeleteCardValidatorUnderTest returns validator instance.
 //
@@ -435,10 +510,13 @@ func getDeleteCardValidatorUnderTest(deps validatorDeps) *DeleteCardValidator {
 		deps.crypto = &mock.Crypto{}
 	}
 
+	if nil == deps.idGenerator {
+		deps.idGenerator = getIDGeneratorUnderTest()
+	}
+
 	return NewDeleteCardValidator(deps.cardRepository, deps.crypto, &CSRValidator{
-		encoder: deps.encoder,
-	}, &CSRStampsValidator{
-		crypto:  deps.crypto,
-		encoder: deps.encoder,
-	})
+		encoder:      deps.encoder,
+		idGenerator:  deps.idGenerator,
+		cardVersions: getCardVersionRegistryUnderTest(),
+	}, getCSRStampsValidatorUnderTest(deps.crypto, deps.encoder))
 }
@@ -10,7 +10,10 @@ import (
 // Validator performs card search request validations.
 //
 const (
-	searchIdentitiesLimit = 50
+	searchIdentitiesLimit     = 50
+	searchPageIdentitiesLimit = 10000
+	searchPageSizeDefault     = 100
+	searchPageSizeLimit       = 1000
 )
 
 //
@@ -50,11 +53,24 @@ func (v *SearchCardValidator) Validate(span tracer.Span, cardSearchRequest *api.
 	)
 	defer span.Finish()
 
+	identitiesLimit := searchIdentitiesLimit
+	if cardSearchRequest.IsPaginated() {
+		if cardSearchRequest.LatestOnly {
+			return tracer.SetSpanErrorAndReturn(span, api.ErrSearchLatestOnlyIsNotPaginated)
+		}
+
+		identitiesLimit = searchPageIdentitiesLimit
+
+		if 0 > cardSearchRequest.Limit || searchPageSizeLimit < cardSearchRequest.Limit {
+			return tracer.SetSpanErrorAndReturn(span, api.ErrSearchPageSizeIsLimited(searchPageSizeLimit))
+		}
+	}
+
 	identitiesCount := len(cardSearchRequest.GetIdentities())
 	if identitiesCount == 0 {
 		return tracer.SetSpanErrorAndReturn(span, api.ErrIdentitySearchTermCannotBeEmpty)
-	} else if searchIdentitiesLimit < identitiesCount {
-		return tracer.SetSpanErrorAndReturn(span, api.ErrIdentitySearchCountIsLimited(searchIdentitiesLimit))
+	} else if identitiesLimit < identitiesCount {
+		return tracer.SetSpanErrorAndReturn(span, api.ErrIdentitySearchCountIsLimited(identitiesLimit))
 	}
 
 	return nil
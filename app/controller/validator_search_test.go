@@ -70,3 +70,57 @@ func TestValidateSearchRequestWithWrongCountOfIdentities(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, api.ErrIdentitySearchCountIsLimited(searchIdentitiesLimit), err)
 }
+
+//
+// TestValidateSearchRequestForPaginatedSearch :: for valid paginated Search request :: passes.
+//
+func TestValidateSearchRequestForPaginatedSearch(t *testing.T) {
+
+	validator := NewSearchCardValidator()
+
+	err := validator.Validate(mock.StartNoopSpan(), &api.CardSearchRequest{
+		Identities: func() []string {
+			var identities []string
+			for i := 0; i < searchPageIdentitiesLimit; i++ {
+				identities = append(identities, "identity")
+			}
+			return identities
+		}(),
+		Limit: searchPageSizeLimit,
+	})
+
+	assert.NoError(t, err)
+}
+
+//
+// TestValidateSearchRequestWithWrongPageSize :: for wrong paginated Search page size :: returns an error.
+//
+func TestValidateSearchRequestWithWrongPageSize(t *testing.T) {
+
+	validator := NewSearchCardValidator()
+
+	for _, limit := range []int{-1, searchPageSizeLimit + 1} {
+		err := validator.Validate(mock.StartNoopSpan(), &api.CardSearchRequest{
+			Identity: "identity",
+			Limit:    limit,
+		})
+
+		assert.Equal(t, api.ErrSearchPageSizeIsLimited(searchPageSizeLimit), err)
+	}
+}
+
+//
+// TestValidateSearchRequestForPaginatedLatestOnlySearch :: for paginated Search of the latest cards :: returns an error.
+//
+func TestValidateSearchRequestForPaginatedLatestOnlySearch(t *testing.T) {
+
+	validator := NewSearchCardValidator()
+
+	err := validator.Validate(mock.StartNoopSpan(), &api.CardSearchRequest{
+		Identity:   "identity",
+		Limit:      10,
+		LatestOnly: true,
+	})
+
+	assert.Equal(t, api.ErrSearchLatestOnlyIsNotPaginated, err)
+}
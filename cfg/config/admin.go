@@ -0,0 +1,25 @@
+package config
+
+//
+// GetAdminHTTPAddress returns an Admin HTTP Server address. Empty means the admin API is disabled.
+//
+func (c *Config) GetAdminHTTPAddress() string {
+
+	return c.config.GetString(ConfAdminHTTPAddress)
+}
+
+//
+// IsAdminEnabled returns true if the admin API is served on its own address.
+//
+func (c *Config) IsAdminEnabled() bool {
+
+	return "" != c.GetAdminHTTPAddress()
+}
+
+//
+// GetAdminToken returns the Admin API bearer token.
+//
+func (c *Config) GetAdminToken() string {
+
+	return c.config.GetString(ConfAdminToken)
+}
@@ -0,0 +1,34 @@
+package config
+
+//
+// Card audit log sink types.
+//
+const (
+	AuditSinkCassandra = "cassandra"
+	AuditSinkMemory    = "memory"
+	AuditSinkFile      = "file"
+)
+
+//
+// GetAuditSink returns a card audit log sink type. The cards storage type is used unless the sink is set.
+//
+func (c *Config) GetAuditSink() string {
+
+	if sink := c.config.GetString(ConfAuditSink); "" != sink {
+		return sink
+	}
+
+	if c.IsMemoryStorage() {
+		return AuditSinkMemory
+	}
+
+	return AuditSinkCassandra
+}
+
+//
+// GetAuditFile returns a path to the card audit log file.
+//
+func (c *Config) GetAuditFile() string {
+
+	return c.config.GetString(ConfAuditFile)
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"time"
+)
+
+//
+// GetCardMaxLifetime returns the maximum Virgil Card lifetime. Zero means the lifetime is unlimited.
+//
+func (c *Config) GetCardMaxLifetime() time.Duration {
+
+	return c.config.GetDuration(ConfCardMaxLifetime)
+}
+
+//
+// GetCSRCreatedAtPastSkew returns the maximum time the CSR creation time may precede the server time.
+// Zero means the skew is unlimited.
+//
+func (c *Config) GetCSRCreatedAtPastSkew() time.Duration {
+
+	return c.config.GetDuration(ConfCSRCreatedAtPastSkew)
+}
+
+//
+// GetCSRCreatedAtFutureSkew returns the maximum time the CSR creation time may exceed the server time.
+// Zero means the skew is unlimited.
+//
+func (c *Config) GetCSRCreatedAtFutureSkew() time.Duration {
+
+	return c.config.GetDuration(ConfCSRCreatedAtFutureSkew)
+}
+
+//
+// GetSignerKeysFile returns a path to the JSON file with trusted CSR stamp signer public keys.
+//
+func (c *Config) GetSignerKeysFile() string {
+
+	return c.config.GetString(ConfSignerKeysFile)
+}
+
+//
+// GetStampPoliciesFile returns a path to the JSON file with CSR stamp policies of the applications.
+//
+func (c *Config) GetStampPoliciesFile() string {
+
+	return c.config.GetString(ConfStampPoliciesFile)
+}
+
+//
+// IsDeleteAppStampRequired returns true if the Virgil Card delete requests must contain an application signature.
+//
+func (c *Config) IsDeleteAppStampRequired() bool {
+
+	return c.config.GetBool(ConfDeleteAppStampRequired)
+}
+
+//
+// IsUnregisteredSignersAllowed returns true if the CSR stamps of the not registered signers are accepted unverified.
+//
+func (c *Config) IsUnregisteredSignersAllowed() bool {
+
+	return c.config.GetBool(ConfUnregisteredSignersAllowed)
+}
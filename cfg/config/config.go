@@ -12,14 +12,33 @@ import (
 //
 const (
 	ConfCassandra                   = "CARDS5_CASSANDRA"
+	ConfStorage                     = "CARDS5_STORAGE"
+	ConfAuditSink                   = "CARDS5_AUDIT_SINK"
+	ConfAuditFile                   = "CARDS5_AUDIT_FILE"
+	ConfCardMaxLifetime             = "CARDS5_CARD_MAX_LIFETIME"
+	ConfCSRCreatedAtPastSkew        = "CARDS5_CSR_CREATED_AT_PAST_SKEW"
+	ConfCSRCreatedAtFutureSkew      = "CARDS5_CSR_CREATED_AT_FUTURE_SKEW"
+	ConfSignerKeysFile              = "CARDS5_SIGNER_KEYS_FILE"
+	ConfStampPoliciesFile           = "CARDS5_STAMP_POLICIES_FILE"
+	ConfDeleteAppStampRequired      = "CARDS5_DELETE_APP_STAMP_REQUIRED"
+	ConfUnregisteredSignersAllowed  = "CARDS5_UNREGISTERED_SIGNERS_ALLOWED"
 	ConfServerHTTPAddress           = "CARDS5_SERVER_ADDRESS"
 	ConfServerReadTimeout           = "CARDS5_SERVER_READ_TIMEOUT"
 	ConfServerWriteTimeout          = "CARDS5_SERVER_WRITE_TIMEOUT"
+	ConfAdminHTTPAddress            = "CARDS5_ADMIN_ADDRESS"
+	ConfAdminToken                  = "CARDS5_ADMIN_TOKEN"
 	ConfLogLevel                    = "CARDS5_LOG_LEVEL"
 	ConfEventsAddress               = "CARDS5_EVENTS_ADDRESS"
 	ConfEventsPushPeriod            = "CARDS5_EVENTS_PUSH_PERIOD"
 	ConfServicePrivateKey           = "CARDS5_PRIVATE_KEY"
 	ConfServicePrivateKeyPassword   = "CARDS5_PRIVATE_KEY_PASSWORD"
+	ConfServiceRetiredPublicKeys    = "CARDS5_RETIRED_PUBLIC_KEYS"
+	ConfServicePrivateKeySource     = "CARDS5_PRIVATE_KEY_SOURCE"
+	ConfServicePrivateKeyFile       = "CARDS5_PRIVATE_KEY_FILE"
+	ConfServiceKeyFileEncoding      = "CARDS5_PRIVATE_KEY_FILE_ENCODING"
+	ConfServiceKeyPasswordFile      = "CARDS5_PRIVATE_KEY_PASSWORD_FILE"
+	ConfSigningAgentSocket          = "CARDS5_SIGNING_AGENT_SOCKET"
+	ConfSigningAgentTimeout         = "CARDS5_SIGNING_AGENT_TIMEOUT"
 	ConfTracerDisabled              = "CARDS5_TRACER_DISABLED"
 	ConfTracerAgentAddress          = "CARDS5_TRACER_AGENT_ADDRESS"
 	ConfTracerSamplerType           = "CARDS5_TRACER_SAMPLER_TYPE"
@@ -40,6 +59,8 @@ const (
 //
 type Config struct {
 	config *config.Config
+
+	serviceRetiredPublicKeys [][]byte
 }
 
 //
@@ -65,6 +86,17 @@ func New() (*Config, error) {
 			5*time.Second,
 		),
 
+		config.NewString(
+			ConfAdminHTTPAddress,
+			"Admin HTTP server address for binding. Empty means the admin API is disabled.",
+			"",
+		),
+		config.NewString(
+			ConfAdminToken,
+			"Admin API bearer token. It is required if the admin API is enabled.",
+			"",
+		),
+
 		config.NewLoggerLevel(
 			ConfLogLevel,
 			"Logging level",
@@ -74,6 +106,63 @@ func New() (*Config, error) {
 			ConfCassandra,
 			"Cassandra db connection string.",
 		),
+		config.NewString(
+			ConfStorage,
+			"Cards storage type. Allowed values are: cassandra, memory.",
+			StorageCassandra,
+		),
+		config.NewString(
+			ConfAuditSink,
+			"Card audit log sink type. Allowed values are: cassandra, memory, file. Empty means the cards storage type.",
+			"",
+		),
+		config.NewString(
+			ConfAuditFile,
+			"Path to the JSON Lines file the card audit log is appended to by the file sink.",
+			"",
+		),
+
+		config.NewDuration(
+			ConfCardMaxLifetime,
+			"Maximum Virgil Card lifetime from its creation to its expiration. Zero means unlimited.",
+			0,
+		),
+
+		config.NewDuration(
+			ConfCSRCreatedAtPastSkew,
+			"Maximum time the CSR creation time may precede the server time. Zero means unlimited.",
+			24*time.Hour,
+		),
+
+		config.NewDuration(
+			ConfCSRCreatedAtFutureSkew,
+			"Maximum time the CSR creation time may exceed the server time. Zero means unlimited.",
+			5*time.Minute,
+		),
+
+		config.NewString(
+			ConfSignerKeysFile,
+			"JSON file with trusted CSR stamp signer public keys per application ID. Empty means no signers are trusted.",
+			"",
+		),
+
+		config.NewString(
+			ConfStampPoliciesFile,
+			"JSON file with CSR stamp policies per application ID. Empty means no application policies.",
+			"",
+		),
+
+		config.NewBool(
+			ConfDeleteAppStampRequired,
+			"Require an application signature on the Virgil Card delete requests.",
+			false,
+		),
+
+		config.NewBool(
+			ConfUnregisteredSignersAllowed,
+			"Accept CSR stamps of the signers without a public key registered for the application unverified.",
+			false,
+		),
 
 		config.NewString(
 			ConfEventsAddress,
@@ -93,9 +182,44 @@ func New() (*Config, error) {
 		),
 		config.NewBase64String(
 			ConfServicePrivateKeyPassword,
-			"Cards Service Private Key Password.",
+			"Cards Service Private Key Password for the env key source.",
+			"",
+		),
+		config.NewString(
+			ConfServiceRetiredPublicKeys,
+			"Comma separated base64 encoded Cards Service retired Public Keys the cards signed before are verified with.",
+			"",
+		),
+		config.NewString(
+			ConfServicePrivateKeySource,
+			"Cards Service Private Key source. Allowed values are: env, file, agent.",
+			KeySourceEnv,
+		),
+		config.NewString(
+			ConfServicePrivateKeyFile,
+			"Path to the password encrypted Cards Service Private Key file for the file key source.",
 			"",
 		),
+		config.NewString(
+			ConfServiceKeyFileEncoding,
+			"Cards Service Private Key file encoding for the file key source. Allowed values are: raw, base64.",
+			KeyFileEncodingRaw,
+		),
+		config.NewString(
+			ConfServiceKeyPasswordFile,
+			"Path to the file with the Cards Service Private Key Password for the file key source.",
+			"",
+		),
+		config.NewString(
+			ConfSigningAgentSocket,
+			"Unix socket path of the signing agent holding the Cards Service Private Key for the agent key source.",
+			"",
+		),
+		config.NewDuration(
+			ConfSigningAgentTimeout,
+			"Signing agent request timeout.",
+			5*time.Second,
+		),
 
 		config.NewBool(
 			ConfTracerDisabled,
@@ -128,17 +252,71 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
-	// TODO I want to setup this check as a custom validator for given parameter.
-	privateKey := c.GetBase64String(ConfServicePrivateKey)
-	if len(privateKey) == 0 {
-		return nil, errors.New("config parameter (%s) was not set", ConfServicePrivateKey)
+	if storage := c.GetString(ConfStorage); StorageCassandra != storage && StorageMemory != storage {
+		return nil, errors.New("config parameter (%s) has unsupported value (%s)", ConfStorage, storage)
+	}
+
+	switch sink := c.GetString(ConfAuditSink); sink {
+	case "", AuditSinkCassandra, AuditSinkMemory:
+	case AuditSinkFile:
+		if "" == c.GetString(ConfAuditFile) {
+			return nil, errors.New("config parameter (%s) was not set", ConfAuditFile)
+		}
+	default:
+		return nil, errors.New("config parameter (%s) has unsupported value (%s)", ConfAuditSink, sink)
+	}
+
+	if "" != c.GetString(ConfAdminHTTPAddress) && "" == c.GetString(ConfAdminToken) {
+		return nil, errors.New("config parameter (%s) was not set", ConfAdminToken)
 	}
 
-	if len(privateKey) != 0 && len(c.GetBase64String(ConfServicePrivateKeyPassword)) == 0 {
-		return nil, errors.New("config parameter (%s) was not set", ConfServicePrivateKeyPassword)
+	for _, name := range []string{ConfCardMaxLifetime, ConfCSRCreatedAtPastSkew, ConfCSRCreatedAtFutureSkew} {
+		if 0 > c.GetDuration(name) {
+			return nil, errors.New("config parameter (%s) must not be negative", name)
+		}
+	}
+
+	switch source := c.GetString(ConfServicePrivateKeySource); source {
+	case KeySourceEnv:
+		// TODO I want to setup this check as a custom validator for given parameter.
+		privateKey := c.GetBase64String(ConfServicePrivateKey)
+		if len(privateKey) == 0 {
+			return nil, errors.New("config parameter (%s) was not set", ConfServicePrivateKey)
+		}
+
+		if len(privateKey) != 0 && len(c.GetBase64String(ConfServicePrivateKeyPassword)) == 0 {
+			return nil, errors.New("config parameter (%s) was not set", ConfServicePrivateKeyPassword)
+		}
+	case KeySourceFile:
+		if "" == c.GetString(ConfServicePrivateKeyFile) {
+			return nil, errors.New("config parameter (%s) was not set", ConfServicePrivateKeyFile)
+		}
+
+		if "" == c.GetString(ConfServiceKeyPasswordFile) {
+			return nil, errors.New("config parameter (%s) was not set", ConfServiceKeyPasswordFile)
+		}
+
+		switch encoding := c.GetString(ConfServiceKeyFileEncoding); encoding {
+		case KeyFileEncodingRaw, KeyFileEncodingBase64:
+		default:
+			return nil, errors.New("config parameter (%s) has unsupported value (%s)", ConfServiceKeyFileEncoding, encoding)
+		}
+	case KeySourceAgent:
+		if "" == c.GetString(ConfSigningAgentSocket) {
+			return nil, errors.New("config parameter (%s) was not set", ConfSigningAgentSocket)
+		}
+	default:
+		return nil, errors.New("config parameter (%s) has unsupported value (%s)", ConfServicePrivateKeySource, source)
+	}
+
+	serviceRetiredPublicKeys, err := decodeServiceRetiredPublicKeys(c.GetString(ConfServiceRetiredPublicKeys))
+	if nil != err {
+		return nil, errors.WithMessage(err, "config parameter (%s) is incorrect", ConfServiceRetiredPublicKeys)
 	}
 
 	return &Config{
 		config: c,
+
+		serviceRetiredPublicKeys: serviceRetiredPublicKeys,
 	}, nil
 }
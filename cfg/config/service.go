@@ -1,5 +1,30 @@
 package config
 
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+)
+
+//
+// Service private key source types.
+//
+const (
+	KeySourceEnv   = "env"
+	KeySourceFile  = "file"
+	KeySourceAgent = "agent"
+)
+
+//
+// Service private key file encodings.
+//
+const (
+	KeyFileEncodingRaw    = "raw"
+	KeyFileEncodingBase64 = "base64"
+)
+
 //
 // GetServicePrivateKey returns service Private Key.
 //
@@ -15,3 +40,85 @@ func (c *Config) GetServicePrivateKeyPassword() []byte {
 	// TODO we should move this data to the Vault.
 	return c.config.GetBase64String(ConfServicePrivateKeyPassword)
 }
+
+//
+// GetServiceRetiredPublicKeys returns service retired Public Keys decoded on the config validation.
+//
+func (c *Config) GetServiceRetiredPublicKeys() [][]byte {
+
+	return c.serviceRetiredPublicKeys
+}
+
+//
+// decodeServiceRetiredPublicKeys decodes comma separated base64 encoded public keys.
+// An empty value means no retired keys, but an empty key in the list is an error.
+//
+func decodeServiceRetiredPublicKeys(value string) ([][]byte, error) {
+
+	if "" == strings.TrimSpace(value) {
+		return nil, nil
+	}
+
+	var keys [][]byte
+	for i, encodedKey := range strings.Split(value, ",") {
+		if encodedKey = strings.TrimSpace(encodedKey); "" == encodedKey {
+			return nil, errors.New("public key #%d is empty", i)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if nil != err {
+			return nil, errors.WithMessage(err, "public key #%d decode error", i)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+//
+// GetServicePrivateKeySource returns service Private Key source type.
+//
+func (c *Config) GetServicePrivateKeySource() string {
+
+	return c.config.GetString(ConfServicePrivateKeySource)
+}
+
+//
+// GetServicePrivateKeyFile returns a path to the password encrypted service Private Key file.
+//
+func (c *Config) GetServicePrivateKeyFile() string {
+
+	return c.config.GetString(ConfServicePrivateKeyFile)
+}
+
+//
+// IsServicePrivateKeyFileBase64 returns true if the service Private Key file is base64 encoded.
+//
+func (c *Config) IsServicePrivateKeyFileBase64() bool {
+
+	return KeyFileEncodingBase64 == c.config.GetString(ConfServiceKeyFileEncoding)
+}
+
+//
+// GetServicePrivateKeyPasswordFile returns a path to the file with the service Private Key password.
+//
+func (c *Config) GetServicePrivateKeyPasswordFile() string {
+
+	return c.config.GetString(ConfServiceKeyPasswordFile)
+}
+
+//
+// GetSigningAgentSocket returns a Unix socket path of the signing agent.
+//
+func (c *Config) GetSigningAgentSocket() string {
+
+	return c.config.GetString(ConfSigningAgentSocket)
+}
+
+//
+// GetSigningAgentTimeout returns a signing agent request timeout.
+//
+func (c *Config) GetSigningAgentTimeout() time.Duration {
+
+	return c.config.GetDuration(ConfSigningAgentTimeout)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// decodeServiceRetiredPublicKeys :: for an empty value :: returns no keys.
+//
+func TestDecodeServiceRetiredPublicKeysForAnEmptyValue(t *testing.T) {
+
+	keys, err := decodeServiceRetiredPublicKeys(" ")
+
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+//
+// decodeServiceRetiredPublicKeys :: for comma separated base64 encoded keys :: returns the decoded keys.
+//
+func TestDecodeServiceRetiredPublicKeysForEncodedKeys(t *testing.T) {
+
+	keys, err := decodeServiceRetiredPublicKeys("a2V5IDE=, a2V5IDI=")
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key 1"), []byte("key 2")}, keys)
+}
+
+//
+// decodeServiceRetiredPublicKeys :: for a malformed value :: returns an error.
+//
+func TestDecodeServiceRetiredPublicKeysForAMalformedValue(t *testing.T) {
+
+	for _, value := range []string{"a2V5IDE=,not base64", "a2V5IDE=,,a2V5IDI=", "a2V5IDE=,"} {
+		keys, err := decodeServiceRetiredPublicKeys(value)
+
+		assert.Error(t, err, value)
+		assert.Nil(t, keys, value)
+	}
+}
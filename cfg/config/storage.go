@@ -0,0 +1,25 @@
+package config
+
+//
+// Storage types.
+//
+const (
+	StorageCassandra = "cassandra"
+	StorageMemory    = "memory"
+)
+
+//
+// GetStorage returns a cards storage type.
+//
+func (c *Config) GetStorage() string {
+
+	return c.config.GetString(ConfStorage)
+}
+
+//
+// IsMemoryStorage returns true if cards are kept in memory instead of the Cassandra database.
+//
+func (c *Config) IsMemoryStorage() bool {
+
+	return StorageMemory == c.GetStorage()
+}
@@ -44,13 +44,25 @@ func (c *Container) Build() error {
 		c.registerCryptoIDGenerator,
 		c.registerCryptoHasher,
 		c.registerHTTPRouter,
+		c.registerAdminHTTPRouter,
 		c.registerLogger,
 		c.registerCassandraClient,
 		c.registerEventMeter,
 		c.registerCardsHandler,
 		c.registerCardController,
+		c.registerAdminHandler,
+		c.registerAdminController,
 		c.registerCardRepository,
+		c.registerCardAuditSink,
+		c.registerSchemaMigrator,
+		c.registerSignerKeyRepository,
+		c.registerStampPolicyRepository,
 		c.registerCardSigner,
+		c.registerTransparencyLog,
+		c.registerTransparencyLogRepository,
+		c.registerKeySource,
+		c.registerCardVerifier,
+		c.registerCardVersionRegistry,
 		c.registerTracer,
 		c.registerEncoderBase64,
 		c.registerEncoderHex,
@@ -59,6 +71,7 @@ func (c *Container) Build() error {
 		c.registerValidatorCreateCard,
 		c.registerValidatorSearchCard,
 		c.registerValidatorDeleteCard,
+		c.registerValidatorBatchGetCard,
 	} {
 		if err := dep(); err != nil {
 			return err
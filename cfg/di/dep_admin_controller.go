@@ -0,0 +1,41 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/app/admin"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefAdminController = "AdminController"
+)
+
+//
+// registerAdminController dependency registrar.
+//
+func (c *Container) registerAdminController() error {
+
+	return c.RegisterDependency(
+		DefAdminController,
+		func(ctx di.Context) (interface{}, error) {
+
+			return admin.New(
+				c.GetCardSigner(),
+				c.GetCardRepository(),
+				c.GetCardAuditSink(),
+			), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetAdminController dependency retriever.
+//
+func (c *Container) GetAdminController() admin.Provider {
+
+	return c.Container.Get(DefAdminController).(admin.Provider)
+}
@@ -0,0 +1,39 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-cards/src/transport"
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefAdminHandler = "AdminHandler"
+)
+
+//
+// registerAdminHandler dependency registrar.
+//
+func (c *Container) registerAdminHandler() error {
+
+	return c.RegisterDependency(
+		DefAdminHandler,
+		func(ctx di.Context) (interface{}, error) {
+
+			return transport.NewAdminHandler(
+				c.GetAdminController(),
+				c.GetCardController(),
+			), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetAdminHandler dependency retriever.
+//
+func (c *Container) GetAdminHandler() *transport.AdminHandler {
+
+	return c.Container.Get(DefAdminHandler).(*transport.AdminHandler)
+}
@@ -0,0 +1,47 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+	"github.com/VirgilSecurity/virgil-services-core-kit/http"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/router/routes"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefAdminHTTPRouter = "AdminHTTPRouter"
+)
+
+//
+// registerAdminHTTPRouter dependency registrar.
+//
+func (c *Container) registerAdminHTTPRouter() error {
+
+	return c.RegisterDependency(
+		DefAdminHTTPRouter,
+		func(ctx di.Context) (interface{}, error) {
+
+			r := http.NewRouter(
+				c.GetLogger(),
+				c.GetConfig().GetMetricPrefix()+"_admin",
+				http.SetupHealthDependencyList(),
+			)
+
+			// Admin endpoints.
+			routes.InitAdminRouteList(c.GetTracer(), r, c.GetConfig().GetAdminToken(), c.GetAdminHandler())
+
+			return r, nil
+
+		}, nil,
+	)
+}
+
+//
+// GetAdminHTTPRouter dependency retriever.
+//
+func (c *Container) GetAdminHTTPRouter() http.RouterProvider {
+
+	return c.Container.Get(DefAdminHTTPRouter).(http.RouterProvider)
+}
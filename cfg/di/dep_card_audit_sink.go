@@ -0,0 +1,55 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/cfg/config"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefCardAuditSink = "CardAuditSink"
+)
+
+//
+// registerCardAuditSink dependency registrar.
+//
+func (c *Container) registerCardAuditSink() error {
+
+	return c.RegisterDependency(
+		DefCardAuditSink,
+		func(ctx di.Context) (interface{}, error) {
+
+			switch c.GetConfig().GetAuditSink() {
+			case config.AuditSinkFile:
+				return dao.NewFileCardAuditSink(c.GetConfig().GetAuditFile())
+			case config.AuditSinkMemory:
+				return dao.NewMemoryCardAuditSink(), nil
+			default:
+				return dao.NewCardAuditRepository(
+					c.GetCassandraClient(),
+				), nil
+			}
+		},
+		func(obj interface{}) error {
+
+			// The file sink holds the log file open.
+			if sink, ok := obj.(*dao.FileCardAuditSink); ok {
+				return sink.Close()
+			}
+
+			return nil
+		},
+	)
+}
+
+//
+// GetCardAuditSink dependency retriever.
+//
+func (c *Container) GetCardAuditSink() dao.CardAuditSinkProvider {
+
+	return c.Container.Get(DefCardAuditSink).(dao.CardAuditSinkProvider)
+}
@@ -28,6 +28,13 @@ func (c *Container) registerCardController() error {
 				c.GetValidatorCreateCard(),
 				c.GetValidatorSearchCard(),
 				c.GetValidatorDeleteCard(),
+				c.GetValidatorBatchGetCard(),
+				c.GetCardVersionRegistry(),
+				c.GetEventMeter(),
+				c.GetCardVerifier(),
+				c.GetCardAuditSink(),
+				c.GetTransparencyLog(),
+				c.GetLogger(),
 			), nil
 		},
 		nil,
@@ -22,6 +22,10 @@ func (c *Container) registerCardRepository() error {
 		DefCardRepository,
 		func(ctx di.Context) (interface{}, error) {
 
+			if c.GetConfig().IsMemoryStorage() {
+				return dao.NewMemoryCardRepository(), nil
+			}
+
 			return dao.NewCardRepository(
 				c.GetCassandraClient(),
 			), nil
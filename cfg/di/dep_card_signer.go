@@ -23,31 +23,19 @@ func (c *Container) registerCardSigner() error {
 		DefCardSigner,
 		func(ctx di.Context) (interface{}, error) {
 
-			crypto := c.GetCrypto()
-			privateKey, err := crypto.ImportPrivateKey(
-				c.GetConfig().GetServicePrivateKey(),
-				string(c.GetConfig().GetServicePrivateKeyPassword()),
-			)
-
-			if err != nil {
-				return nil, errors.WithMessage(err, "private key import error")
-			}
-
-			publicKey, err := privateKey.ExtractPublicKey()
-			if err != nil {
-				return nil, errors.WithMessage(err, "extract public key error")
-			}
-
-			publicKeyBytes, err := publicKey.Encode()
+			signer, err := c.GetKeySource().Signer()
 			if nil != err {
-				return nil, errors.WithMessage(err, "decode public key error")
+				return nil, errors.WithMessage(err, "service key signer error")
 			}
 
+			crypto := c.GetCrypto()
+
 			return model.NewSigner(
 				crypto,
-				crypto.CalculatePublicKeyID(publicKeyBytes),
-				privateKey,
-			), err
+				crypto.CalculatePublicKeyID(signer.PublicKey()),
+				signer,
+				c.GetConfig().GetServiceRetiredPublicKeys(),
+			), nil
 		},
 		nil,
 	)
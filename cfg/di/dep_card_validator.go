@@ -15,6 +15,8 @@ const (
 	DefValidatorCreateCard = "ValidatorCreateCard"
 	DefValidatorSearchCard = "ValidatorSearchCard"
 	DefValidatorDeleteCard = "ValidatorDeleteCard"
+
+	DefValidatorBatchGetCard = "ValidatorBatchGetCard"
 )
 
 //
@@ -27,7 +29,12 @@ func (c *Container) registerValidatorCSR() error {
 		func(ctx di.Context) (interface{}, error) {
 
 			return controller.NewCSRValidator(
-				c.GetEncoderBase64()), nil
+				c.GetEncoderBase64(),
+				c.GetCryptoIDGenerator(),
+				c.GetCardVersionRegistry(),
+				c.config.GetCardMaxLifetime(),
+				c.config.GetCSRCreatedAtPastSkew(),
+				c.config.GetCSRCreatedAtFutureSkew()), nil
 		},
 		nil,
 	)
@@ -52,7 +59,11 @@ func (c *Container) registerValidatorCSRStamps() error {
 
 			return controller.NewCSRStampsValidator(
 				c.GetCrypto(),
-				c.GetEncoderBase64()), nil
+				c.GetEncoderBase64(),
+				c.GetSignerKeyRepository(),
+				c.GetStampPolicyRepository(),
+				c.config.IsDeleteAppStampRequired(),
+				c.config.IsUnregisteredSignersAllowed()), nil
 		},
 		nil,
 	)
@@ -144,3 +155,26 @@ func (c *Container) GetValidatorDeleteCard() controller.DeleteCardValidatorProvi
 
 	return c.Container.Get(DefValidatorDeleteCard).(controller.DeleteCardValidatorProvider)
 }
+
+//
+// registerValidatorBatchGetCard dependency registrar.
+//
+func (c *Container) registerValidatorBatchGetCard() error {
+
+	return c.RegisterDependency(
+		DefValidatorBatchGetCard,
+		func(ctx di.Context) (interface{}, error) {
+
+			return controller.NewBatchGetCardValidator(), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetValidatorBatchGetCard dependency retriever.
+//
+func (c *Container) GetValidatorBatchGetCard() controller.BatchGetCardValidatorProvider {
+
+	return c.Container.Get(DefValidatorBatchGetCard).(controller.BatchGetCardValidatorProvider)
+}
@@ -0,0 +1,41 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/verifier"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefCardVerifier = "CardVerifier"
+)
+
+//
+// registerCardVerifier dependency registrar.
+//
+func (c *Container) registerCardVerifier() error {
+
+	return c.RegisterDependency(
+		DefCardVerifier,
+		func(ctx di.Context) (interface{}, error) {
+
+			return verifier.New(
+				c.GetCrypto(),
+				c.GetCryptoIDGenerator(),
+				c.GetCardSigner().GetServiceKeys(),
+			)
+		},
+		nil,
+	)
+}
+
+//
+// GetCardVerifier dependency retriever.
+//
+func (c *Container) GetCardVerifier() verifier.Provider {
+
+	return c.Container.Get(DefCardVerifier).(verifier.Provider)
+}
@@ -0,0 +1,37 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/app/controller"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefCardVersionRegistry = "CardVersionRegistry"
+)
+
+//
+// registerCardVersionRegistry dependency registrar.
+//
+func (c *Container) registerCardVersionRegistry() error {
+
+	return c.RegisterDependency(
+		DefCardVersionRegistry,
+		func(ctx di.Context) (interface{}, error) {
+
+			return controller.NewDefaultCardVersionRegistry()
+		},
+		nil,
+	)
+}
+
+//
+// GetCardVersionRegistry dependency retriever.
+//
+func (c *Container) GetCardVersionRegistry() *controller.CardVersionRegistry {
+
+	return c.Container.Get(DefCardVersionRegistry).(*controller.CardVersionRegistry)
+}
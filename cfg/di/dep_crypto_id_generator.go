@@ -4,6 +4,8 @@ import (
 	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
 
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/generator"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/hasher"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
 )
 
 //
@@ -22,10 +24,25 @@ func (c *Container) registerCryptoIDGenerator() error {
 		DefCryptoIDGenerator,
 		func(ctx di.Context) (interface{}, error) {
 
-			return generator.NewID(
+			idGenerator := generator.NewID(
 				c.GetCryptoHasher(),
 				c.GetEncoderHex(),
-			), nil
+			)
+
+			// Card versions keep their ID schemes forever, so the existing chains are not broken
+			// by a newer card version hashing the IDs in another way.
+			if err := idGenerator.SelectIDScheme(generator.IDSchemeSHA512, model.CardVersion5); nil != err {
+				return nil, err
+			}
+			if err := idGenerator.RegisterIDScheme(&generator.IDScheme{
+				Name:   generator.IDSchemeSHA256,
+				Hasher: hasher.NewSHA256(),
+				Bytes:  generator.VirgilCardBytesInID,
+			}, model.CardVersion6); nil != err {
+				return nil, err
+			}
+
+			return idGenerator, nil
 		},
 		nil,
 	)
@@ -23,12 +23,18 @@ func (c *Container) registerHTTPRouter() error {
 		DefHTTPRouter,
 		func(ctx di.Context) (interface{}, error) {
 
+			// There is no database to check the health of while cards are kept in memory.
+			healthDependencyList := http.SetupHealthDependencyList()
+			if !c.GetConfig().IsMemoryStorage() {
+				healthDependencyList = http.SetupHealthDependencyList(
+					c.GetCassandraClient(),
+				)
+			}
+
 			r := http.NewRouter(
 				c.GetLogger(),
 				c.GetConfig().GetMetricPrefix(),
-				http.SetupHealthDependencyList(
-					c.GetCassandraClient(),
-				),
+				healthDependencyList,
 			)
 
 			// Cards endpoints.
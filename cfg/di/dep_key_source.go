@@ -0,0 +1,54 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/cfg/config"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/keysource"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefKeySource = "KeySource"
+)
+
+//
+// registerKeySource dependency registrar.
+//
+func (c *Container) registerKeySource() error {
+
+	return c.RegisterDependency(
+		DefKeySource,
+		func(ctx di.Context) (interface{}, error) {
+
+			switch c.GetConfig().GetServicePrivateKeySource() {
+			case config.KeySourceFile:
+				return keysource.NewFileSource(
+					c.GetCrypto(),
+					c.GetConfig().GetServicePrivateKeyFile(),
+					c.GetConfig().GetServicePrivateKeyPasswordFile(),
+					c.GetConfig().IsServicePrivateKeyFileBase64()), nil
+			case config.KeySourceAgent:
+				return keysource.NewAgentSource(
+					c.GetConfig().GetSigningAgentSocket(),
+					c.GetConfig().GetSigningAgentTimeout()), nil
+			default:
+				return keysource.NewEnvSource(
+					c.GetCrypto(),
+					c.GetConfig().GetServicePrivateKey(),
+					string(c.GetConfig().GetServicePrivateKeyPassword())), nil
+			}
+		},
+		nil,
+	)
+}
+
+//
+// GetKeySource dependency retriever.
+//
+func (c *Container) GetKeySource() keysource.Source {
+
+	return c.Container.Get(DefKeySource).(keysource.Source)
+}
@@ -0,0 +1,44 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao/migration"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefSchemaMigrator = "SchemaMigrator"
+)
+
+//
+// registerSchemaMigrator dependency registrar.
+//
+func (c *Container) registerSchemaMigrator() error {
+
+	return c.RegisterDependency(
+		DefSchemaMigrator,
+		func(ctx di.Context) (interface{}, error) {
+
+			if c.GetConfig().IsMemoryStorage() {
+				return migration.NewMigrator(migration.NewMemoryStore(), migration.Migrations)
+			}
+
+			return migration.NewMigrator(
+				migration.NewCassandraStore(c.GetCassandraClient()),
+				migration.Migrations,
+			)
+		},
+		nil,
+	)
+}
+
+//
+// GetSchemaMigrator dependency retriever.
+//
+func (c *Container) GetSchemaMigrator() *migration.Migrator {
+
+	return c.Container.Get(DefSchemaMigrator).(*migration.Migrator)
+}
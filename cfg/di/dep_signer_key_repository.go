@@ -0,0 +1,41 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefSignerKeyRepository = "SignerKeyRepository"
+)
+
+//
+// registerSignerKeyRepository dependency registrar.
+//
+func (c *Container) registerSignerKeyRepository() error {
+
+	return c.RegisterDependency(
+		DefSignerKeyRepository,
+		func(ctx di.Context) (interface{}, error) {
+
+			if path := c.GetConfig().GetSignerKeysFile(); "" != path {
+				return dao.NewFileSignerKeyRepository(path)
+			}
+
+			return dao.NewMemorySignerKeyRepository(), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetSignerKeyRepository dependency retriever.
+//
+func (c *Container) GetSignerKeyRepository() dao.SignerKeyRepositoryProvider {
+
+	return c.Container.Get(DefSignerKeyRepository).(dao.SignerKeyRepositoryProvider)
+}
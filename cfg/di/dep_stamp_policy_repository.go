@@ -0,0 +1,41 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefStampPolicyRepository = "StampPolicyRepository"
+)
+
+//
+// registerStampPolicyRepository dependency registrar.
+//
+func (c *Container) registerStampPolicyRepository() error {
+
+	return c.RegisterDependency(
+		DefStampPolicyRepository,
+		func(ctx di.Context) (interface{}, error) {
+
+			if path := c.GetConfig().GetStampPoliciesFile(); "" != path {
+				return dao.NewFileStampPolicyRepository(path)
+			}
+
+			return dao.NewMemoryStampPolicyRepository(), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetStampPolicyRepository dependency retriever.
+//
+func (c *Container) GetStampPolicyRepository() dao.StampPolicyRepositoryProvider {
+
+	return c.Container.Get(DefStampPolicyRepository).(dao.StampPolicyRepositoryProvider)
+}
@@ -0,0 +1,40 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/translog"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefTransparencyLog = "TransparencyLog"
+)
+
+//
+// registerTransparencyLog dependency registrar.
+//
+func (c *Container) registerTransparencyLog() error {
+
+	return c.RegisterDependency(
+		DefTransparencyLog,
+		func(ctx di.Context) (interface{}, error) {
+
+			return translog.New(
+				c.GetTransparencyLogRepository(),
+				c.GetCardRepository(),
+			), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetTransparencyLog dependency retriever.
+//
+func (c *Container) GetTransparencyLog() translog.Provider {
+
+	return c.Container.Get(DefTransparencyLog).(translog.Provider)
+}
@@ -0,0 +1,43 @@
+package di
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/cfg/di"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+)
+
+//
+// Dependency name.
+//
+const (
+	DefTransparencyLogRepository = "TransparencyLogRepository"
+)
+
+//
+// registerTransparencyLogRepository dependency registrar.
+//
+func (c *Container) registerTransparencyLogRepository() error {
+
+	return c.RegisterDependency(
+		DefTransparencyLogRepository,
+		func(ctx di.Context) (interface{}, error) {
+
+			if c.GetConfig().IsMemoryStorage() {
+				return dao.NewMemoryTransparencyLogRepository(), nil
+			}
+
+			return dao.NewTransparencyLogRepository(
+				c.GetCassandraClient(),
+			), nil
+		},
+		nil,
+	)
+}
+
+//
+// GetTransparencyLogRepository dependency retriever.
+//
+func (c *Container) GetTransparencyLogRepository() dao.TransparencyLogRepositoryProvider {
+
+	return c.Container.Get(DefTransparencyLogRepository).(dao.TransparencyLogRepositoryProvider)
+}
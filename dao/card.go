@@ -2,6 +2,7 @@ package dao
 
 import (
 	"encoding/base64"
+	"sort"
 
 	"github.com/gocql/gocql"
 
@@ -28,20 +29,83 @@ type CardRepositoryProvider interface {
 	//
 	GetCardByID(span tracer.Span, ID string) (*model.CardDTO, error)
 
+	//
+	// GetCardsByIDs returns the cards found by their IDs.
+	// Not found cards are omitted.
+	//
+	GetCardsByIDs(span tracer.Span, IDs []string) ([]*model.CardDTO, error)
+
 	//
 	// DoesCardExistByPreviousIDAndScopeID returns true if the card exists by search criteria.
 	//
 	DoesCardExistByPreviousIDAndScopeID(span tracer.Span, previousCardID, applicationID string) (bool, error)
 
 	//
-	// SaveCard saves the card to the database.
+	// GetSupersedingCardID returns an ID of the card that replaced the previous card.
+	// Returns an empty string if the card has not been replaced or the replacement ID has not been stored.
+	//
+	GetSupersedingCardID(span tracer.Span, previousCardID, applicationID string) (string, error)
+
+	//
+	// SaveCard saves the card to the database along with the chain revocation record of the delete card.
+	// Returns ErrPreviousCardIsSuperseded if the previous card has been replaced by another card already.
 	//
 	SaveCard(tracer.Span, *model.CardDTO) error
 
+	//
+	// ReplacePreviousCard marks the previous card as replaced by the card before the card is saved.
+	// Returns ErrPreviousCardIsSuperseded if the previous card has been replaced by another card already.
+	//
+	ReplacePreviousCard(span tracer.Span, card *model.CardDTO) error
+
+	//
+	// RevertPreviousCardReplacement reverts the replacement of the previous card by the card which is not saved.
+	//
+	RevertPreviousCardReplacement(span tracer.Span, card *model.CardDTO) error
+
 	//
 	// SearchCardsByIdentities returns the cards matching search criteria.
+	// The cards of the deleted chains are returned if they are included only.
+	//
+	SearchCardsByIdentities(
+		span tracer.Span,
+		identities []string,
+		scopeID string,
+		includeDeleted bool,
+	) ([]*model.CardDTO, error)
+
+	//
+	// SearchCardsByIdentitiesPage returns a page of Virgil Cards of not deleted chains by their identities.
+	// The cards of the deleted chains are returned if they are included only.
+	//
+	SearchCardsByIdentitiesPage(
+		span tracer.Span,
+		identities []string,
+		scopeID string,
+		includeDeleted bool,
+		cursor *model.CardSearchCursor,
+		limit int,
+	) (*model.CardSearchPage, error)
+
+	//
+	// SearchLatestCardsByIdentities returns the latest Virgil Card of each not deleted chain by their identities.
+	// The latest cards of the deleted chains are returned if they are included only.
+	//
+	SearchLatestCardsByIdentities(
+		span tracer.Span,
+		identities []string,
+		scopeID string,
+		includeDeleted bool,
+	) ([]*model.CardDTO, error)
+
 	//
-	SearchCardsByIdentities(span tracer.Span, identities []string, scopeID string) ([]*model.CardDTO, error)
+	// SearchChainRevocationsByIdentities returns the revocation records of the deleted chains by their identities.
+	//
+	SearchChainRevocationsByIdentities(
+		span tracer.Span,
+		identities []string,
+		scopeID string,
+	) ([]*model.CardRevocation, error)
 
 	//
 	// SetCardChainID sets chainID property of card given.
@@ -58,8 +122,62 @@ type CardRepositoryProvider interface {
 	// Returns 'false' in case the chain has already been deleted.
 	//
 	SetChainDeleted(span tracer.Span, identity string, scopeID string, chainID string, unixSeconds int64) (bool, error)
+
+	//
+	// GetChainCards returns the cards of the chain ordered from the root card to the latest one.
+	//
+	GetChainCards(span tracer.Span, identity string, scopeID string, chainID string) ([]*model.CardDTO, error)
+
+	//
+	// SaveChainRevocation saves the signed revocation record of the deleted chain.
+	//
+	SaveChainRevocation(span tracer.Span, revocation *model.CardRevocation) error
+
+	//
+	// GetChainRevocation returns the revocation record of the chain.
+	// Returns nil if the chain has not been revoked or it was deleted before the records were kept.
+	//
+	GetChainRevocation(span tracer.Span, identity string, scopeID string, chainID string) (*model.CardRevocation, error)
+	//
+	// GetChainDeletedAt returns the chain 'Deleted' time. Zero means the chain is not deleted.
+	//
+	GetChainDeletedAt(span tracer.Span, identity string, scopeID string, chainID string) (int64, error)
+
+	//
+	// RestoreChain clears the 'Deleted' time of the chain deleted at the time given and unlinks the delete card.
+	// The delete card is unlinked after the time is cleared.
+	// Returns 'false' in case the chain 'Deleted' time has been changed.
+	//
+	RestoreChain(
+		span tracer.Span,
+		identity string,
+		scopeID string,
+		chainID string,
+		deleteCard *model.CardDTO,
+		deletedAt int64,
+	) (bool, error)
+
+	//
+	// GetIdentityChains returns the chains of the identity in all the applications.
+	//
+	GetIdentityChains(span tracer.Span, identity string) ([]*model.CardChainState, error)
+
+	//
+	// CountCardsByApplications returns the amount of cards of each application.
+	//
+	CountCardsByApplications(span tracer.Span) ([]*model.ApplicationCardCount, error)
+
+	//
+	// BackfillAdminTables fills the admin tables with the cards saved before the UTC Unix timestamp given.
+	//
+	BackfillAdminTables(span tracer.Span, savedBefore int64) ([]*model.ApplicationCardCount, error)
 }
 
+//
+// ErrPreviousCardIsSuperseded is returned when the card replaces the previous card replaced by another card already.
+//
+var ErrPreviousCardIsSuperseded = errors.New("previous card is superseded already")
+
 //
 // ErrorCassandraNotFound to wrap out standard error
 //
@@ -107,6 +225,9 @@ func (d *CardRepository) GetCardByID(span tracer.Span, ID string) (*model.CardDT
 		&card.Identity,
 		&card.ApplicationID,
 		&card.ChainID,
+		&card.Version,
+		&card.CreatedAt,
+		&card.ExpiresAt,
 		&signatures,
 	); err != nil {
 		if err == gocql.ErrNotFound {
@@ -133,6 +254,58 @@ func (d *CardRepository) GetCardByID(span tracer.Span, ID string) (*model.CardDT
 	return card, nil
 }
 
+//
+// GetCardsByIDs returns the cards found by their IDs.
+// Not found cards are omitted.
+//
+func (d *CardRepository) GetCardsByIDs(span tracer.Span, IDs []string) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var (
+		card       *model.CardDTO
+		signatures SignatureList
+
+		cards = make([]*model.CardDTO, 0, len(IDs))
+	)
+
+	if 0 == len(IDs) {
+		return cards, nil
+	}
+
+	cardsIterator := d.session.Query(qGetCardsByIDs, IDs).Iter()
+	for {
+		card = model.NewCardDTO()
+		if !cardsIterator.Scan(
+			&card.ID,
+			&card.ContentSnapshot,
+			&card.ApplicationID,
+			&card.ExpiresAt,
+			&signatures,
+		) {
+			break
+		}
+
+		card.Signatures = wrapDBSignatureListToDTOs(signatures)
+		cards = append(cards, card)
+	}
+	if err := cardsIterator.Close(); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error selecting cards in GetCardsByIDs for cardIDs (%v)", IDs,
+		))
+	}
+
+	return cards, nil
+}
+
 //
 // DoesCardExistByPreviousIDAndScopeID returns true if card exists by search criteria.
 //
@@ -166,6 +339,33 @@ func (d *CardRepository) DoesCardExistByPreviousIDAndScopeID(
 	return false, nil
 }
 
+//
+// GetSupersedingCardID returns an ID of the card that replaced the previous card.
+// Returns an empty string if the card has not been replaced or the replacement ID has not been stored.
+//
+func (d *CardRepository) GetSupersedingCardID(span tracer.Span, prevID, applicationID string) (string, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var id string
+	if err := d.session.Query(qGetSupersedingCardID, prevID, applicationID).Scan(&id); nil != err {
+		if err == gocql.ErrNotFound {
+			return "", nil
+		}
+
+		return "", tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	return id, nil
+}
+
 //
 // SaveCard persists the Virgil Card DTO to the database.
 //
@@ -181,7 +381,14 @@ func (d *CardRepository) SaveCard(span tracer.Span, card *model.CardDTO) error {
 	)
 	defer span.Finish()
 
-	batchSave := d.session.NewBatch(gocql.UnloggedBatch)
+	// The previous card is replaced first, so the concurrent replacements of the card can not fork the chain.
+	if "" != card.GetPreviousCardID() {
+		if err := d.ReplacePreviousCard(span, card); nil != err {
+			return err
+		}
+	}
+
+	batchSave := d.session.NewBatch(gocql.LoggedBatch)
 	batchSave.SetConsistency(gocql.LocalQuorum) //just in case
 
 	batchSave.Query(qCreateCardInCardPKTable,
@@ -195,6 +402,7 @@ func (d *CardRepository) SaveCard(span tracer.Span, card *model.CardDTO) error {
 		wrapSignatureDTOsToDBSignatureList(card.Signatures),
 		card.GetCreatedAt().Unix(),
 		card.GetChainID(),
+		card.GetExpiresAt(),
 	)
 
 	batchSave.Query(qCreateCardInIdentityPKTable,
@@ -208,45 +416,171 @@ func (d *CardRepository) SaveCard(span tracer.Span, card *model.CardDTO) error {
 		wrapSignatureDTOsToDBSignatureList(card.Signatures),
 		card.GetCreatedAt().Unix(),
 		card.GetChainID(),
+		card.GetExpiresAt(),
 	)
 
 	// Insert/Update chain's IDs:
 	if "" == card.PreviousCardID {
-		batchSave.Query(getCreateChainIdentitiesQuery(card.GetID()),
+		batchSave.Query(qCreateChainIdentities,
+			[]string{card.GetID()},
 			card.GetCreatedAt().Unix(),
 			card.GetIdentity(),
 			card.GetApplicationID(),
 			card.GetChainID(),
 		)
+		batchSave.Query(qInsertCardIdentityApplication, card.GetIdentity(), card.GetApplicationID())
 	} else {
-		batchSave.Query(getUpdateChainIdentitiesQuery(card.GetID()),
+		batchSave.Query(qUpdateChainIdentities,
+			[]string{card.GetID()},
 			card.GetIdentity(),
 			card.GetApplicationID(),
 			card.GetChainID(),
 		)
 	}
 
-	if "" != card.GetPreviousCardID() {
-		batchSave.Query(qUpdatePreviousCardIDs, card.GetPreviousCardID(), card.GetApplicationID())
+	// The delete card is saved along with the revocation record of the chain it deletes.
+	if nil != card.Revocation {
+		batchSave.Query(qInsertChainRevocation, chainRevocationValues(card.Revocation)...)
 	}
 
 	if err := d.session.ExecuteBatch(batchSave); err != nil {
+		// the timed out logged batch may still be applied from the batch log, so the replacement is kept.
+		// the revert error is set to the span only, the card save error is the one returned.
+		if "" != card.GetPreviousCardID() && !isWriteOutcomeUnknown(err) {
+			_ = d.RevertPreviousCardReplacement(span, card)
+		}
+
 		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
 			err,
 			"unable to save a new card (%s)", card.ID,
 		))
 	}
 
+	// the delete card has no key, so the chain it deletes is counted only.
+	if !card.IsDeleteCard() {
+		d.incrementApplicationCardCount(span, card)
+	}
+
+	return nil
+}
+
+//
+// incrementApplicationCardCount counts the saved card of its application.
+// The cards saved before the schema migration 5 are counted by the admin tables backfill.
+// The counter can not be updated in the card batch, so the count is approximate.
+// The error is set to the span only, the card is saved already.
+//
+func (d *CardRepository) incrementApplicationCardCount(span tracer.Span, card *model.CardDTO) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := d.session.Query(qIncrementApplicationCardCount, card.GetApplicationID()).Exec(); nil != err {
+		_ = tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"unable to count the card (%s) of application (%s)", card.GetID(), card.GetApplicationID(),
+		))
+	}
+}
+
+//
+// ReplacePreviousCard marks the previous card as replaced by the card with a lightweight transaction.
+// The same card replacing the previous card again is not a fork, so the card can be saved after the replacement.
+// Returns ErrPreviousCardIsSuperseded if the previous card has been replaced by another card already.
+//
+func (d *CardRepository) ReplacePreviousCard(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var previousCardID, applicationID, supersedingCardID string
+	applied, err := d.session.Query(
+		qInsertPreviousCardIDIfNotExists,
+		card.GetPreviousCardID(),
+		card.GetApplicationID(),
+		card.GetID(),
+	).ScanCAS(&previousCardID, &applicationID, &supersedingCardID)
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"unable to replace the previous card (%s) by the card (%s)", card.GetPreviousCardID(), card.GetID(),
+		))
+	}
+
+	// the same card saved again is not a fork.
+	if !applied && supersedingCardID != card.GetID() {
+		return tracer.SetSpanErrorAndReturn(span, ErrPreviousCardIsSuperseded)
+	}
+
+	return nil
+}
+
+//
+// RevertPreviousCardReplacement reverts the replacement of the previous card by the card which is not saved.
+// The replacement of the previous card by another card is kept.
+//
+func (d *CardRepository) RevertPreviousCardReplacement(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var supersedingCardID string
+	if _, err := d.session.Query(
+		qDeletePreviousCardIDIfCardID,
+		card.GetPreviousCardID(),
+		card.GetApplicationID(),
+		card.GetID(),
+	).ScanCAS(&supersedingCardID); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"unable to revert the previous card (%s) replacement by the card (%s)",
+			card.GetPreviousCardID(), card.GetID(),
+		))
+	}
+
 	return nil
 }
 
 //
-// SearchCardsByIdentities returns Virgil Cards by their identities. index - identity, application, card_id.
+// isWriteOutcomeUnknown returns true if the write error does not tell whether the write has been applied.
+//
+func isWriteOutcomeUnknown(err error) bool {
+
+	if _, ok := err.(*gocql.RequestErrWriteTimeout); ok {
+		return true
+	}
+
+	return err == gocql.ErrTimeoutNoResponse
+}
+
+//
+// SearchCardsByIdentities returns Virgil Cards of not deleted chains by their identities.
+// The cards are read from the identity partitioned table and joined with the chains deleted state,
+// so no query is made by the card IDs. The cards of the deleted chains are returned if they are included only.
 //
 func (d *CardRepository) SearchCardsByIdentities(
 	span tracer.Span,
 	identities []string,
 	scopeID string,
+	includeDeleted bool,
 ) ([]*model.CardDTO, error) {
 
 	// Create a root span, because action is complicated and contains several database queries below.
@@ -261,14 +595,16 @@ func (d *CardRepository) SearchCardsByIdentities(
 
 	var (
 		// chains search
-		deletedAt    int
-		chainCardIDs []string
+		identity  string
+		chainID   string
+		deletedAt int
 		// cards content
 		contentSnapshot string
+		expiresAt       int64
 		signatures      SignatureList
 
-		cardIDs = make([]string, 0)
-		cards   = make([]*model.CardDTO, 0)
+		searchChains = make(map[identityChainKey]struct{})
+		cards        = make([]*model.CardDTO, 0)
 	)
 
 	// Mark query with separate Span.
@@ -282,13 +618,13 @@ func (d *CardRepository) SearchCardsByIdentities(
 		)
 		defer span.Finish()
 
-		iterCardIDs := d.session.Query(getSearchCardIDsByMultipleIdentitiesQuery(identities), scopeID).Iter()
-		for iterCardIDs.Scan(&deletedAt, &chainCardIDs) {
-			if 0 >= deletedAt {
-				cardIDs = append(cardIDs, chainCardIDs...)
+		iterChains := d.searchByIdentitiesQuery(qSearchChainStatesByIdentities, scopeID, identities).Iter()
+		for iterChains.Scan(&identity, &chainID, &deletedAt) {
+			if 0 >= deletedAt || includeDeleted {
+				searchChains[identityChainKey{identity: identity, chainID: chainID}] = struct{}{}
 			}
 		}
-		if err := iterCardIDs.Close(); nil != err {
+		if err := iterChains.Close(); nil != err {
 			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
 				err,
 				"error selecting from chain table in SearchCardsByIdentities for identities (%v) and appID (%s)",
@@ -298,8 +634,8 @@ func (d *CardRepository) SearchCardsByIdentities(
 		}
 	}
 
-	if 0 == len(cardIDs) {
-		return []*model.CardDTO{}, nil
+	if 0 == len(searchChains) {
+		return cards, nil
 	}
 
 	// Mark query with separate Span.
@@ -313,17 +649,27 @@ func (d *CardRepository) SearchCardsByIdentities(
 		)
 		defer span.Finish()
 
-		cardsIterator := d.session.Query(getSearchByCardsIDs(cardIDs)).Iter()
-		for cardsIterator.Scan(&contentSnapshot, &signatures) {
+		cardsIterator := d.searchByIdentitiesQuery(qSearchCardsByIdentities, scopeID, identities).Iter()
+		for cardsIterator.Scan(&identity, &chainID, &contentSnapshot, &expiresAt, &signatures) {
+			if _, ok := searchChains[identityChainKey{identity: identity, chainID: chainID}]; !ok {
+				continue
+			}
+
 			cards = append(cards, &model.CardDTO{
+				Identity:        identity,
+				ApplicationID:   scopeID,
+				ChainID:         chainID,
 				ContentSnapshot: contentSnapshot,
+				ExpiresAt:       expiresAt,
 				Signatures:      wrapDBSignatureListToDTOs(signatures),
 			})
 		}
 		if err := cardsIterator.Close(); nil != err {
 			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
 				err,
-				"error selecting cards in SearchCardsByIdentities for cardIDs (%v)", cardIDs,
+				"error selecting from identity table in SearchCardsByIdentities for identities (%v) and appID (%s)",
+				identities,
+				scopeID,
 			))
 		}
 	}
@@ -332,10 +678,37 @@ func (d *CardRepository) SearchCardsByIdentities(
 }
 
 //
-// SetCardChainID sets chainID property of card given.
+// searchByIdentitiesQuery returns the search statement query with the application ID and the identities list bound.
+// The identities are never formatted into the statement, so any identity is passed to the database as is.
 //
-func (d *CardRepository) SetCardChainID(span tracer.Span, card *model.CardDTO) error {
+func (d *CardRepository) searchByIdentitiesQuery(statement, scopeID string, identities []string) *gocql.Query {
+
+	return d.session.Query(statement, scopeID, identities)
+}
 
+//
+// identityChainKey identifies the chain of the identity.
+//
+type identityChainKey struct {
+	identity string
+	chainID  string
+}
+
+//
+// SearchCardsByIdentitiesPage returns a page of Virgil Cards of not deleted chains by their identities.
+// Chains are requested per identity with bounded parallelism, the cards are selected for the page only.
+// The cards of the deleted chains are returned if they are included only.
+//
+func (d *CardRepository) SearchCardsByIdentitiesPage(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+	includeDeleted bool,
+	cursor *model.CardSearchCursor,
+	limit int,
+) (*model.CardSearchPage, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
 	span = span.Tracer().StartSpan(
 		tracer.GetCallerInfo(),
 		tracer.ChildOf(span.Context()),
@@ -345,49 +718,266 @@ func (d *CardRepository) SetCardChainID(span tracer.Span, card *model.CardDTO) e
 	)
 	defer span.Finish()
 
-	if "" == card.GetPreviousCardID() {
-		// new card create
-		chainID, err := uuid.NewV4()
-		if nil != err {
-			return tracer.SetSpanErrorAndReturn(
-				span,
-				errors.WithMessage(err, "error creating card's (%s) new chainID", card.GetID()),
-			)
+	cardIDs, next, err := searchPageCardIDs(span, identities, cursor, limit, func(
+		span tracer.Span,
+		identity string,
+	) ([]string, error) {
+
+		var (
+			deletedAt    int
+			chainCardIDs []string
 
+			ids = make([]string, 0)
+		)
+
+		iterCardIDs := d.session.Query(qGetIdentityChainCardIDs, identity, scopeID).Iter()
+		for iterCardIDs.Scan(&deletedAt, &chainCardIDs) {
+			if 0 >= deletedAt || includeDeleted {
+				ids = append(ids, chainCardIDs...)
+			}
 		}
-		card.SetChainID(chainID.String())
-		return nil
+		if err := iterCardIDs.Close(); nil != err {
+			return nil, errors.WithMessage(
+				err,
+				"error selecting from chain table in SearchCardsByIdentitiesPage for identity (%s) and appID (%s)",
+				identity,
+				scopeID,
+			)
+		}
+
+		return ids, nil
+	})
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
 	}
 
-	chainID := ""
-	if err := d.session.Query(
-		qGetChainIDByCardID,
-		card.GetPreviousCardID(),
-	).Scan(&chainID); nil != err {
-		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
-			err,
-			"error retrieving previous card's (%s) chainID", card.GetPreviousCardID(),
-		))
+	page := &model.CardSearchPage{
+		Cards: make([]*model.CardDTO, 0, len(cardIDs)),
+		Next:  next,
 	}
 
-	if "" == chainID {
-		return tracer.SetSpanErrorAndReturn(
-			span,
-			errors.New("previous card's (%s) chainID is empty", card.GetPreviousCardID()),
-		)
+	if 0 == len(cardIDs) {
+		return page, nil
 	}
 
-	card.SetChainID(chainID)
+	var (
+		identity        string
+		applicationID   string
+		chainID         string
+		contentSnapshot string
+		expiresAt       int64
+		signatures      SignatureList
+	)
 
-	return nil
+	cardsIterator := d.session.Query(qSearchCardsByIDs, cardIDs).Iter()
+	for cardsIterator.Scan(&identity, &applicationID, &chainID, &contentSnapshot, &expiresAt, &signatures) {
+		page.Cards = append(page.Cards, &model.CardDTO{
+			Identity:        identity,
+			ApplicationID:   applicationID,
+			ChainID:         chainID,
+			ContentSnapshot: contentSnapshot,
+			ExpiresAt:       expiresAt,
+			Signatures:      wrapDBSignatureListToDTOs(signatures),
+		})
+	}
+	if err := cardsIterator.Close(); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error selecting cards in SearchCardsByIdentitiesPage for cardIDs (%v)", cardIDs,
+		))
+	}
+
+	return page, nil
 }
 
 //
-// IsChainDeleted checks if chain is deleted.
+// SearchLatestCardsByIdentities returns the latest Virgil Card of each not deleted chain by their identities.
+// The cards are returned with their chain info. The latest cards of the deleted chains are returned
+// if they are included only.
 //
-func (d *CardRepository) IsChainDeleted(span tracer.Span, identity, scopeID, chainID string) (bool, error) {
-
-	span = span.Tracer().StartSpan(
+func (d *CardRepository) SearchLatestCardsByIdentities(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+	includeDeleted bool,
+) ([]*model.CardDTO, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var (
+		// chains search
+		identity     string
+		chainID      string
+		deletedAt    int
+		chainCardIDs []string
+
+		chainIDs    = make([]string, 0)
+		cardChains  = make(map[string]*model.CardDTO)
+		chainCards  = make(map[string][]*model.CardDTO)
+		cardIDs     = make([]string, 0)
+		latestCards = make([]*model.CardDTO, 0)
+	)
+
+	// Mark query with separate Span.
+	{
+		span := span.Tracer().StartSpan(
+			tracer.GetCallerInfo(),
+			tracer.ChildOf(span.Context()),
+			tracer.Tags{
+				tracer.TagComponent: tracer.ComponentDAO,
+			},
+		)
+		defer span.Finish()
+
+		iterChains := d.searchByIdentitiesQuery(qSearchChainsByIdentities, scopeID, identities).Iter()
+		for iterChains.Scan(&identity, &chainID, &deletedAt, &chainCardIDs) {
+			if 0 < deletedAt && !includeDeleted {
+				continue
+			}
+
+			chainIDs = append(chainIDs, chainID)
+			for _, id := range chainCardIDs {
+				cardChains[id] = &model.CardDTO{Identity: identity, ApplicationID: scopeID, ChainID: chainID}
+			}
+			cardIDs = append(cardIDs, chainCardIDs...)
+		}
+		if err := iterChains.Close(); nil != err {
+			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"error selecting from chain table in SearchLatestCardsByIdentities for identities (%v) and appID (%s)",
+				identities,
+				scopeID,
+			))
+		}
+	}
+
+	if 0 == len(cardIDs) {
+		return latestCards, nil
+	}
+
+	// Mark query with separate Span.
+	{
+		span := span.Tracer().StartSpan(
+			tracer.GetCallerInfo(),
+			tracer.ChildOf(span.Context()),
+			tracer.Tags{
+				tracer.TagComponent: tracer.ComponentDAO,
+			},
+		)
+		defer span.Finish()
+
+		var (
+			card       *model.CardDTO
+			signatures SignatureList
+		)
+
+		cardsIterator := d.session.Query(qGetChainCardsByIDs, cardIDs).Iter()
+		for {
+			card = model.NewCardDTO()
+			if !cardsIterator.Scan(
+				&card.ID,
+				&card.ContentSnapshot,
+				&card.PreviousCardID,
+				&card.CreatedAt,
+				&card.ExpiresAt,
+				&signatures,
+			) {
+				break
+			}
+
+			chain, ok := cardChains[card.GetID()]
+			if !ok {
+				continue
+			}
+			card.Identity = chain.Identity
+			card.ApplicationID = chain.ApplicationID
+			card.ChainID = chain.ChainID
+			card.Signatures = wrapDBSignatureListToDTOs(signatures)
+
+			chainCards[card.ChainID] = append(chainCards[card.ChainID], card)
+		}
+		if err := cardsIterator.Close(); nil != err {
+			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"error selecting cards in SearchLatestCardsByIdentities for cardIDs (%v)", cardIDs,
+			))
+		}
+	}
+
+	for _, chainID := range chainIDs {
+		if head := resolveChainHead(chainCards[chainID]); nil != head {
+			latestCards = append(latestCards, head)
+		}
+	}
+
+	return latestCards, nil
+}
+
+//
+// SetCardChainID sets chainID property of card given.
+//
+func (d *CardRepository) SetCardChainID(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if "" == card.GetPreviousCardID() {
+		// new card create
+		chainID, err := uuid.NewV4()
+		if nil != err {
+			return tracer.SetSpanErrorAndReturn(
+				span,
+				errors.WithMessage(err, "error creating card's (%s) new chainID", card.GetID()),
+			)
+
+		}
+		card.SetChainID(chainID.String())
+		return nil
+	}
+
+	chainID := ""
+	if err := d.session.Query(
+		qGetChainIDByCardID,
+		card.GetPreviousCardID(),
+	).Scan(&chainID); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error retrieving previous card's (%s) chainID", card.GetPreviousCardID(),
+		))
+	}
+
+	if "" == chainID {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			errors.New("previous card's (%s) chainID is empty", card.GetPreviousCardID()),
+		)
+	}
+
+	card.SetChainID(chainID)
+
+	return nil
+}
+
+//
+// IsChainDeleted checks if chain is deleted.
+//
+func (d *CardRepository) IsChainDeleted(span tracer.Span, identity, scopeID, chainID string) (bool, error) {
+
+	span = span.Tracer().StartSpan(
 		tracer.GetCallerInfo(),
 		tracer.ChildOf(span.Context()),
 		tracer.Tags{
@@ -448,6 +1038,525 @@ func (d *CardRepository) SetChainDeleted(
 	return applied, nil
 }
 
+//
+// GetChainCards returns the cards of the chain ordered from the root card to the latest one.
+// The delete tombstone card goes the last one in case the chain is deleted.
+//
+func (d *CardRepository) GetChainCards(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+) ([]*model.CardDTO, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var (
+		cardIDs []string
+		cards   = make([]*model.CardDTO, 0)
+	)
+
+	if err := d.session.Query(qGetChainCardIDs, identity, scopeID, chainID).Scan(&cardIDs); nil != err {
+		if err == gocql.ErrNotFound {
+			return cards, nil
+		}
+
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error selecting chain's (%s) card IDs", chainID,
+		))
+	}
+
+	if 0 == len(cardIDs) {
+		return cards, nil
+	}
+
+	// Mark query with separate Span.
+	{
+		span := span.Tracer().StartSpan(
+			tracer.GetCallerInfo(),
+			tracer.ChildOf(span.Context()),
+			tracer.Tags{
+				tracer.TagComponent: tracer.ComponentDAO,
+			},
+		)
+		defer span.Finish()
+
+		var (
+			card       *model.CardDTO
+			signatures SignatureList
+		)
+
+		cardsIterator := d.session.Query(qGetChainCardsByIDs, cardIDs).Iter()
+		for {
+			card = &model.CardDTO{
+				Identity:      identity,
+				ApplicationID: scopeID,
+				ChainID:       chainID,
+			}
+			if !cardsIterator.Scan(
+				&card.ID,
+				&card.ContentSnapshot,
+				&card.PreviousCardID,
+				&card.CreatedAt,
+				&card.ExpiresAt,
+				&signatures,
+			) {
+				break
+			}
+
+			card.Signatures = wrapDBSignatureListToDTOs(signatures)
+			cards = append(cards, card)
+		}
+		if err := cardsIterator.Close(); nil != err {
+			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"error selecting cards of chain (%s) for cardIDs (%v)", chainID, cardIDs,
+			))
+		}
+	}
+
+	return orderChainCards(cards), nil
+}
+
+//
+// SaveChainRevocation saves the signed revocation record of the deleted chain.
+//
+func (d *CardRepository) SaveChainRevocation(span tracer.Span, revocation *model.CardRevocation) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := d.session.Query(qInsertChainRevocation, chainRevocationValues(revocation)...).Exec(); nil != err {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			errors.WithMessage(err, "error inserting chain's(%s) revocation", revocation.ChainID),
+		)
+	}
+
+	return nil
+}
+
+//
+// chainRevocationValues returns the values of the chain revocation insert statement.
+//
+func chainRevocationValues(revocation *model.CardRevocation) []interface{} {
+
+	return []interface{}{
+		revocation.Identity,
+		revocation.ApplicationID,
+		revocation.ChainID,
+		revocation.CardID,
+		revocation.DeleteCardID,
+		revocation.Reason,
+		revocation.Note,
+		revocation.RevokedAt,
+		revocation.ContentSnapshot,
+		revocation.Signature,
+		revocation.KeyID,
+	}
+}
+
+//
+// GetChainRevocation returns the revocation record of the chain.
+// Returns nil if the chain has not been revoked or it was deleted before the records were kept.
+//
+func (d *CardRepository) GetChainRevocation(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+) (*model.CardRevocation, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	revocation := &model.CardRevocation{
+		CardRevocationContent: model.CardRevocationContent{
+			ChainID:  chainID,
+			Identity: identity,
+		},
+		ApplicationID: scopeID,
+	}
+
+	if err := d.session.Query(qGetChainRevocation, identity, scopeID, chainID).Scan(
+		&revocation.CardID,
+		&revocation.DeleteCardID,
+		&revocation.Reason,
+		&revocation.Note,
+		&revocation.RevokedAt,
+		&revocation.ContentSnapshot,
+		&revocation.Signature,
+		&revocation.KeyID,
+	); nil != err {
+		if err == gocql.ErrNotFound {
+			return nil, nil
+		}
+
+		return nil, tracer.SetSpanErrorAndReturn(
+			span,
+			errors.WithMessage(err, "error selecting chain's(%s) revocation", chainID),
+		)
+	}
+
+	return revocation, nil
+}
+
+//
+// SearchChainRevocationsByIdentities returns the revocation records of the deleted chains by their identities.
+// Revocations are requested per identity with bounded parallelism, the same way the search page chains are.
+//
+func (d *CardRepository) SearchChainRevocationsByIdentities(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+) ([]*model.CardRevocation, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	revocations, err := fetchIdentitiesChainRevocations(span, identities, func(
+		span tracer.Span,
+		identity string,
+	) ([]*model.CardRevocation, error) {
+
+		var (
+			revocation  *model.CardRevocation
+			revocations = make([]*model.CardRevocation, 0)
+		)
+
+		iterRevocations := d.session.Query(qGetIdentityChainRevocations, identity, scopeID).Iter()
+		for {
+			revocation = &model.CardRevocation{ApplicationID: scopeID}
+			if !iterRevocations.Scan(
+				&revocation.Identity,
+				&revocation.ChainID,
+				&revocation.CardID,
+				&revocation.DeleteCardID,
+				&revocation.Reason,
+				&revocation.Note,
+				&revocation.RevokedAt,
+				&revocation.ContentSnapshot,
+				&revocation.Signature,
+				&revocation.KeyID,
+			) {
+				break
+			}
+
+			revocations = append(revocations, revocation)
+		}
+		if err := iterRevocations.Close(); nil != err {
+			return nil, errors.WithMessage(
+				err,
+				"error selecting chain revocations for identity (%s) and appID (%s)",
+				identity,
+				scopeID,
+			)
+		}
+
+		return revocations, nil
+	})
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	return revocations, nil
+}
+
+//
+// GetChainDeletedAt returns the chain 'Deleted' time. Zero means the chain is not deleted.
+//
+func (d *CardRepository) GetChainDeletedAt(span tracer.Span, identity, scopeID, chainID string) (int64, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var deletedAt int64
+	if err := d.session.Query(qGetChainDeletedAt, identity, scopeID, chainID).Scan(&deletedAt); nil != err {
+		if err == gocql.ErrNotFound {
+			return 0, nil
+		}
+
+		return 0, tracer.SetSpanErrorAndReturn(
+			span,
+			errors.WithMessage(err, "error retrieving chain's(%s) deleted_at", chainID),
+		)
+	}
+
+	return deletedAt, nil
+}
+
+//
+// RestoreChain clears the 'Deleted' time of the chain deleted at the time given and unlinks the delete card.
+// The delete card stays in the card ID primary table, but it is removed from the chain, from the identity table
+// and from the previous card replacements along with the chain revocation record.
+// The delete card is unlinked once the 'Deleted' time is cleared, so it is unlinked by the restore
+// that has cleared the time only. An unlink error is returned along with 'true'.
+// Returns 'false' in case the chain 'Deleted' time has been changed.
+//
+func (d *CardRepository) RestoreChain(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+	deleteCard *model.CardDTO,
+	deletedAt int64,
+) (bool, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var query *gocql.Query
+	if nil == deleteCard {
+		query = d.session.Query(qClearChainDeletedAt, identity, scopeID, chainID, deletedAt)
+	} else {
+		query = d.session.Query(
+			qClearChainDeletedAtAndRemoveCardIDs,
+			[]string{deleteCard.GetID()},
+			identity,
+			scopeID,
+			chainID,
+			deletedAt,
+		)
+	}
+
+	var oldDeletedAt int64
+	applied, err := query.ScanCAS(&oldDeletedAt)
+	if nil != err {
+		return false, tracer.SetSpanErrorAndReturn(
+			span,
+			errors.WithMessage(err, "error clearing chain's(%s) deleted_at", chainID),
+		)
+	}
+
+	if !applied || nil == deleteCard {
+		return applied, nil
+	}
+
+	// The previous card can not be replaced or deleted again until its replacement by the delete card is removed,
+	// so the records removed are the ones of the delete card.
+	batchUnlink := d.session.NewBatch(gocql.LoggedBatch)
+	batchUnlink.SetConsistency(gocql.LocalQuorum)
+
+	batchUnlink.Query(qDeletePreviousCardID, deleteCard.GetPreviousCardID(), scopeID)
+	batchUnlink.Query(qDeleteCardInIdentityPKTable, identity, scopeID, deleteCard.GetID())
+	batchUnlink.Query(qDeleteChainRevocation, identity, scopeID, chainID)
+
+	if err := d.session.ExecuteBatch(batchUnlink); nil != err {
+		return true, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"chain (%s) is restored, unable to unlink its delete card (%s)", chainID, deleteCard.GetID(),
+		))
+	}
+
+	return true, nil
+}
+
+//
+// GetIdentityChains returns the chains of the identity in all the applications.
+// The applications are looked up in the identity applications table, the chains are read per application.
+// The table is filled on the root card save since the schema migration 5 and by the backfill subcommand before it.
+//
+func (d *CardRepository) GetIdentityChains(span tracer.Span, identity string) ([]*model.CardChainState, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var (
+		applicationID  string
+		applicationIDs = make([]string, 0)
+		chains         = make([]*model.CardChainState, 0)
+	)
+
+	iterApplications := d.session.Query(qGetCardIdentityApplications, identity).Iter()
+	for iterApplications.Scan(&applicationID) {
+		applicationIDs = append(applicationIDs, applicationID)
+	}
+	if err := iterApplications.Close(); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(
+			span,
+			errors.WithMessage(err, "error selecting identity's(%s) applications", identity),
+		)
+	}
+
+	for _, applicationID := range applicationIDs {
+		chain := &model.CardChainState{Identity: identity, ApplicationID: applicationID}
+		iterChains := d.session.Query(qGetIdentityChains, identity, applicationID).Iter()
+		for iterChains.Scan(&chain.ChainID, &chain.CardIDs, &chain.CreatedAt, &chain.DeletedAt) {
+			chains = append(chains, chain)
+			chain = &model.CardChainState{Identity: identity, ApplicationID: applicationID}
+		}
+		if err := iterChains.Close(); nil != err {
+			return nil, tracer.SetSpanErrorAndReturn(
+				span,
+				errors.WithMessage(err, "error selecting identity's(%s) chains of appID (%s)", identity, applicationID),
+			)
+		}
+	}
+
+	sortChainStates(chains)
+
+	return chains, nil
+}
+
+//
+// CountCardsByApplications returns the amount of cards of each application ordered by the application ID.
+// The amounts are the application counters updated on the card save since the schema migration 5
+// plus the backfilled amounts of the cards saved before it.
+//
+func (d *CardRepository) CountCardsByApplications(span tracer.Span) ([]*model.ApplicationCardCount, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var (
+		applicationID string
+		cards         int64
+		counts        = make(map[string]int64)
+	)
+
+	for _, statement := range []string{qGetApplicationCardCounts, qGetApplicationBackfillCardCounts} {
+		iter := d.session.Query(statement).Iter()
+		for iter.Scan(&applicationID, &cards) {
+			counts[applicationID] += cards
+		}
+		if err := iter.Close(); nil != err {
+			return nil, tracer.SetSpanErrorAndReturn(
+				span,
+				errors.WithMessage(err, "error counting cards by applications"),
+			)
+		}
+	}
+
+	return wrapApplicationCardCounts(counts), nil
+}
+
+//
+// BackfillAdminTables fills the admin tables with the cards saved before the UTC Unix timestamp given,
+// it is the time the schema migration 5 has been applied at, so the cards counted by the counters are skipped.
+// The card is saved at the server write time of its row, the creation time is given by the client and is not trusted.
+// The identity applications are inserted for all the cards and the backfilled counts are overwritten,
+// so the backfill can be run again. Returns the backfilled counts of the applications.
+//
+func (d *CardRepository) BackfillAdminTables(
+	span tracer.Span,
+	savedBefore int64,
+) ([]*model.ApplicationCardCount, error) {
+
+	// Create a root span, because action is complicated and contains several database queries below.
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var (
+		pubKeyString string
+		savedAt      int64
+		card         = new(model.CardDTO)
+		counts       = make(map[string]int64)
+	)
+
+	iter := d.session.Query(qGetBackfillCards).Iter()
+	for iter.Scan(&card.Identity, &card.ApplicationID, &card.PreviousCardID, &pubKeyString, &savedAt) {
+		if err := d.session.Query(qInsertCardIdentityApplication, card.Identity, card.ApplicationID).Exec(); nil != err {
+			_ = iter.Close()
+
+			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"unable to backfill the identity (%s) application (%s)", card.Identity, card.ApplicationID,
+			))
+		}
+
+		// the delete card has no key, so the chain it deletes is counted only. The key is not decoded to be checked.
+		card.PublicKey = []byte(pubKeyString)
+		if savedAt < savedBefore*1e6 && !card.IsDeleteCard() {
+			counts[card.ApplicationID]++
+		}
+	}
+	if err := iter.Close(); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(err, "error selecting the backfill cards"))
+	}
+
+	for applicationID, cards := range counts {
+		if err := d.session.Query(qInsertApplicationBackfillCardCount, applicationID, cards).Exec(); nil != err {
+			return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"unable to backfill the card count of application (%s)", applicationID,
+			))
+		}
+	}
+
+	return wrapApplicationCardCounts(counts), nil
+}
+
+//
+// wrapApplicationCardCounts wraps the card counts by application IDs ordered by the application ID.
+//
+func wrapApplicationCardCounts(counts map[string]int64) []*model.ApplicationCardCount {
+
+	result := make([]*model.ApplicationCardCount, 0, len(counts))
+	for applicationID, cards := range counts {
+		result = append(result, &model.ApplicationCardCount{ApplicationID: applicationID, Cards: cards})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ApplicationID < result[j].ApplicationID
+	})
+
+	return result
+}
+
 //
 // wrapDBSignaturesToDTOs wraps database signatures data into the DTOs.
 //
@@ -459,6 +1568,7 @@ func wrapDBSignatureListToDTOs(signatures SignatureList) []*model.CardSignatureD
 			Signer:    signature["signer"],
 			Signature: signature["signature"],
 			Snapshot:  signature["snapshot"],
+			KeyID:     signature["key_id"],
 		})
 	}
 
@@ -476,6 +1586,7 @@ func wrapSignatureDTOsToDBSignatureList(signatureDTOs []*model.CardSignatureDTO)
 			"signer":    signature.GetSigner(),
 			"signature": signature.GetSignature(),
 			"snapshot":  signature.GetExtraContent(),
+			"key_id":    signature.GetKeyID(),
 		})
 	}
 
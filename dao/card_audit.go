@@ -0,0 +1,198 @@
+package dao
+
+import (
+	"github.com/gocql/gocql"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+	"github.com/VirgilSecurity/virgil-services-core-kit/uuid"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// CardAuditSinkProvider is an interface of the append-only card audit log.
+//
+type CardAuditSinkProvider interface {
+	//
+	// AppendCardAuditRecord appends the card audit record. The record ID is set on append.
+	//
+	AppendCardAuditRecord(span tracer.Span, record *model.CardAuditRecord) error
+
+	//
+	// GetCardAuditRecordsByIdentity returns the card audit records of the identity in all the applications.
+	//
+	GetCardAuditRecordsByIdentity(span tracer.Span, identity string) ([]*model.CardAuditRecord, error)
+
+	//
+	// GetCardAuditRecordsByChain returns the card audit records of the chain.
+	//
+	GetCardAuditRecordsByChain(span tracer.Span, chainID string) ([]*model.CardAuditRecord, error)
+}
+
+//
+// CardAuditRepository keeps the card audit log in the Cassandra tables partitioned by identity and by chain.
+//
+type CardAuditRepository struct {
+	session *gocql.Session
+}
+
+//
+// NewCardAuditRepository returns an instance of the CardAuditRepository.
+//
+func NewCardAuditRepository(connector cassandra.GoCQLSessionProvider) *CardAuditRepository {
+	return &CardAuditRepository{session: connector.GetGoCQLSession()}
+}
+
+//
+// AppendCardAuditRecord appends the card audit record to both tables at once. The record ID is set on append.
+//
+func (d *CardAuditRepository) AppendCardAuditRecord(span tracer.Span, record *model.CardAuditRecord) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := setCardAuditRecordID(record); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	batchAppend := d.session.NewBatch(gocql.LoggedBatch)
+	batchAppend.SetConsistency(gocql.LocalQuorum)
+
+	for _, query := range []string{qInsertCardAuditRecordByIdentity, qInsertCardAuditRecordByChain} {
+		batchAppend.Query(query,
+			record.ID,
+			record.CardID,
+			record.ChainID,
+			record.Identity,
+			record.ApplicationID,
+			record.AccountID,
+			record.PreviousCardID,
+			record.Action,
+			record.OperatorID,
+			record.Reason,
+			record.CreatedAt,
+		)
+	}
+
+	if err := d.session.ExecuteBatch(batchAppend); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error appending card's(%s) audit record", record.CardID,
+		))
+	}
+
+	return nil
+}
+
+//
+// GetCardAuditRecordsByIdentity returns the card audit records of the identity in all the applications
+// ordered by their creation time.
+//
+func (d *CardAuditRepository) GetCardAuditRecordsByIdentity(
+	span tracer.Span,
+	identity string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	records, err := d.getCardAuditRecords(d.session.Query(qGetCardAuditRecordsByIdentity, identity))
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting identity's(%s) card audit records", identity,
+		))
+	}
+
+	return records, nil
+}
+
+//
+// GetCardAuditRecordsByChain returns the card audit records of the chain ordered by their creation time.
+//
+func (d *CardAuditRepository) GetCardAuditRecordsByChain(
+	span tracer.Span,
+	chainID string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	records, err := d.getCardAuditRecords(d.session.Query(qGetCardAuditRecordsByChain, chainID))
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting chain's(%s) card audit records", chainID,
+		))
+	}
+
+	return records, nil
+}
+
+//
+// getCardAuditRecords scans the card audit records selected by the query.
+//
+func (d *CardAuditRepository) getCardAuditRecords(query *gocql.Query) ([]*model.CardAuditRecord, error) {
+
+	var (
+		record  model.CardAuditRecord
+		records = make([]*model.CardAuditRecord, 0)
+	)
+
+	iter := query.Iter()
+	for iter.Scan(
+		&record.ID,
+		&record.CardID,
+		&record.ChainID,
+		&record.Identity,
+		&record.ApplicationID,
+		&record.AccountID,
+		&record.PreviousCardID,
+		&record.Action,
+		&record.OperatorID,
+		&record.Reason,
+		&record.CreatedAt,
+	) {
+		r := record
+		records = append(records, &r)
+	}
+	if err := iter.Close(); nil != err {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+//
+// setCardAuditRecordID sets a new ID of the card audit record.
+//
+func setCardAuditRecordID(record *model.CardAuditRecord) error {
+
+	id, err := uuid.NewV4()
+	if nil != err {
+		return errors.WithMessage(err, "error creating card's(%s) audit record ID", record.CardID)
+	}
+	record.ID = id.String()
+
+	return nil
+}
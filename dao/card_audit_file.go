@@ -0,0 +1,195 @@
+package dao
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// cardAuditFileMaxLineSize is the maximum size of the card audit record line read from the file.
+//
+const cardAuditFileMaxLineSize = 1024 * 1024
+
+//
+// FileCardAuditSink appends the card audit log to the local JSON Lines file, one record per line.
+// The file is opened in the append mode and synced after every record. The reads scan the whole file.
+//
+type FileCardAuditSink struct {
+	mutex sync.RWMutex
+	path  string
+	file  *os.File
+}
+
+//
+// NewFileCardAuditSink opens or creates the card audit log file.
+//
+func NewFileCardAuditSink(path string) (*FileCardAuditSink, error) {
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if nil != err {
+		return nil, errors.WithMessage(err, `card audit file (%s) open error`, path)
+	}
+
+	return &FileCardAuditSink{
+		path: path,
+		file: file,
+	}, nil
+}
+
+//
+// AppendCardAuditRecord appends the card audit record line to the file. The record ID is set on append.
+//
+func (d *FileCardAuditSink) AppendCardAuditRecord(span tracer.Span, record *model.CardAuditRecord) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := setCardAuditRecordID(record); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	line, err := json.Marshal(record)
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"card's(%s) audit record marshal error", record.CardID,
+		))
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, err = d.file.Write(append(line, '\n')); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"card's(%s) audit record write error", record.CardID,
+		))
+	}
+
+	if err = d.file.Sync(); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"card's(%s) audit record sync error", record.CardID,
+		))
+	}
+
+	return nil
+}
+
+//
+// GetCardAuditRecordsByIdentity returns the card audit records of the identity in all the applications
+// in the order they were appended.
+//
+func (d *FileCardAuditSink) GetCardAuditRecordsByIdentity(
+	span tracer.Span,
+	identity string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	records, err := d.filter(func(record *model.CardAuditRecord) bool {
+		return record.Identity == identity
+	})
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting identity's(%s) card audit records", identity,
+		))
+	}
+
+	return records, nil
+}
+
+//
+// GetCardAuditRecordsByChain returns the card audit records of the chain in the order they were appended.
+//
+func (d *FileCardAuditSink) GetCardAuditRecordsByChain(
+	span tracer.Span,
+	chainID string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	records, err := d.filter(func(record *model.CardAuditRecord) bool {
+		return record.ChainID == chainID
+	})
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting chain's(%s) card audit records", chainID,
+		))
+	}
+
+	return records, nil
+}
+
+//
+// Close closes the card audit log file.
+//
+func (d *FileCardAuditSink) Close() error {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.file.Close()
+}
+
+//
+// filter scans the file and returns the records matching the predicate.
+//
+func (d *FileCardAuditSink) filter(match func(record *model.CardAuditRecord) bool) ([]*model.CardAuditRecord, error) {
+
+	// the appends are blocked, so the last line read is always complete.
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	file, err := os.Open(d.path)
+	if nil != err {
+		return nil, err
+	}
+	defer file.Close()
+
+	records := make([]*model.CardAuditRecord, 0)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), cardAuditFileMaxLineSize)
+	for scanner.Scan() {
+		record := new(model.CardAuditRecord)
+		if err = json.Unmarshal(scanner.Bytes(), record); nil != err {
+			return nil, errors.WithMessage(err, "card audit file (%s) line unmarshal error", d.path)
+		}
+
+		if match(record) {
+			records = append(records, record)
+		}
+	}
+
+	return records, scanner.Err()
+}
@@ -0,0 +1,119 @@
+package dao
+
+import (
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// MemoryCardAuditSink keeps the card audit log in memory. It is intended for the memory cards storage.
+//
+type MemoryCardAuditSink struct {
+	mutex   sync.RWMutex
+	records []*model.CardAuditRecord
+}
+
+//
+// NewMemoryCardAuditSink returns an empty instance of the MemoryCardAuditSink.
+//
+func NewMemoryCardAuditSink() *MemoryCardAuditSink {
+
+	return &MemoryCardAuditSink{
+		records: make([]*model.CardAuditRecord, 0),
+	}
+}
+
+//
+// AppendCardAuditRecord appends the card audit record. The record ID is set on append.
+//
+func (d *MemoryCardAuditSink) AppendCardAuditRecord(span tracer.Span, record *model.CardAuditRecord) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := setCardAuditRecordID(record); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	r := *record
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.records = append(d.records, &r)
+
+	return nil
+}
+
+//
+// GetCardAuditRecordsByIdentity returns the card audit records of the identity in all the applications
+// in the order they were appended.
+//
+func (d *MemoryCardAuditSink) GetCardAuditRecordsByIdentity(
+	span tracer.Span,
+	identity string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	return d.filter(func(record *model.CardAuditRecord) bool {
+		return record.Identity == identity
+	}), nil
+}
+
+//
+// GetCardAuditRecordsByChain returns the card audit records of the chain in the order they were appended.
+//
+func (d *MemoryCardAuditSink) GetCardAuditRecordsByChain(
+	span tracer.Span,
+	chainID string,
+) ([]*model.CardAuditRecord, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	return d.filter(func(record *model.CardAuditRecord) bool {
+		return record.ChainID == chainID
+	}), nil
+}
+
+//
+// filter returns copies of the records matching the predicate.
+//
+func (d *MemoryCardAuditSink) filter(match func(record *model.CardAuditRecord) bool) []*model.CardAuditRecord {
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	records := make([]*model.CardAuditRecord, 0)
+	for _, record := range d.records {
+		if match(record) {
+			r := *record
+			records = append(records, &r)
+		}
+	}
+
+	return records
+}
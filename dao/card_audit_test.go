@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// AppendCardAuditRecord :: for the memory sink :: returns the records by identity and by chain.
+//
+func TestMemoryCardAuditSinkAppendCardAuditRecord(t *testing.T) {
+
+	sink := NewMemoryCardAuditSink()
+
+	appendTestCardAuditRecords(t, sink)
+	assertTestCardAuditRecords(t, sink)
+}
+
+//
+// AppendCardAuditRecord :: for the file sink :: keeps the records appended before the file is reopened.
+//
+func TestFileCardAuditSinkAppendCardAuditRecord(t *testing.T) {
+
+	path := writeDAOTestFile(t, "")
+	defer os.Remove(path)
+
+	sink, err := NewFileCardAuditSink(path)
+	assert.NoError(t, err)
+
+	appendTestCardAuditRecords(t, sink)
+	assert.NoError(t, sink.Close())
+
+	sink, err = NewFileCardAuditSink(path)
+	assert.NoError(t, err)
+	defer sink.Close()
+
+	assertTestCardAuditRecords(t, sink)
+}
+
+//
+// appendTestCardAuditRecords appends the create, delete and restore records of the test chain
+// and a record of another identity.
+//
+func appendTestCardAuditRecords(t *testing.T, sink CardAuditSinkProvider) {
+
+	records := []*model.CardAuditRecord{
+		{CardID: "card", ChainID: "chain", Identity: testIdentity, Action: model.CardAuditActionCreate},
+		{CardID: "other", ChainID: "other chain", Identity: "bob", Action: model.CardAuditActionCreate},
+		{
+			CardID:         "delete card",
+			ChainID:        "chain",
+			Identity:       testIdentity,
+			PreviousCardID: "card",
+			Action:         model.CardAuditActionDelete,
+		},
+		{
+			CardID:         "delete card",
+			ChainID:        "chain",
+			Identity:       testIdentity,
+			PreviousCardID: "card",
+			Action:         model.CardAuditActionRestore,
+			OperatorID:     "operator",
+			Reason:         "deleted by the client release bug",
+		},
+	}
+
+	for _, record := range records {
+		record.ApplicationID, record.AccountID, record.CreatedAt = testApplicationID, "account", 1515686245
+
+		assert.NoError(t, sink.AppendCardAuditRecord(mock.StartNoopSpan(), record))
+		assert.NotEmpty(t, record.ID)
+	}
+}
+
+//
+// assertTestCardAuditRecords asserts the test chain records are returned in the order they were appended.
+//
+func assertTestCardAuditRecords(t *testing.T, sink CardAuditSinkProvider) {
+
+	byIdentity, err := sink.GetCardAuditRecordsByIdentity(mock.StartNoopSpan(), testIdentity)
+	assert.NoError(t, err)
+
+	byChain, err := sink.GetCardAuditRecordsByChain(mock.StartNoopSpan(), "chain")
+	assert.NoError(t, err)
+
+	assert.Equal(t, byIdentity, byChain)
+	if assert.Len(t, byChain, 3) {
+		assert.Equal(t, model.CardAuditActionCreate, byChain[0].Action)
+		assert.Equal(t, "delete card", byChain[1].CardID)
+		assert.Equal(t, "card", byChain[1].PreviousCardID)
+		assert.Equal(t, "account", byChain[1].AccountID)
+		assert.Equal(t, "operator", byChain[2].OperatorID)
+		assert.Equal(t, "deleted by the client release bug", byChain[2].Reason)
+	}
+}
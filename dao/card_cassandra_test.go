@@ -0,0 +1,293 @@
+package dao
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// Cassandra test environment variables.
+// The Cassandra tests run against the keyspace migrated with the migrate subcommand and are skipped otherwise.
+//
+const (
+	testCassandraHostsEnv    = "CARDS5_TEST_CASSANDRA_HOSTS"
+	testCassandraKeyspaceEnv = "CARDS5_TEST_CASSANDRA_KEYSPACE"
+)
+
+//
+// SaveCard :: for concurrent replacements of a card :: saves a single replacing card only.
+//
+func TestCassandraSaveCardForConcurrentReplacements(t *testing.T) {
+
+	const forks = 16
+
+	repository := getCassandraTestCardRepository(t)
+	defer repository.session.Close()
+
+	previousCard := saveCassandraTestCard(t, repository, "card1")
+
+	errs := runCassandraTestForks(forks, func(i int) error {
+		card := newCassandraTestCard(previousCard, fmt.Sprintf("fork%d", i))
+
+		return repository.SaveCard(mock.StartNoopSpan(), card)
+	})
+
+	assertSingleCassandraTestFork(t, errs)
+
+	cards, err := repository.GetChainCards(
+		mock.StartNoopSpan(),
+		previousCard.GetIdentity(),
+		previousCard.GetApplicationID(),
+		previousCard.GetChainID(),
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+}
+
+//
+// ReplacePreviousCard :: for concurrent delete cards of a card :: lets a single delete card delete the chain only.
+//
+func TestCassandraReplacePreviousCardForConcurrentDeletes(t *testing.T) {
+
+	const forks = 16
+
+	repository := getCassandraTestCardRepository(t)
+	defer repository.session.Close()
+
+	previousCard := saveCassandraTestCard(t, repository, "card1")
+
+	errs := runCassandraTestForks(forks, func(i int) error {
+		card := newCassandraTestCard(previousCard, fmt.Sprintf("delete%d", i))
+		if err := repository.ReplacePreviousCard(mock.StartNoopSpan(), card); nil != err {
+			return err
+		}
+
+		isDeletedNow, err := repository.SetChainDeleted(
+			mock.StartNoopSpan(),
+			card.GetIdentity(),
+			card.GetApplicationID(),
+			card.GetChainID(),
+			time.Now().Unix(),
+		)
+		if nil != err {
+			return err
+		}
+		if !isDeletedNow {
+			return fmt.Errorf("chain (%s) is deleted by another card than (%s)", card.GetChainID(), card.GetID())
+		}
+
+		return repository.SaveCard(mock.StartNoopSpan(), card)
+	})
+
+	assertSingleCassandraTestFork(t, errs)
+
+	isDeleted, err := repository.IsChainDeleted(
+		mock.StartNoopSpan(),
+		previousCard.GetIdentity(),
+		previousCard.GetApplicationID(),
+		previousCard.GetChainID(),
+	)
+
+	assert.NoError(t, err)
+	assert.True(t, isDeleted)
+}
+
+//
+// BackfillAdminTables :: for a chain deleted before the time given :: counts the not delete cards and the identity application.
+//
+func TestCassandraBackfillAdminTablesForADeletedChain(t *testing.T) {
+
+	repository := getCassandraTestCardRepository(t)
+	defer repository.session.Close()
+
+	previousCard := saveCassandraTestCard(t, repository, "card1")
+	deleteCard := newCassandraTestCard(previousCard, "delete")
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), deleteCard))
+
+	// the chain is saved as before the schema migration 5, with no identity application.
+	assert.NoError(t, repository.session.Query(
+		fmt.Sprintf("DELETE FROM %s WHERE identity = ? AND application_id = ?", CollectionIdentityApplications),
+		previousCard.GetIdentity(),
+		previousCard.GetApplicationID(),
+	).Exec())
+
+	counts, err := repository.BackfillAdminTables(mock.StartNoopSpan(), time.Now().Add(time.Second).Unix())
+
+	assert.NoError(t, err)
+	assert.Contains(t, counts, &model.ApplicationCardCount{ApplicationID: previousCard.GetApplicationID(), Cards: 1})
+
+	chains, err := repository.GetIdentityChains(mock.StartNoopSpan(), previousCard.GetIdentity())
+
+	assert.NoError(t, err)
+	assert.Contains(t, chainApplicationIDs(chains), previousCard.GetApplicationID())
+}
+
+//
+// BackfillAdminTables :: for the cards of a skewed creation time :: counts the cards by the time they are saved at.
+//
+func TestCassandraBackfillAdminTablesForASkewedCreationTime(t *testing.T) {
+
+	repository := getCassandraTestCardRepository(t)
+	defer repository.session.Close()
+
+	pastCard := newCassandraTestRootCard("card1", time.Now().Add(-24*time.Hour).Unix())
+	futureCard := newCassandraTestRootCard("card2", time.Now().Add(24*time.Hour).Unix())
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), pastCard))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), futureCard))
+
+	counts, err := repository.BackfillAdminTables(mock.StartNoopSpan(), time.Now().Add(-time.Hour).Unix())
+
+	assert.NoError(t, err)
+	assert.NotContains(t, countApplicationIDs(counts), pastCard.GetApplicationID())
+
+	counts, err = repository.BackfillAdminTables(mock.StartNoopSpan(), time.Now().Add(time.Hour).Unix())
+
+	assert.NoError(t, err)
+	assert.Contains(t, counts, &model.ApplicationCardCount{ApplicationID: futureCard.GetApplicationID(), Cards: 1})
+}
+
+//
+// countApplicationIDs returns the application IDs of the card counts.
+//
+func countApplicationIDs(counts []*model.ApplicationCardCount) []string {
+
+	applicationIDs := make([]string, 0, len(counts))
+	for _, count := range counts {
+		applicationIDs = append(applicationIDs, count.ApplicationID)
+	}
+
+	return applicationIDs
+}
+
+//
+// chainApplicationIDs returns the application IDs of the chains.
+//
+func chainApplicationIDs(chains []*model.CardChainState) []string {
+
+	applicationIDs := make([]string, 0, len(chains))
+	for _, chain := range chains {
+		applicationIDs = append(applicationIDs, chain.ApplicationID)
+	}
+
+	return applicationIDs
+}
+
+//
+// getCassandraTestCardRepository returns the repository connected to the test keyspace.
+//
+func getCassandraTestCardRepository(t *testing.T) *CardRepository {
+
+	hosts, keyspace := os.Getenv(testCassandraHostsEnv), os.Getenv(testCassandraKeyspaceEnv)
+	if "" == hosts || "" == keyspace {
+		t.Skipf("%s and %s are not set", testCassandraHostsEnv, testCassandraKeyspaceEnv)
+	}
+
+	cluster := gocql.NewCluster(strings.Split(hosts, ",")...)
+	cluster.Keyspace = keyspace
+
+	session, err := cluster.CreateSession()
+	if nil != err {
+		t.Fatalf("cassandra session error: %+v", err)
+	}
+
+	return &CardRepository{session: session}
+}
+
+//
+// saveCassandraTestCard saves the root card of a new application, so the test runs do not share the cards.
+//
+func saveCassandraTestCard(t *testing.T, repository *CardRepository, id string) *model.CardDTO {
+
+	card := newCassandraTestRootCard(id, time.Now().Unix())
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+
+	return card
+}
+
+//
+// newCassandraTestRootCard returns the root card of a new application created at the time given.
+//
+func newCassandraTestRootCard(id string, createdAt int64) *model.CardDTO {
+
+	applicationID := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	return &model.CardDTO{
+		ID:              applicationID + "-" + id,
+		ContentSnapshot: "snapshot of " + id,
+		Identity:        testIdentity,
+		ApplicationID:   applicationID,
+		Version:         model.CardVersion5,
+		ChainID:         applicationID + "-chain",
+		CreatedAt:       createdAt,
+		PublicKey:       []byte("public key"),
+	}
+}
+
+//
+// newCassandraTestCard returns the card replacing the previous card given.
+//
+func newCassandraTestCard(previousCard *model.CardDTO, id string) *model.CardDTO {
+
+	return &model.CardDTO{
+		ID:              previousCard.GetApplicationID() + "-" + id,
+		ContentSnapshot: "snapshot of " + id,
+		Identity:        previousCard.GetIdentity(),
+		ApplicationID:   previousCard.GetApplicationID(),
+		PreviousCardID:  previousCard.GetID(),
+		Version:         model.CardVersion5,
+		ChainID:         previousCard.GetChainID(),
+		CreatedAt:       time.Now().Unix(),
+	}
+}
+
+//
+// runCassandraTestForks runs the forks at once and returns their errors.
+//
+func runCassandraTestForks(forks int, fork func(i int) error) []error {
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, forks)
+	)
+	for i := 0; i < forks; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+			errs[i] = fork(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+//
+// assertSingleCassandraTestFork asserts a single fork has succeeded and the rest are superseded.
+//
+func assertSingleCassandraTestFork(t *testing.T, errs []error) {
+
+	succeeded := 0
+	for _, err := range errs {
+		if nil == err {
+			succeeded++
+		} else {
+			assert.Equal(t, ErrPreviousCardIsSuperseded, err)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
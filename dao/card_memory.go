@@ -0,0 +1,935 @@
+package dao
+
+import (
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+	"github.com/VirgilSecurity/virgil-services-core-kit/uuid"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// memoryChainKey is a key of the card chain entry. It mirrors the card chain table primary key.
+//
+type memoryChainKey struct {
+	identity      string
+	applicationID string
+	chainID       string
+}
+
+//
+// memoryPreviousIDKey is a key of the previous card ID entry.
+//
+type memoryPreviousIDKey struct {
+	previousCardID string
+	applicationID  string
+}
+
+//
+// memoryChain is an in-memory card chain entry.
+//
+type memoryChain struct {
+	ids       []string
+	createdAt int64
+	deletedAt int64
+}
+
+//
+// MemoryCardRepository is an in-memory data access layer to operate over Virgil Cards.
+// It follows the CardRepository semantics and is intended for the local development and tests.
+//
+type MemoryCardRepository struct {
+	mutex       sync.RWMutex
+	cards       map[string]*model.CardDTO
+	previousIDs map[memoryPreviousIDKey]string
+	chains      map[memoryChainKey]*memoryChain
+	chainKeys   []memoryChainKey
+	revocations map[memoryChainKey]*model.CardRevocation
+}
+
+//
+// NewMemoryCardRepository returns an instance of the MemoryCardRepository.
+//
+func NewMemoryCardRepository() *MemoryCardRepository {
+
+	return &MemoryCardRepository{
+		cards:       make(map[string]*model.CardDTO),
+		previousIDs: make(map[memoryPreviousIDKey]string),
+		chains:      make(map[memoryChainKey]*memoryChain),
+		revocations: make(map[memoryChainKey]*model.CardRevocation),
+	}
+}
+
+//
+// GetCardByID returns a Virgil Card by its ID
+// The method returns an error if no entry was found.
+//
+func (d *MemoryCardRepository) GetCardByID(span tracer.Span, ID string) (*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	card, ok := d.cards[ID]
+	if !ok {
+		return nil, cassandra.ErrEntityNotFound
+	}
+
+	return copyCardDTO(card), nil
+}
+
+//
+// GetCardsByIDs returns the cards found by their IDs.
+// Not found cards are omitted.
+//
+func (d *MemoryCardRepository) GetCardsByIDs(span tracer.Span, IDs []string) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	cards := make([]*model.CardDTO, 0, len(IDs))
+	for _, id := range uniqueStrings(IDs) {
+		if card, ok := d.cards[id]; ok {
+			cards = append(cards, copyCardDTO(card))
+		}
+	}
+
+	return cards, nil
+}
+
+//
+// DoesCardExistByPreviousIDAndScopeID returns true if card exists by search criteria.
+//
+func (d *MemoryCardRepository) DoesCardExistByPreviousIDAndScopeID(
+	span tracer.Span,
+	prevID, applicationID string,
+) (bool, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	_, ok := d.previousIDs[memoryPreviousIDKey{previousCardID: prevID, applicationID: applicationID}]
+
+	return ok, nil
+}
+
+//
+// GetSupersedingCardID returns an ID of the card that replaced the previous card.
+// Returns an empty string if the card has not been replaced.
+//
+func (d *MemoryCardRepository) GetSupersedingCardID(span tracer.Span, prevID, applicationID string) (string, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.previousIDs[memoryPreviousIDKey{previousCardID: prevID, applicationID: applicationID}], nil
+}
+
+//
+// SaveCard persists the Virgil Card DTO.
+// Returns ErrPreviousCardIsSuperseded if the previous card has been replaced by another card already.
+//
+func (d *MemoryCardRepository) SaveCard(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	previousIDKey := memoryPreviousIDKey{
+		previousCardID: card.GetPreviousCardID(),
+		applicationID:  card.GetApplicationID(),
+	}
+	if "" != card.GetPreviousCardID() {
+		if supersedingCardID, ok := d.previousIDs[previousIDKey]; ok && supersedingCardID != card.GetID() {
+			return tracer.SetSpanErrorAndReturn(span, ErrPreviousCardIsSuperseded)
+		}
+	}
+
+	d.cards[card.GetID()] = copyCardDTO(card)
+
+	// Chain entries are upserted the same way as the card chain table rows are.
+	key := memoryChainKey{
+		identity:      card.GetIdentity(),
+		applicationID: card.GetApplicationID(),
+		chainID:       card.GetChainID(),
+	}
+	chain, ok := d.chains[key]
+	if !ok {
+		chain = new(memoryChain)
+		d.chains[key] = chain
+		d.chainKeys = append(d.chainKeys, key)
+	}
+	if "" == card.GetPreviousCardID() {
+		chain.createdAt = card.CreatedAt
+		chain.deletedAt = 0
+	}
+	chain.ids = appendUniqueString(chain.ids, card.GetID())
+
+	if "" != card.GetPreviousCardID() {
+		d.previousIDs[previousIDKey] = card.GetID()
+	}
+
+	if nil != card.Revocation {
+		r := *card.Revocation
+		d.revocations[key] = &r
+	}
+
+	return nil
+}
+
+//
+// ReplacePreviousCard marks the previous card as replaced by the card.
+// Returns ErrPreviousCardIsSuperseded if the previous card has been replaced by another card already.
+//
+func (d *MemoryCardRepository) ReplacePreviousCard(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	previousIDKey := memoryPreviousIDKey{
+		previousCardID: card.GetPreviousCardID(),
+		applicationID:  card.GetApplicationID(),
+	}
+	if supersedingCardID, ok := d.previousIDs[previousIDKey]; ok && supersedingCardID != card.GetID() {
+		return tracer.SetSpanErrorAndReturn(span, ErrPreviousCardIsSuperseded)
+	}
+	d.previousIDs[previousIDKey] = card.GetID()
+
+	return nil
+}
+
+//
+// RevertPreviousCardReplacement reverts the replacement of the previous card by the card which is not saved.
+// The replacement of the previous card by another card is kept.
+//
+func (d *MemoryCardRepository) RevertPreviousCardReplacement(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	previousIDKey := memoryPreviousIDKey{
+		previousCardID: card.GetPreviousCardID(),
+		applicationID:  card.GetApplicationID(),
+	}
+	if supersedingCardID, ok := d.previousIDs[previousIDKey]; ok && supersedingCardID == card.GetID() {
+		delete(d.previousIDs, previousIDKey)
+	}
+
+	return nil
+}
+
+//
+// SearchCardsByIdentities returns Virgil Cards of not deleted chains by their identities.
+// The cards of the deleted chains are returned if they are included only.
+//
+func (d *MemoryCardRepository) SearchCardsByIdentities(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+	includeDeleted bool,
+) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	searchIdentities := make(map[string]struct{}, len(identities))
+	for _, identity := range identities {
+		searchIdentities[identity] = struct{}{}
+	}
+
+	cards := make([]*model.CardDTO, 0)
+	for _, key := range d.chainKeys {
+		if key.applicationID != scopeID {
+			continue
+		}
+		if _, ok := searchIdentities[key.identity]; !ok {
+			continue
+		}
+
+		chain := d.chains[key]
+		if 0 < chain.deletedAt && !includeDeleted {
+			continue
+		}
+
+		for _, id := range chain.ids {
+			card, ok := d.cards[id]
+			if !ok {
+				continue
+			}
+
+			// Search returns the same subset of card fields as the database one does.
+			cards = append(cards, &model.CardDTO{
+				Identity:        card.Identity,
+				ApplicationID:   card.ApplicationID,
+				ChainID:         card.ChainID,
+				ContentSnapshot: card.ContentSnapshot,
+				ExpiresAt:       card.ExpiresAt,
+				Signatures:      copySignatureDTOs(card.Signatures),
+			})
+		}
+	}
+
+	return cards, nil
+}
+
+//
+// SearchCardsByIdentitiesPage returns a page of Virgil Cards of not deleted chains by their identities.
+// The cards of the deleted chains are returned if they are included only.
+//
+func (d *MemoryCardRepository) SearchCardsByIdentitiesPage(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+	includeDeleted bool,
+	cursor *model.CardSearchCursor,
+	limit int,
+) (*model.CardSearchPage, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	cardIDs, next, err := searchPageCardIDs(span, identities, cursor, limit, func(
+		span tracer.Span,
+		identity string,
+	) ([]string, error) {
+
+		d.mutex.RLock()
+		defer d.mutex.RUnlock()
+
+		ids := make([]string, 0)
+		for _, key := range d.chainKeys {
+			if key.identity != identity || key.applicationID != scopeID {
+				continue
+			}
+
+			if chain := d.chains[key]; 0 >= chain.deletedAt || includeDeleted {
+				ids = append(ids, chain.ids...)
+			}
+		}
+
+		return ids, nil
+	})
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	page := &model.CardSearchPage{
+		Cards: make([]*model.CardDTO, 0, len(cardIDs)),
+		Next:  next,
+	}
+	for _, id := range cardIDs {
+		card, ok := d.cards[id]
+		if !ok {
+			continue
+		}
+
+		page.Cards = append(page.Cards, &model.CardDTO{
+			Identity:        card.Identity,
+			ApplicationID:   card.ApplicationID,
+			ChainID:         card.ChainID,
+			ContentSnapshot: card.ContentSnapshot,
+			ExpiresAt:       card.ExpiresAt,
+			Signatures:      copySignatureDTOs(card.Signatures),
+		})
+	}
+
+	return page, nil
+}
+
+//
+// SearchLatestCardsByIdentities returns the latest Virgil Card of each not deleted chain by their identities.
+// The cards are returned with their chain info. The latest cards of the deleted chains are returned
+// if they are included only.
+//
+func (d *MemoryCardRepository) SearchLatestCardsByIdentities(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+	includeDeleted bool,
+) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	searchIdentities := make(map[string]struct{}, len(identities))
+	for _, identity := range identities {
+		searchIdentities[identity] = struct{}{}
+	}
+
+	latestCards := make([]*model.CardDTO, 0)
+	for _, key := range d.chainKeys {
+		if key.applicationID != scopeID {
+			continue
+		}
+		if _, ok := searchIdentities[key.identity]; !ok {
+			continue
+		}
+
+		chain := d.chains[key]
+		if 0 < chain.deletedAt && !includeDeleted {
+			continue
+		}
+
+		var chainCards []*model.CardDTO
+		for _, id := range chain.ids {
+			if card, ok := d.cards[id]; ok {
+				chainCards = append(chainCards, copyCardDTO(card))
+			}
+		}
+
+		if head := resolveChainHead(chainCards); nil != head {
+			latestCards = append(latestCards, head)
+		}
+	}
+
+	return latestCards, nil
+}
+
+//
+// SetCardChainID sets chainID property of card given.
+//
+func (d *MemoryCardRepository) SetCardChainID(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if "" == card.GetPreviousCardID() {
+		// new card create
+		chainID, err := uuid.NewV4()
+		if nil != err {
+			return tracer.SetSpanErrorAndReturn(
+				span,
+				errors.WithMessage(err, "error creating card's (%s) new chainID", card.GetID()),
+			)
+		}
+		card.SetChainID(chainID.String())
+		return nil
+	}
+
+	d.mutex.RLock()
+	previousCard, ok := d.cards[card.GetPreviousCardID()]
+	d.mutex.RUnlock()
+
+	if !ok {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			errors.New("error retrieving previous card's (%s) chainID", card.GetPreviousCardID()),
+		)
+	}
+
+	if "" == previousCard.GetChainID() {
+		return tracer.SetSpanErrorAndReturn(
+			span,
+			errors.New("previous card's (%s) chainID is empty", card.GetPreviousCardID()),
+		)
+	}
+
+	card.SetChainID(previousCard.GetChainID())
+
+	return nil
+}
+
+//
+// IsChainDeleted checks if chain is deleted.
+//
+func (d *MemoryCardRepository) IsChainDeleted(span tracer.Span, identity, scopeID, chainID string) (bool, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	chain, ok := d.chains[memoryChainKey{identity: identity, applicationID: scopeID, chainID: chainID}]
+	if !ok {
+		return false, nil
+	}
+
+	return 0 < chain.deletedAt, nil
+}
+
+//
+// SetChainDeleted sets 'Deleted' time of chainID.
+// Returns 'false' in case the chain has already been deleted or does not exist.
+//
+func (d *MemoryCardRepository) SetChainDeleted(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+	unixSeconds int64,
+) (bool, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// Compare-and-set the same way as the 'IF deleted_at = 0' condition does.
+	chain, ok := d.chains[memoryChainKey{identity: identity, applicationID: scopeID, chainID: chainID}]
+	if !ok || 0 != chain.deletedAt {
+		return false, nil
+	}
+
+	chain.deletedAt = unixSeconds
+
+	return true, nil
+}
+
+//
+// GetChainCards returns the cards of the chain ordered from the root card to the latest one.
+// The delete tombstone card goes the last one in case the chain is deleted.
+//
+func (d *MemoryCardRepository) GetChainCards(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+) ([]*model.CardDTO, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	cards := make([]*model.CardDTO, 0)
+
+	chain, ok := d.chains[memoryChainKey{identity: identity, applicationID: scopeID, chainID: chainID}]
+	if !ok {
+		return cards, nil
+	}
+
+	for _, id := range chain.ids {
+		if card, ok := d.cards[id]; ok {
+			cards = append(cards, copyCardDTO(card))
+		}
+	}
+
+	return orderChainCards(cards), nil
+}
+
+//
+// SaveChainRevocation saves the signed revocation record of the deleted chain.
+//
+func (d *MemoryCardRepository) SaveChainRevocation(span tracer.Span, revocation *model.CardRevocation) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	r := *revocation
+	d.revocations[memoryChainKey{
+		identity:      revocation.Identity,
+		applicationID: revocation.ApplicationID,
+		chainID:       revocation.ChainID,
+	}] = &r
+
+	return nil
+}
+
+//
+// GetChainRevocation returns the revocation record of the chain.
+// Returns nil if the chain has not been revoked.
+//
+func (d *MemoryCardRepository) GetChainRevocation(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+) (*model.CardRevocation, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	revocation, ok := d.revocations[memoryChainKey{identity: identity, applicationID: scopeID, chainID: chainID}]
+	if !ok {
+		return nil, nil
+	}
+
+	r := *revocation
+
+	return &r, nil
+}
+
+//
+// SearchChainRevocationsByIdentities returns the revocation records of the deleted chains by their identities.
+//
+func (d *MemoryCardRepository) SearchChainRevocationsByIdentities(
+	span tracer.Span,
+	identities []string,
+	scopeID string,
+) ([]*model.CardRevocation, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	searchIdentities := make(map[string]struct{}, len(identities))
+	for _, identity := range identities {
+		searchIdentities[identity] = struct{}{}
+	}
+
+	revocations := make([]*model.CardRevocation, 0)
+	for _, key := range d.chainKeys {
+		if key.applicationID != scopeID {
+			continue
+		}
+		if _, ok := searchIdentities[key.identity]; !ok {
+			continue
+		}
+
+		if revocation, ok := d.revocations[key]; ok {
+			r := *revocation
+			revocations = append(revocations, &r)
+		}
+	}
+
+	return revocations, nil
+}
+
+//
+// GetChainDeletedAt returns the chain 'Deleted' time. Zero means the chain is not deleted.
+//
+func (d *MemoryCardRepository) GetChainDeletedAt(span tracer.Span, identity, scopeID, chainID string) (int64, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	chain, ok := d.chains[memoryChainKey{identity: identity, applicationID: scopeID, chainID: chainID}]
+	if !ok {
+		return 0, nil
+	}
+
+	return chain.deletedAt, nil
+}
+
+//
+// RestoreChain clears the 'Deleted' time of the chain deleted at the time given and unlinks the delete card.
+// The delete card is kept, but it is removed from the chain and from the previous card replacements
+// along with the chain revocation record.
+// Returns 'false' in case the chain 'Deleted' time has been changed.
+//
+func (d *MemoryCardRepository) RestoreChain(
+	span tracer.Span,
+	identity, scopeID, chainID string,
+	deleteCard *model.CardDTO,
+	deletedAt int64,
+) (bool, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// Compare-and-set the same way as the 'IF deleted_at = ?' condition does.
+	key := memoryChainKey{identity: identity, applicationID: scopeID, chainID: chainID}
+	chain, ok := d.chains[key]
+	if !ok || deletedAt != chain.deletedAt {
+		return false, nil
+	}
+
+	if nil != deleteCard {
+		ids := make([]string, 0, len(chain.ids))
+		for _, id := range chain.ids {
+			if id != deleteCard.GetID() {
+				ids = append(ids, id)
+			}
+		}
+		chain.ids = ids
+
+		delete(d.previousIDs, memoryPreviousIDKey{
+			previousCardID: deleteCard.GetPreviousCardID(),
+			applicationID:  scopeID,
+		})
+		delete(d.revocations, key)
+	}
+
+	chain.deletedAt = 0
+
+	return true, nil
+}
+
+//
+// GetIdentityChains returns the chains of the identity in all the applications.
+//
+func (d *MemoryCardRepository) GetIdentityChains(span tracer.Span, identity string) ([]*model.CardChainState, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	chains := make([]*model.CardChainState, 0)
+	for _, key := range d.chainKeys {
+		if key.identity != identity {
+			continue
+		}
+
+		chain := d.chains[key]
+		chains = append(chains, &model.CardChainState{
+			Identity:      key.identity,
+			ApplicationID: key.applicationID,
+			ChainID:       key.chainID,
+			CardIDs:       append([]string(nil), chain.ids...),
+			CreatedAt:     chain.createdAt,
+			DeletedAt:     chain.deletedAt,
+		})
+	}
+
+	sortChainStates(chains)
+
+	return chains, nil
+}
+
+//
+// CountCardsByApplications returns the amount of cards of each application ordered by the application ID.
+// The delete cards are not counted.
+//
+func (d *MemoryCardRepository) CountCardsByApplications(span tracer.Span) ([]*model.ApplicationCardCount, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, card := range d.cards {
+		if !card.IsDeleteCard() {
+			counts[card.GetApplicationID()]++
+		}
+	}
+
+	return wrapApplicationCardCounts(counts), nil
+}
+
+//
+// BackfillAdminTables returns no counts, the memory admin tables are computed from the saved cards.
+//
+func (d *MemoryCardRepository) BackfillAdminTables(
+	span tracer.Span,
+	savedBefore int64,
+) ([]*model.ApplicationCardCount, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	return []*model.ApplicationCardCount{}, nil
+}
+
+//
+// copyCardDTO returns a copy of the card without the computed properties.
+//
+func copyCardDTO(card *model.CardDTO) *model.CardDTO {
+
+	c := *card
+	c.PublicKey = append([]byte(nil), card.PublicKey...)
+	c.Signatures = copySignatureDTOs(card.Signatures)
+	c.IsSuperseeded = false
+	c.SuperseedingCardID = ""
+	c.Chain = nil
+	c.Revocation = nil
+	c.RevocationReason = ""
+	c.RevocationNote = ""
+
+	return &c
+}
+
+//
+// copySignatureDTOs returns a copy of the signature list.
+//
+func copySignatureDTOs(signatures []*model.CardSignatureDTO) []*model.CardSignatureDTO {
+
+	var signs []*model.CardSignatureDTO
+	for _, signature := range signatures {
+		s := *signature
+		signs = append(signs, &s)
+	}
+
+	return signs
+}
+
+//
+// appendUniqueString appends the value to the list unless it is there already.
+//
+func appendUniqueString(list []string, value string) []string {
+
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
@@ -0,0 +1,647 @@
+package dao
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// Testing constants.
+//
+const (
+	testIdentity      = "identity"
+	testApplicationID = "application"
+)
+
+//
+// GetCardByID :: for a not existing card :: returns not found error.
+//
+func TestMemoryGetCardByIDForANotExistingCard(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+
+	card, err := repository.GetCardByID(mock.StartNoopSpan(), "not existing card ID")
+
+	assert.Nil(t, card)
+	assert.Equal(t, cassandra.ErrEntityNotFound, err)
+}
+
+//
+// GetCardsByIDs :: for existing and not existing cards :: returns the existing cards only.
+//
+func TestMemoryGetCardsByIDs(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+
+	cards, err := repository.GetCardsByIDs(
+		mock.StartNoopSpan(),
+		[]string{card.GetID(), "not existing card ID", card.GetID()},
+	)
+
+	assert.NoError(t, err)
+	if assert.Len(t, cards, 1) {
+		assert.Equal(t, card.GetID(), cards[0].GetID())
+		assert.Equal(t, testApplicationID, cards[0].GetApplicationID())
+	}
+}
+
+//
+// SaveCard :: for a new card :: persists the card and its chain.
+//
+func TestMemorySaveCardForANewCard(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+
+	savedCard, err := repository.GetCardByID(mock.StartNoopSpan(), card.GetID())
+
+	assert.NoError(t, err)
+	assert.Equal(t, card.GetChainID(), savedCard.GetChainID())
+	assert.Equal(t, card.GetContentSnapshot(), savedCard.GetContentSnapshot())
+
+	isDeleted, err := repository.IsChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		card.GetChainID(),
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, isDeleted)
+}
+
+//
+// SetCardChainID :: for a card that replaces another one :: sets the previous card chain ID.
+//
+func TestMemorySetCardChainIDForAReplacingCard(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	previousCard := saveMemoryTestCard(t, repository, "card1", "")
+	card := saveMemoryTestCard(t, repository, "card2", previousCard.GetID())
+
+	assert.Equal(t, previousCard.GetChainID(), card.GetChainID())
+
+	isSuperseeded, err := repository.DoesCardExistByPreviousIDAndScopeID(
+		mock.StartNoopSpan(),
+		previousCard.GetID(),
+		testApplicationID,
+	)
+
+	assert.NoError(t, err)
+	assert.True(t, isSuperseeded)
+}
+
+//
+// GetSupersedingCardID :: for a replaced card :: returns the replacing card ID.
+//
+func TestMemoryGetSupersedingCardIDForAReplacedCard(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	previousCard := saveMemoryTestCard(t, repository, "card1", "")
+	card := saveMemoryTestCard(t, repository, "card2", previousCard.GetID())
+
+	supersedingCardID, err := repository.GetSupersedingCardID(mock.StartNoopSpan(), previousCard.GetID(), testApplicationID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, card.GetID(), supersedingCardID)
+
+	supersedingCardID, err = repository.GetSupersedingCardID(mock.StartNoopSpan(), card.GetID(), testApplicationID)
+
+	assert.NoError(t, err)
+	assert.Empty(t, supersedingCardID)
+}
+
+//
+// SaveCard :: for concurrent replacements of a card :: saves a single replacing card only.
+//
+func TestMemorySaveCardForConcurrentReplacements(t *testing.T) {
+
+	const forks = 16
+
+	repository := NewMemoryCardRepository()
+	previousCard := saveMemoryTestCard(t, repository, "card1", "")
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make(chan error, forks)
+	)
+	for i := 0; i < forks; i++ {
+		card := &model.CardDTO{
+			ID:             fmt.Sprintf("fork%d", i),
+			Identity:       testIdentity,
+			ApplicationID:  testApplicationID,
+			PreviousCardID: previousCard.GetID(),
+			Version:        model.CardVersion5,
+			CreatedAt:      time.Now().Unix(),
+		}
+		assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+
+		wg.Add(1)
+		go func(card *model.CardDTO) {
+			defer wg.Done()
+
+			<-start
+			errs <- repository.SaveCard(mock.StartNoopSpan(), card)
+		}(card)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	saved := 0
+	for err := range errs {
+		if nil == err {
+			saved++
+		} else {
+			assert.Equal(t, ErrPreviousCardIsSuperseded, err)
+		}
+	}
+	assert.Equal(t, 1, saved)
+
+	cards, err := repository.GetChainCards(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		previousCard.GetChainID(),
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+}
+
+//
+// ReplacePreviousCard :: for the card replaced by another card :: keeps the replacement until it is reverted.
+//
+func TestMemoryReplacePreviousCardUntilItIsReverted(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	previousCard := saveMemoryTestCard(t, repository, "card1", "")
+	deleteCard := &model.CardDTO{ID: "card2", ApplicationID: testApplicationID, PreviousCardID: previousCard.GetID()}
+	replacingCard := &model.CardDTO{ID: "card3", ApplicationID: testApplicationID, PreviousCardID: previousCard.GetID()}
+
+	assert.NoError(t, repository.ReplacePreviousCard(mock.StartNoopSpan(), deleteCard))
+	assert.NoError(t, repository.ReplacePreviousCard(mock.StartNoopSpan(), deleteCard))
+	assert.Equal(t, ErrPreviousCardIsSuperseded, repository.ReplacePreviousCard(mock.StartNoopSpan(), replacingCard))
+
+	assert.NoError(t, repository.RevertPreviousCardReplacement(mock.StartNoopSpan(), replacingCard))
+	assert.NoError(t, repository.RevertPreviousCardReplacement(mock.StartNoopSpan(), deleteCard))
+
+	assert.NoError(t, repository.ReplacePreviousCard(mock.StartNoopSpan(), replacingCard))
+}
+
+//
+// SetCardChainID :: for a not existing previous card :: returns an error.
+//
+func TestMemorySetCardChainIDForANotExistingPreviousCard(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+
+	err := repository.SetCardChainID(mock.StartNoopSpan(), &model.CardDTO{
+		ID:             "card2",
+		PreviousCardID: "card1",
+	})
+
+	assert.Error(t, err)
+}
+
+//
+// SearchCardsByIdentities :: for cards of several chains :: returns cards of not deleted chains of the scope.
+//
+func TestMemorySearchCardsByIdentities(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+	saveMemoryTestCard(t, repository, "card2", card.GetID())
+	deletedCard := saveMemoryTestCard(t, repository, "card3", "")
+
+	_, err := repository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		deletedCard.GetChainID(),
+		time.Now().Unix(),
+	)
+
+	assert.NoError(t, err)
+
+	cards, err := repository.SearchCardsByIdentities(
+		mock.StartNoopSpan(),
+		[]string{testIdentity, "another identity"},
+		testApplicationID,
+		false,
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+
+	cards, err = repository.SearchCardsByIdentities(
+		mock.StartNoopSpan(),
+		[]string{testIdentity, "another identity"},
+		testApplicationID,
+		true,
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 3)
+
+	cards, err = repository.SearchCardsByIdentities(
+		mock.StartNoopSpan(),
+		[]string{testIdentity},
+		"another application",
+		false,
+	)
+
+	assert.NoError(t, err)
+	assert.Empty(t, cards)
+}
+
+//
+// SearchCardsByIdentitiesPage :: for a page smaller than the result :: returns the next page cursor.
+//
+func TestMemorySearchCardsByIdentitiesPage(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+	saveMemoryTestCard(t, repository, "card2", card.GetID())
+
+	page, err := repository.SearchCardsByIdentitiesPage(
+		mock.StartNoopSpan(),
+		[]string{testIdentity},
+		testApplicationID,
+		false,
+		&model.CardSearchCursor{},
+		1,
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Cards, 1)
+	assert.Equal(t, &model.CardSearchCursor{CardOffset: 1}, page.Next)
+
+	page, err = repository.SearchCardsByIdentitiesPage(
+		mock.StartNoopSpan(),
+		[]string{testIdentity},
+		testApplicationID,
+		false,
+		page.Next,
+		1,
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Cards, 1)
+	assert.Nil(t, page.Next)
+}
+
+//
+// SearchLatestCardsByIdentities :: for cards of several chains :: returns the latest card of each not deleted chain.
+//
+func TestMemorySearchLatestCardsByIdentities(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+	latestCard := saveMemoryTestCard(t, repository, "card2", card.GetID())
+	deletedCard := saveMemoryTestCard(t, repository, "card3", "")
+
+	_, err := repository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		deletedCard.GetChainID(),
+		time.Now().Unix(),
+	)
+
+	assert.NoError(t, err)
+
+	cards, err := repository.SearchLatestCardsByIdentities(
+		mock.StartNoopSpan(),
+		[]string{testIdentity},
+		testApplicationID,
+		false,
+	)
+
+	assert.NoError(t, err)
+	if assert.Len(t, cards, 1) {
+		assert.Equal(t, latestCard.GetID(), cards[0].GetID())
+		assert.Equal(t, &model.CardChainInfo{
+			ChainID:        card.GetChainID(),
+			PreviousCardID: card.GetID(),
+			Length:         2,
+		}, cards[0].Chain)
+	}
+}
+
+//
+// SetChainDeleted :: for an already deleted chain :: is not applied.
+//
+func TestMemorySetChainDeletedForAnAlreadyDeletedChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+
+	isDeletedNow, err := repository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		card.GetChainID(),
+		time.Now().Unix(),
+	)
+
+	assert.NoError(t, err)
+	assert.True(t, isDeletedNow)
+
+	isDeletedNow, err = repository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		card.GetChainID(),
+		time.Now().Unix(),
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, isDeletedNow)
+}
+
+//
+// SetChainDeleted :: for a not existing chain :: is not applied.
+//
+func TestMemorySetChainDeletedForANotExistingChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+
+	isDeletedNow, err := repository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		"not existing chain ID",
+		time.Now().Unix(),
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, isDeletedNow)
+}
+
+//
+// GetChainCards :: for a deleted chain :: returns cards from the root one to the delete tombstone.
+//
+func TestMemoryGetChainCardsForADeletedChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	rootCard := saveMemoryTestCard(t, repository, "card3", "")
+	card := saveMemoryTestCard(t, repository, "card2", rootCard.GetID())
+	tombstone := saveMemoryTestCard(t, repository, "card1", card.GetID())
+
+	isDeletedNow, err := repository.SetChainDeleted(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		rootCard.GetChainID(),
+		time.Now().Unix(),
+	)
+
+	assert.NoError(t, err)
+	assert.True(t, isDeletedNow)
+
+	cards, err := repository.GetChainCards(mock.StartNoopSpan(), testIdentity, testApplicationID, rootCard.GetChainID())
+
+	assert.NoError(t, err)
+	if assert.Len(t, cards, 3) {
+		assert.Equal(t, rootCard.GetID(), cards[0].GetID())
+		assert.Equal(t, card.GetID(), cards[1].GetID())
+		assert.Equal(t, tombstone.GetID(), cards[2].GetID())
+	}
+}
+
+//
+// GetChainCards :: for a not existing chain :: returns an empty list.
+//
+func TestMemoryGetChainCardsForANotExistingChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+
+	cards, err := repository.GetChainCards(mock.StartNoopSpan(), testIdentity, testApplicationID, "chain ID")
+
+	assert.NoError(t, err)
+	assert.Empty(t, cards)
+}
+
+//
+// GetChainRevocation :: for a revoked chain :: returns the saved revocation record.
+//
+func TestMemoryGetChainRevocationForARevokedChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card2", "")
+	tombstone := saveMemoryTestCard(t, repository, "card1", card.GetID())
+	tombstone.RevocationReason = model.RevocationReasonKeyCompromise
+
+	revocation := model.NewCardRevocation(tombstone, time.Now().Unix())
+	revocation.Signature = "signature"
+
+	assert.NoError(t, repository.SaveChainRevocation(mock.StartNoopSpan(), revocation))
+
+	saved, err := repository.GetChainRevocation(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID())
+
+	assert.NoError(t, err)
+	assert.Equal(t, revocation, saved)
+	assert.Equal(t, card.GetID(), saved.CardID)
+	assert.Equal(t, tombstone.GetID(), saved.DeleteCardID)
+}
+
+//
+// GetChainRevocation :: for a not revoked chain :: returns nil.
+//
+func TestMemoryGetChainRevocationForANotRevokedChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card1", "")
+
+	revocation, err := repository.GetChainRevocation(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		card.GetChainID(),
+	)
+
+	assert.NoError(t, err)
+	assert.Nil(t, revocation)
+}
+
+//
+// RestoreChain :: for a deleted chain :: unlinks the delete card and clears the deleted time.
+//
+func TestMemoryRestoreChainForADeletedChain(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card2", "")
+	deleteCard := saveMemoryTestCard(t, repository, "card1", card.GetID())
+	deletedAt := time.Now().Unix()
+
+	_, err := repository.SetChainDeleted(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID(), deletedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, repository.SaveChainRevocation(mock.StartNoopSpan(), model.NewCardRevocation(deleteCard, deletedAt)))
+
+	isRestoredNow, err := repository.RestoreChain(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		card.GetChainID(),
+		deleteCard,
+		deletedAt,
+	)
+
+	assert.NoError(t, err)
+	assert.True(t, isRestoredNow)
+
+	isDeleted, err := repository.IsChainDeleted(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID())
+	assert.NoError(t, err)
+	assert.False(t, isDeleted)
+
+	cards, err := repository.GetChainCards(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID())
+	assert.NoError(t, err)
+	if assert.Len(t, cards, 1) {
+		assert.Equal(t, card.GetID(), cards[0].GetID())
+	}
+
+	isSuperseded, err := repository.DoesCardExistByPreviousIDAndScopeID(mock.StartNoopSpan(), card.GetID(), testApplicationID)
+	assert.NoError(t, err)
+	assert.False(t, isSuperseded)
+
+	revocation, err := repository.GetChainRevocation(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID())
+	assert.NoError(t, err)
+	assert.Nil(t, revocation)
+
+	_, err = repository.GetCardByID(mock.StartNoopSpan(), deleteCard.GetID())
+	assert.NoError(t, err)
+}
+
+//
+// RestoreChain :: for a changed deleted time :: returns 'false' and keeps the chain deleted.
+//
+func TestMemoryRestoreChainForAChangedDeletedTime(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	card := saveMemoryTestCard(t, repository, "card2", "")
+	deleteCard := saveMemoryTestCard(t, repository, "card1", card.GetID())
+	deletedAt := time.Now().Unix()
+
+	_, err := repository.SetChainDeleted(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID(), deletedAt)
+	assert.NoError(t, err)
+
+	isRestoredNow, err := repository.RestoreChain(
+		mock.StartNoopSpan(),
+		testIdentity,
+		testApplicationID,
+		card.GetChainID(),
+		deleteCard,
+		deletedAt-1,
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, isRestoredNow)
+
+	chainDeletedAt, err := repository.GetChainDeletedAt(mock.StartNoopSpan(), testIdentity, testApplicationID, card.GetChainID())
+	assert.NoError(t, err)
+	assert.Equal(t, deletedAt, chainDeletedAt)
+}
+
+//
+// GetIdentityChains :: for the identity chains in several applications :: returns the chains of all the applications.
+//
+func TestMemoryGetIdentityChainsInSeveralApplications(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	first := saveMemoryTestCard(t, repository, "first card", "")
+	second := saveMemoryTestCard(t, repository, "second card", first.GetID())
+
+	otherCard := &model.CardDTO{
+		ID:              "other application card",
+		ContentSnapshot: "snapshot of other application card",
+		Identity:        testIdentity,
+		ApplicationID:   "another application",
+		Version:         model.CardVersion5,
+		CreatedAt:       time.Now().Unix(),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), otherCard))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), otherCard))
+
+	chains, err := repository.GetIdentityChains(mock.StartNoopSpan(), testIdentity)
+
+	assert.NoError(t, err)
+	if assert.Len(t, chains, 2) {
+		assert.Equal(t, "another application", chains[0].ApplicationID)
+		assert.Equal(t, []string{otherCard.GetID()}, chains[0].CardIDs)
+		assert.Equal(t, testApplicationID, chains[1].ApplicationID)
+		assert.Equal(t, []string{first.GetID(), second.GetID()}, chains[1].CardIDs)
+	}
+}
+
+//
+// CountCardsByApplications :: for the cards of several applications :: returns the counts of not delete cards ordered by application.
+//
+func TestMemoryCountCardsByApplications(t *testing.T) {
+
+	repository := NewMemoryCardRepository()
+	first := saveMemoryTestCard(t, repository, "first card", "")
+	second := saveMemoryTestCard(t, repository, "second card", first.GetID())
+
+	deleteCard := &model.CardDTO{
+		ID:             "delete card",
+		Identity:       testIdentity,
+		ApplicationID:  testApplicationID,
+		PreviousCardID: second.GetID(),
+		Version:        model.CardVersion5,
+		CreatedAt:      time.Now().Unix(),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), deleteCard))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), deleteCard))
+
+	otherCard := &model.CardDTO{
+		ID:            "other application card",
+		Identity:      testIdentity,
+		ApplicationID: "another application",
+		Version:       model.CardVersion5,
+		CreatedAt:     time.Now().Unix(),
+	}
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), otherCard))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), otherCard))
+
+	counts, err := repository.CountCardsByApplications(mock.StartNoopSpan())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.ApplicationCardCount{
+		{ApplicationID: "another application", Cards: 1},
+		{ApplicationID: testApplicationID, Cards: 2},
+	}, counts)
+}
+
+//
+// saveMemoryTestCard saves a test card to the repository.
+//
+func saveMemoryTestCard(t *testing.T, repository *MemoryCardRepository, id, previousCardID string) *model.CardDTO {
+
+	card := &model.CardDTO{
+		ID:              id,
+		ContentSnapshot: "snapshot of " + id,
+		Identity:        testIdentity,
+		ApplicationID:   testApplicationID,
+		PreviousCardID:  previousCardID,
+		Version:         model.CardVersion5,
+		CreatedAt:       time.Now().Unix(),
+		PublicKey:       []byte("public key"),
+	}
+
+	assert.NoError(t, repository.SetCardChainID(mock.StartNoopSpan(), card))
+	assert.NoError(t, repository.SaveCard(mock.StartNoopSpan(), card))
+
+	return card
+}
@@ -0,0 +1,205 @@
+package dao
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// Benchmark environment variables.
+// The search benchmarks run against the keyspace migrated with the migrate subcommand and are skipped otherwise.
+//
+const (
+	benchCassandraHostsEnv    = "CARDS5_BENCH_CASSANDRA_HOSTS"
+	benchCassandraKeyspaceEnv = "CARDS5_BENCH_CASSANDRA_KEYSPACE"
+)
+
+//
+// Benchmark queries.
+//
+var (
+	// Select chain card IDs with the chain deleted state by identities list.
+	// It is the search by card IDs the identity partitioned table search is benchmarked against.
+	qSearchCardIDsByIdentities = fmt.Sprintf(`
+	SELECT
+		deleted_at,
+		ids
+	FROM
+		%s
+	WHERE
+		application_id = ? AND identity IN ?
+	`, CollectionCardChain)
+)
+
+//
+// Benchmark data set size.
+//
+const (
+	benchIdentities        = 10
+	benchChainsPerIdentity = 3
+	benchCardsPerChain     = 5
+)
+
+//
+// BenchmarkSearchCardsByIdentities compares the search by the chain card IDs lookup
+// to the search in the identity partitioned table.
+//
+func BenchmarkSearchCardsByIdentities(b *testing.B) {
+
+	repository := getBenchCardRepository(b)
+	defer repository.session.Close()
+
+	applicationID, identities := seedBenchCards(b, repository)
+
+	b.Run("ChainLookup", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cards, err := searchCardsByIdentitiesWithChainLookup(repository, identities, applicationID)
+			assertBenchCardsFound(b, cards, err)
+		}
+	})
+
+	b.Run("IdentityTable", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cards, err := repository.SearchCardsByIdentities(mock.StartNoopSpan(), identities, applicationID, false)
+			assertBenchCardsFound(b, cards, err)
+		}
+	})
+}
+
+//
+// getBenchCardRepository returns the repository connected to the benchmark keyspace.
+//
+func getBenchCardRepository(b *testing.B) *CardRepository {
+
+	hosts, keyspace := os.Getenv(benchCassandraHostsEnv), os.Getenv(benchCassandraKeyspaceEnv)
+	if "" == hosts || "" == keyspace {
+		b.Skipf("%s and %s are not set", benchCassandraHostsEnv, benchCassandraKeyspaceEnv)
+	}
+
+	cluster := gocql.NewCluster(strings.Split(hosts, ",")...)
+	cluster.Keyspace = keyspace
+
+	session, err := cluster.CreateSession()
+	if nil != err {
+		b.Fatalf("cassandra session error: %+v", err)
+	}
+
+	return &CardRepository{session: session}
+}
+
+//
+// seedBenchCards saves the benchmark cards of a new application and returns the application ID and identities.
+//
+func seedBenchCards(b *testing.B, repository *CardRepository) (string, []string) {
+
+	applicationID := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	identities := make([]string, 0, benchIdentities)
+
+	for i := 0; i < benchIdentities; i++ {
+		identity := fmt.Sprintf("identity-%d", i)
+		identities = append(identities, identity)
+
+		for j := 0; j < benchChainsPerIdentity; j++ {
+			previousCardID := ""
+			for k := 0; k < benchCardsPerChain; k++ {
+				card := &model.CardDTO{
+					ID:              fmt.Sprintf("%s-%s-%d-%d", applicationID, identity, j, k),
+					ContentSnapshot: "content snapshot",
+					Identity:        identity,
+					PreviousCardID:  previousCardID,
+					ApplicationID:   applicationID,
+					Version:         model.CardVersion5,
+					ChainID:         fmt.Sprintf("%s-chain-%d", identity, j),
+					CreatedAt:       time.Now().Unix(),
+					Signatures:      []*model.CardSignatureDTO{{Signer: model.SelfSignatureType, Signature: "sign"}},
+				}
+				if err := repository.SaveCard(mock.StartNoopSpan(), card); nil != err {
+					b.Fatalf("save card error: %+v", err)
+				}
+
+				previousCardID = card.ID
+			}
+		}
+	}
+
+	return applicationID, identities
+}
+
+//
+// assertBenchCardsFound fails the benchmark unless all the seeded cards are found.
+//
+func assertBenchCardsFound(b *testing.B, cards []*model.CardDTO, err error) {
+
+	if nil != err {
+		b.Fatalf("search error: %+v", err)
+	}
+	if benchIdentities*benchChainsPerIdentity*benchCardsPerChain != len(cards) {
+		b.Fatalf("unexpected cards count: %d", len(cards))
+	}
+}
+
+//
+// searchCardsByIdentitiesWithChainLookup is the former search of the chain card IDs
+// followed by the select of the cards by their IDs. It is kept as the benchmark baseline.
+//
+func searchCardsByIdentitiesWithChainLookup(
+	d *CardRepository,
+	identities []string,
+	scopeID string,
+) ([]*model.CardDTO, error) {
+
+	var (
+		// chains search
+		deletedAt    int
+		chainCardIDs []string
+		// cards content
+		identity        string
+		applicationID   string
+		chainID         string
+		contentSnapshot string
+		expiresAt       int64
+		signatures      SignatureList
+
+		cardIDs = make([]string, 0)
+		cards   = make([]*model.CardDTO, 0)
+	)
+
+	iterCardIDs := d.searchByIdentitiesQuery(qSearchCardIDsByIdentities, scopeID, identities).Iter()
+	for iterCardIDs.Scan(&deletedAt, &chainCardIDs) {
+		if 0 >= deletedAt {
+			cardIDs = append(cardIDs, chainCardIDs...)
+		}
+	}
+	if err := iterCardIDs.Close(); nil != err {
+		return nil, err
+	}
+
+	if 0 == len(cardIDs) {
+		return cards, nil
+	}
+
+	cardsIterator := d.session.Query(qSearchCardsByIDs, cardIDs).Iter()
+	for cardsIterator.Scan(&identity, &applicationID, &chainID, &contentSnapshot, &expiresAt, &signatures) {
+		cards = append(cards, &model.CardDTO{
+			Identity:        identity,
+			ApplicationID:   applicationID,
+			ChainID:         chainID,
+			ContentSnapshot: contentSnapshot,
+			ExpiresAt:       expiresAt,
+			Signatures:      wrapDBSignatureListToDTOs(signatures),
+		})
+	}
+	if err := cardsIterator.Close(); nil != err {
+		return nil, err
+	}
+
+	return cards, nil
+}
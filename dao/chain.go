@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"sort"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// orderChainCards orders the chain cards from the root card to the latest one following the previous card IDs.
+// Cards that are not reachable from the root card go the last ones in order of their creation.
+//
+func orderChainCards(cards []*model.CardDTO) []*model.CardDTO {
+
+	var (
+		roots       []*model.CardDTO
+		nextCards   = make(map[string]*model.CardDTO, len(cards))
+		ordered     = make([]*model.CardDTO, 0, len(cards))
+		orderedCard = make(map[string]struct{}, len(cards))
+	)
+
+	for _, card := range cards {
+		if "" == card.GetPreviousCardID() {
+			roots = append(roots, card)
+		} else {
+			nextCards[card.GetPreviousCardID()] = card
+		}
+	}
+	sortCardsByCreation(roots)
+
+	for _, card := range roots {
+		for ; nil != card; card = nextCards[card.GetID()] {
+			if _, ok := orderedCard[card.GetID()]; ok {
+				break
+			}
+			orderedCard[card.GetID()] = struct{}{}
+			ordered = append(ordered, card)
+		}
+	}
+
+	if len(ordered) == len(cards) {
+		return ordered
+	}
+
+	var unreachable []*model.CardDTO
+	for _, card := range cards {
+		if _, ok := orderedCard[card.GetID()]; !ok {
+			unreachable = append(unreachable, card)
+		}
+	}
+	sortCardsByCreation(unreachable)
+
+	return append(ordered, unreachable...)
+}
+
+//
+// resolveChainHead returns the latest card of the chain cards given with the chain info attached.
+// Returns nil if there is no cards.
+//
+func resolveChainHead(cards []*model.CardDTO) *model.CardDTO {
+
+	if 0 == len(cards) {
+		return nil
+	}
+
+	ordered := orderChainCards(cards)
+	head := ordered[len(ordered)-1]
+	head.Chain = &model.CardChainInfo{
+		ChainID:        head.GetChainID(),
+		PreviousCardID: head.GetPreviousCardID(),
+		Length:         len(ordered),
+	}
+
+	return head
+}
+
+//
+// sortCardsByCreation sorts cards by their creation time and IDs.
+//
+func sortCardsByCreation(cards []*model.CardDTO) {
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].CreatedAt != cards[j].CreatedAt {
+			return cards[i].CreatedAt < cards[j].CreatedAt
+		}
+
+		return cards[i].GetID() < cards[j].GetID()
+	})
+}
+
+//
+// sortChainStates orders the chains by the application ID and the creation time.
+//
+func sortChainStates(chains []*model.CardChainState) {
+
+	sort.SliceStable(chains, func(i, j int) bool {
+		if chains[i].ApplicationID != chains[j].ApplicationID {
+			return chains[i].ApplicationID < chains[j].ApplicationID
+		}
+
+		return chains[i].CreatedAt < chains[j].CreatedAt
+	})
+}
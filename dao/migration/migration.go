@@ -0,0 +1,141 @@
+package migration
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+)
+
+//
+// Migration is a versioned set of CQL statements changing the database schema.
+// The statements are expected to be idempotent, so a partially applied migration can be applied again.
+//
+type Migration struct {
+	Version     int
+	Description string
+	Statements  []string
+}
+
+//
+// Status describes the migration state. Zero AppliedAt means the migration is pending.
+//
+type Status struct {
+	Version     int
+	Description string
+	AppliedAt   int64
+}
+
+//
+// IsApplied returns true if the migration has been applied.
+//
+func (s *Status) IsApplied() bool {
+
+	return 0 != s.AppliedAt
+}
+
+//
+// StoreProvider applies the migrations and keeps the applied migration versions.
+//
+type StoreProvider interface {
+	//
+	// GetAppliedVersions returns the applied migration versions with their UTC Unix timestamps of applying.
+	// Returns an empty map if no migration has been applied yet.
+	//
+	GetAppliedVersions() (map[int]int64, error)
+
+	//
+	// Apply executes the migration statements and records the migration version as applied.
+	//
+	Apply(migration *Migration, appliedAt int64) error
+}
+
+//
+// Migrator applies the pending migrations in the order of their versions.
+//
+type Migrator struct {
+	store      StoreProvider
+	migrations []*Migration
+}
+
+//
+// NewMigrator returns a new instance of the migrator.
+// The migration versions must be positive and unique.
+//
+func NewMigrator(store StoreProvider, migrations []*Migration) (*Migrator, error) {
+
+	sorted := make([]*Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	for i, migration := range sorted {
+		if 0 >= migration.Version {
+			return nil, errors.New("migration version %d is not positive", migration.Version)
+		}
+		if 0 < i && sorted[i-1].Version == migration.Version {
+			return nil, errors.New("migration version %d is duplicated", migration.Version)
+		}
+		if 0 == len(migration.Statements) {
+			return nil, errors.New("migration version %d has no statements", migration.Version)
+		}
+	}
+
+	return &Migrator{
+		store:      store,
+		migrations: sorted,
+	}, nil
+}
+
+//
+// Status returns the states of all the migrations.
+//
+func (m *Migrator) Status() ([]*Status, error) {
+
+	applied, err := m.store.GetAppliedVersions()
+	if nil != err {
+		return nil, errors.WithMessage(err, "applied migration versions read error")
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, &Status{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   applied[migration.Version],
+		})
+	}
+
+	return statuses, nil
+}
+
+//
+// Up applies the pending migrations and returns them. The dry run returns the pending migrations without applying.
+//
+func (m *Migrator) Up(dryRun bool) ([]*Migration, error) {
+
+	applied, err := m.store.GetAppliedVersions()
+	if nil != err {
+		return nil, errors.WithMessage(err, "applied migration versions read error")
+	}
+
+	var pending []*Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	if dryRun {
+		return pending, nil
+	}
+
+	for i, migration := range pending {
+		if err = m.store.Apply(migration, time.Now().UTC().Unix()); nil != err {
+			return pending[:i], errors.WithMessage(err, "migration version %d apply error", migration.Version)
+		}
+	}
+
+	return pending, nil
+}
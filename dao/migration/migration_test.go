@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// Testing variables.
+//
+var (
+	testMigrations = []*Migration{
+		{Version: 2, Description: "second", Statements: []string{"CREATE TABLE second"}},
+		{Version: 1, Description: "first", Statements: []string{"CREATE TABLE first"}},
+	}
+)
+
+//
+// Up :: for pending migrations :: applies them in the version order.
+//
+func TestMigratorUpForPendingMigrations(t *testing.T) {
+
+	store := NewMemoryStore()
+	migrator, err := NewMigrator(store, testMigrations)
+	assert.NoError(t, err)
+
+	migrations, err := migrator.Up(false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Migration{testMigrations[1], testMigrations[0]}, migrations)
+
+	migrations, err = migrator.Up(false)
+
+	assert.NoError(t, err)
+	assert.Empty(t, migrations)
+}
+
+//
+// Up :: for a dry run :: returns the pending migrations without applying them.
+//
+func TestMigratorUpForADryRun(t *testing.T) {
+
+	store := NewMemoryStore()
+	assert.NoError(t, store.Apply(testMigrations[1], 1515686245))
+
+	migrator, err := NewMigrator(store, testMigrations)
+	assert.NoError(t, err)
+
+	migrations, err := migrator.Up(true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Migration{testMigrations[0]}, migrations)
+
+	statuses, err := migrator.Status()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Status{
+		{Version: 1, Description: "first", AppliedAt: 1515686245},
+		{Version: 2, Description: "second"},
+	}, statuses)
+	assert.False(t, statuses[1].IsApplied())
+}
+
+//
+// NewMigrator :: for duplicated migration versions :: returns an error.
+//
+func TestNewMigratorForDuplicatedVersions(t *testing.T) {
+
+	migrator, err := NewMigrator(NewMemoryStore(), append(testMigrations, &Migration{
+		Version:    1,
+		Statements: []string{"CREATE TABLE duplicate"},
+	}))
+
+	assert.Error(t, err)
+	assert.Nil(t, migrator)
+}
+
+//
+// Migrations :: for the Cards service schema :: are valid.
+//
+func TestMigrationsAreValid(t *testing.T) {
+
+	_, err := NewMigrator(NewMemoryStore(), Migrations)
+
+	assert.NoError(t, err)
+}
+
+//
+// Migrations :: for the baseline card tables :: add the later card columns to the existing tables.
+//
+func TestMigrationsAddTheLaterCardColumns(t *testing.T) {
+
+	for _, statement := range Migrations[0].Statements {
+		assert.NotContains(t, statement, "expires_at")
+		assert.NotContains(t, statement, "\tcard_id text")
+	}
+
+	var added []string
+	for _, migration := range Migrations[1:] {
+		for _, statement := range migration.Statements {
+			if strings.Contains(statement, "ALTER TABLE") {
+				added = append(added, strings.TrimSpace(statement))
+			}
+		}
+	}
+
+	assert.Equal(t, []string{
+		"ALTER TABLE card_by_card_id ADD expires_at bigint",
+		"ALTER TABLE card_by_identity ADD expires_at bigint",
+		"ALTER TABLE card_previous_ids ADD card_id text",
+	}, added)
+}
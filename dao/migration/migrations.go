@@ -0,0 +1,232 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+)
+
+//
+// CollectionSchemaMigrations is a table of the applied migration versions.
+//
+const CollectionSchemaMigrations = "schema_migrations"
+
+//
+// AdminTablesVersion is the migration version the admin tables are filled on the card save since.
+// The cards saved before the migration is applied are added to the admin tables by the backfill subcommand.
+//
+const AdminTablesVersion = 5
+
+//
+// cardColumnsDefinition is a definition of the baseline Virgil Card columns shared by the card tables.
+// The columns added later are added by their own migrations, so the existing tables get them too.
+//
+const cardColumnsDefinition = `
+		id text,
+		identity text,
+		public_key text,
+		content_snapshot text,
+		version text,
+		application_id text,
+		previous_card_id text,
+		signatures list<frozen<map<text, text>>>,
+		created_at_timestamp bigint,
+		chain_id text,`
+
+//
+// addColumnFormat is a definition of the column added to the table given.
+//
+const addColumnFormat = `
+	ALTER TABLE %s ADD %s`
+
+//
+// cardAuditTableFormat is a definition of the card audit table of the partition key given.
+//
+const cardAuditTableFormat = `
+	CREATE TABLE IF NOT EXISTS %s (
+		id text,
+		card_id text,
+		chain_id text,
+		identity text,
+		application_id text,
+		account_id text,
+		previous_card_id text,
+		action text,
+		operator_id text,
+		reason text,
+		created_at bigint,
+		PRIMARY KEY (%s, created_at, id)
+	)`
+
+//
+// Migrations is a list of the Cards service database schema migrations.
+// The keyspace is expected to exist, e.g. for a local single-node cluster:
+//
+//	CREATE KEYSPACE cards WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};
+//
+var Migrations = []*Migration{
+	{
+		Version:     1,
+		Description: "create the baseline card tables",
+		Statements: []string{
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (%s
+		PRIMARY KEY (id)
+	)`, dao.CollectionCardWithIDPrimary, cardColumnsDefinition),
+
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (%s
+		PRIMARY KEY ((identity, application_id), id)
+	)`, dao.CollectionCardWithIdentityPrimary, cardColumnsDefinition),
+
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		previous_card_id text,
+		application_id text,
+		PRIMARY KEY (previous_card_id, application_id)
+	)`, dao.CollectionCardPreviousIDs),
+
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		identity text,
+		application_id text,
+		chain_id text,
+		ids set<text>,
+		created_at_timestamp bigint,
+		deleted_at bigint,
+		PRIMARY KEY ((identity, application_id), chain_id)
+	)`, dao.CollectionCardChain),
+		},
+	},
+	{
+		Version:     2,
+		Description: "create the card chain revocation table",
+		Statements: []string{
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		identity text,
+		application_id text,
+		chain_id text,
+		card_id text,
+		delete_card_id text,
+		reason text,
+		note text,
+		revoked_at bigint,
+		content_snapshot text,
+		signature text,
+		key_id text,
+		PRIMARY KEY ((identity, application_id), chain_id)
+	)`, dao.CollectionCardChainRevocation),
+		},
+	},
+	{
+		Version:     3,
+		Description: "create the card audit tables",
+		Statements: []string{
+			fmt.Sprintf(cardAuditTableFormat, dao.CollectionCardAuditByIdentity, "(identity)"),
+			fmt.Sprintf(cardAuditTableFormat, dao.CollectionCardAuditByChain, "(chain_id)"),
+		},
+	},
+	{
+		Version:     4,
+		Description: "create the transparency log tables",
+		Statements: []string{
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		bucket bigint,
+		leaf_index bigint,
+		card_id text,
+		application_id text,
+		leaf_data blob,
+		leaf_hash blob,
+		PRIMARY KEY (bucket, leaf_index)
+	)`, dao.CollectionTransparencyLogLeaf),
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		card_id text,
+		leaf_index bigint,
+		PRIMARY KEY (card_id)
+	)`, dao.CollectionTransparencyLogCard),
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		id text,
+		size bigint,
+		PRIMARY KEY (id)
+	)`, dao.CollectionTransparencyLogSize),
+		},
+	},
+	{
+		Version:     5,
+		Description: "create the admin identity applications and application card counts tables",
+		Statements: []string{
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		identity text,
+		application_id text,
+		PRIMARY KEY (identity, application_id)
+	)`, dao.CollectionIdentityApplications),
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		application_id text,
+		cards counter,
+		PRIMARY KEY (application_id)
+	)`, dao.CollectionCardApplicationCounts),
+		},
+	},
+	{
+		Version:     6,
+		Description: "add the card expiration time columns",
+		Statements: []string{
+			fmt.Sprintf(addColumnFormat, dao.CollectionCardWithIDPrimary, "expires_at bigint"),
+			fmt.Sprintf(addColumnFormat, dao.CollectionCardWithIdentityPrimary, "expires_at bigint"),
+		},
+	},
+	{
+		Version:     7,
+		Description: "add the superseding card ID column of the previous card IDs",
+		Statements: []string{
+			fmt.Sprintf(addColumnFormat, dao.CollectionCardPreviousIDs, "card_id text"),
+		},
+	},
+	{
+		Version:     8,
+		Description: "create the application card counts backfill table",
+		Statements: []string{
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		application_id text,
+		cards bigint,
+		PRIMARY KEY (application_id)
+	)`, dao.CollectionCardApplicationBackfill),
+		},
+	},
+	{
+		Version:     9,
+		Description: "create the transparency log subtree hashes and pending entries tables",
+		Statements: []string{
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		level int,
+		node_index bigint,
+		node_hash blob,
+		PRIMARY KEY ((level, node_index))
+	)`, dao.CollectionTransparencyLogNode),
+			fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		card_id text,
+		application_id text,
+		leaf_data blob,
+		leaf_hash blob,
+		created_at bigint,
+		PRIMARY KEY (card_id)
+	)`, dao.CollectionTransparencyLogPending),
+		},
+	},
+	{
+		Version:     10,
+		Description: "add the leaf claim start index column of the transparency log cards",
+		Statements: []string{
+			fmt.Sprintf(addColumnFormat, dao.CollectionTransparencyLogCard, "claim_from bigint"),
+		},
+	},
+}
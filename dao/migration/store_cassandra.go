@@ -0,0 +1,124 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+)
+
+//
+// Schema migrations table queries.
+//
+// #nosec
+var (
+	qCreateSchemaMigrations = fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
+		version int,
+		description text,
+		applied_at bigint,
+		PRIMARY KEY (version)
+	)`, CollectionSchemaMigrations)
+
+	qGetAppliedVersions = fmt.Sprintf(`
+	SELECT
+		version,
+		applied_at
+	FROM %s
+	`, CollectionSchemaMigrations)
+
+	qInsertAppliedVersion = fmt.Sprintf(`
+	INSERT INTO %s (
+		version,
+		description,
+		applied_at
+	) VALUES (?, ?, ?)
+	`, CollectionSchemaMigrations)
+)
+
+//
+// CassandraStore applies the migrations to the Cassandra keyspace of the session
+// and keeps the applied migration versions in the schema migrations table.
+//
+type CassandraStore struct {
+	session *gocql.Session
+}
+
+//
+// NewCassandraStore returns a new instance of the Cassandra migration store.
+//
+func NewCassandraStore(connector cassandra.GoCQLSessionProvider) *CassandraStore {
+
+	return &CassandraStore{session: connector.GetGoCQLSession()}
+}
+
+//
+// GetAppliedVersions returns the applied migration versions with their UTC Unix timestamps of applying.
+// Returns an empty map if the schema migrations table does not exist yet.
+//
+func (s *CassandraStore) GetAppliedVersions() (map[int]int64, error) {
+
+	applied := make(map[int]int64)
+
+	var (
+		version   int
+		appliedAt int64
+	)
+
+	iter := s.session.Query(qGetAppliedVersions).Iter()
+	for iter.Scan(&version, &appliedAt) {
+		applied[version] = appliedAt
+	}
+
+	if err := iter.Close(); nil != err {
+		// the table is unconfigured until the first migration is applied.
+		if e, ok := err.(gocql.RequestError); ok && gocql.ErrCodeInvalid == e.Code() {
+			return map[int]int64{}, nil
+		}
+
+		return nil, errors.WithMessage(err, "schema migrations select error")
+	}
+
+	return applied, nil
+}
+
+//
+// Apply executes the migration statements and records the migration version as applied.
+//
+func (s *CassandraStore) Apply(migration *Migration, appliedAt int64) error {
+
+	if err := s.session.Query(qCreateSchemaMigrations).Exec(); nil != err {
+		return errors.WithMessage(err, "schema migrations table create error")
+	}
+
+	for _, statement := range migration.Statements {
+		if err := s.session.Query(statement).Exec(); nil != err && !isColumnAddedAlready(err) {
+			return errors.WithMessage(err, "migration version %d statement (%s) error", migration.Version, statement)
+		}
+	}
+
+	if err := s.session.Query(
+		qInsertAppliedVersion,
+		migration.Version,
+		migration.Description,
+		appliedAt,
+	).Exec(); nil != err {
+		return errors.WithMessage(err, "migration version %d record error", migration.Version)
+	}
+
+	return nil
+}
+
+//
+// isColumnAddedAlready returns true if the statement error is the added column conflicting with an existing one.
+// ALTER TABLE ... ADD has no IF NOT EXISTS clause, so the column conflict is ignored to keep the statement idempotent.
+//
+func isColumnAddedAlready(err error) bool {
+
+	e, ok := err.(gocql.RequestError)
+
+	return ok && gocql.ErrCodeInvalid == e.Code() && strings.Contains(e.Message(), "conflicts with an existing column")
+}
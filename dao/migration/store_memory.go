@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"sync"
+)
+
+//
+// MemoryStore keeps the applied migration versions in memory without executing the migration statements.
+// It serves the in-memory storage which has no schema.
+//
+type MemoryStore struct {
+	mutex   sync.RWMutex
+	applied map[int]int64
+}
+
+//
+// NewMemoryStore returns an empty instance of the MemoryStore.
+//
+func NewMemoryStore() *MemoryStore {
+
+	return &MemoryStore{
+		applied: make(map[int]int64),
+	}
+}
+
+//
+// GetAppliedVersions returns the applied migration versions with their UTC Unix timestamps of applying.
+//
+func (s *MemoryStore) GetAppliedVersions() (map[int]int64, error) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	applied := make(map[int]int64, len(s.applied))
+	for version, appliedAt := range s.applied {
+		applied[version] = appliedAt
+	}
+
+	return applied, nil
+}
+
+//
+// Apply records the migration version as applied.
+//
+func (s *MemoryStore) Apply(migration *Migration, appliedAt int64) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.applied[migration.Version] = appliedAt
+
+	return nil
+}
@@ -2,7 +2,6 @@ package dao
 
 import (
 	"fmt"
-	"strings"
 )
 
 //
@@ -13,6 +12,17 @@ const (
 	CollectionCardWithIdentityPrimary = "card_by_identity"
 	CollectionCardPreviousIDs         = "card_previous_ids"
 	CollectionCardChain               = "card_chain"
+	CollectionCardChainRevocation     = "card_chain_revocation"
+	CollectionIdentityApplications    = "card_identity_applications"
+	CollectionCardApplicationCounts   = "card_application_counts"
+	CollectionCardApplicationBackfill = "card_application_backfill_counts"
+	CollectionCardAuditByIdentity     = "card_audit_by_identity"
+	CollectionCardAuditByChain        = "card_audit_by_chain"
+	CollectionTransparencyLogLeaf     = "transparency_log_leaf"
+	CollectionTransparencyLogCard     = "transparency_log_card"
+	CollectionTransparencyLogSize     = "transparency_log_size"
+	CollectionTransparencyLogNode     = "transparency_log_node"
+	CollectionTransparencyLogPending  = "transparency_log_pending"
 
 	InsertFormatFullCardInfo = `
 	INSERT INTO %s (
@@ -25,12 +35,44 @@ const (
 		previous_card_id,
 		signatures,
 		created_at_timestamp,
-		chain_id
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		chain_id,
+		expires_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	InsertFormatCardAuditRecord = `
+	INSERT INTO %s (
+		id,
+		card_id,
+		chain_id,
+		identity,
+		application_id,
+		account_id,
+		previous_card_id,
+		action,
+		operator_id,
+		reason,
+		created_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	SelectFormatCardAuditRecords = `
+	SELECT
+		id,
+		card_id,
+		chain_id,
+		identity,
+		application_id,
+		account_id,
+		previous_card_id,
+		action,
+		operator_id,
+		reason,
+		created_at
+	FROM %s`
 )
 
 //
 // Database query statements.
+// All the values are bound to the statements, so the statements are prepared and cached by the session once.
 //
 // #nosec
 var (
@@ -49,6 +91,20 @@ var (
 	WHERE identity = ? AND application_id = ? AND chain_id = ?
 	`, CollectionCardChain)
 
+	// Select card IDs of the chain.
+	qGetChainCardIDs = fmt.Sprintf(`
+	SELECT ids
+	FROM %s
+	WHERE identity = ? AND application_id = ? AND chain_id = ?
+	`, CollectionCardChain)
+
+	// Select card IDs and deleted state of the identity chains.
+	qGetIdentityChainCardIDs = fmt.Sprintf(`
+	SELECT deleted_at, ids
+	FROM %s
+	WHERE identity = ? AND application_id = ?
+	`, CollectionCardChain)
+
 	// Mark chain as deleted.
 	qSetChainDeletedAt = fmt.Sprintf(`
 	UPDATE %s 
@@ -57,6 +113,284 @@ var (
 		IF deleted_at = 0 
 	`, CollectionCardChain)
 
+	// Clear the chain deleted_at mark if it has not been changed.
+	qClearChainDeletedAt = fmt.Sprintf(`
+	UPDATE %s
+		SET deleted_at = 0
+	WHERE identity = ? AND application_id = ? AND chain_id = ?
+		IF deleted_at = ?
+	`, CollectionCardChain)
+
+	// Delete the card from the identity primary table.
+	qDeleteCardInIdentityPKTable = fmt.Sprintf(`
+	DELETE FROM
+		%s
+	WHERE identity = ? AND application_id = ? AND id = ?
+	`, CollectionCardWithIdentityPrimary)
+
+	// Delete the previous card replacement.
+	qDeletePreviousCardID = fmt.Sprintf(`
+	DELETE FROM
+		%s
+	WHERE previous_card_id = ? AND application_id = ?
+	`, CollectionCardPreviousIDs)
+
+	// Delete the chain revocation record.
+	qDeleteChainRevocation = fmt.Sprintf(`
+	DELETE FROM
+		%s
+	WHERE identity = ? AND application_id = ? AND chain_id = ?
+	`, CollectionCardChainRevocation)
+
+	// Insert the card audit record into the identity partitioned table.
+	qInsertCardAuditRecordByIdentity = fmt.Sprintf(InsertFormatCardAuditRecord, CollectionCardAuditByIdentity)
+
+	// Insert the card audit record into the chain partitioned table.
+	qInsertCardAuditRecordByChain = fmt.Sprintf(InsertFormatCardAuditRecord, CollectionCardAuditByChain)
+
+	// Select the card audit records of the identity.
+	qGetCardAuditRecordsByIdentity = fmt.Sprintf(SelectFormatCardAuditRecords+`
+	WHERE identity = ?
+	`, CollectionCardAuditByIdentity)
+
+	// Select the card audit records of the chain.
+	qGetCardAuditRecordsByChain = fmt.Sprintf(SelectFormatCardAuditRecords+`
+	WHERE chain_id = ?
+	`, CollectionCardAuditByChain)
+
+	// Insert the transparency log leaf, the leaf index is taken by the first insert only.
+	qInsertTransparencyLogLeafIfNotExists = fmt.Sprintf(`
+	INSERT INTO %s (
+		bucket,
+		leaf_index,
+		card_id,
+		application_id,
+		leaf_data,
+		leaf_hash
+	) VALUES (?, ?, ?, ?, ?, ?)
+	IF NOT EXISTS
+	`, CollectionTransparencyLogLeaf)
+
+	// Select the transparency log leaf.
+	qGetTransparencyLogLeaf = fmt.Sprintf(`
+	SELECT
+		card_id,
+		application_id,
+		leaf_data,
+		leaf_hash
+	FROM %s
+	WHERE bucket = ? AND leaf_index = ?
+	`, CollectionTransparencyLogLeaf)
+
+	// Select the transparency log leaf hash.
+	qGetTransparencyLogLeafHash = fmt.Sprintf(`
+	SELECT leaf_hash
+	FROM %s
+	WHERE bucket = ? AND leaf_index = ?
+	`, CollectionTransparencyLogLeaf)
+
+	// Insert the transparency log complete subtree hash.
+	qInsertTransparencyLogNode = fmt.Sprintf(`
+	INSERT INTO %s (
+		level,
+		node_index,
+		node_hash
+	) VALUES (?, ?, ?)
+	`, CollectionTransparencyLogNode)
+
+	// Select the transparency log complete subtree hash.
+	qGetTransparencyLogNode = fmt.Sprintf(`
+	SELECT node_hash
+	FROM %s
+	WHERE level = ? AND node_index = ?
+	`, CollectionTransparencyLogNode)
+
+	// Insert the transparency log entry pending to be appended.
+	qInsertTransparencyLogPending = fmt.Sprintf(`
+	INSERT INTO %s (
+		card_id,
+		application_id,
+		leaf_data,
+		leaf_hash,
+		created_at
+	) VALUES (?, ?, ?, ?, ?)
+	`, CollectionTransparencyLogPending)
+
+	// Select all the transparency log entries pending to be appended.
+	qGetTransparencyLogPending = fmt.Sprintf(`
+	SELECT
+		card_id,
+		application_id,
+		leaf_data,
+		leaf_hash,
+		created_at
+	FROM %s
+	`, CollectionTransparencyLogPending)
+
+	// Delete the transparency log entry pending to be appended.
+	qDeleteTransparencyLogPending = fmt.Sprintf(`
+	DELETE FROM %s
+	WHERE card_id = ?
+	`, CollectionTransparencyLogPending)
+
+	// Reserve the card in the transparency log, the card is reserved by the first insert only.
+	// The leaf of the card is claimed from the index given.
+	qReserveTransparencyLogCard = fmt.Sprintf(`
+	INSERT INTO %s (
+		card_id,
+		leaf_index,
+		claim_from
+	) VALUES (?, ?, ?)
+	IF NOT EXISTS
+	`, CollectionTransparencyLogCard)
+
+	// Update the transparency log leaf index of the reserved card.
+	qUpdateTransparencyLogCardLeafIndex = fmt.Sprintf(`
+	UPDATE %s
+		SET leaf_index = ?
+	WHERE card_id = ?
+	IF EXISTS
+	`, CollectionTransparencyLogCard)
+
+	// Select the transparency log leaf index of the card.
+	qGetTransparencyLogCardLeafIndex = fmt.Sprintf(`
+	SELECT leaf_index
+	FROM %s
+	WHERE card_id = ?
+	`, CollectionTransparencyLogCard)
+
+	// Update the transparency log size hint.
+	qUpdateTransparencyLogSize = fmt.Sprintf(`
+	UPDATE %s
+		SET size = ?
+	WHERE id = ?
+	`, CollectionTransparencyLogSize)
+
+	// Select the transparency log size hint.
+	qGetTransparencyLogSize = fmt.Sprintf(`
+	SELECT size
+	FROM %s
+	WHERE id = ?
+	`, CollectionTransparencyLogSize)
+
+	// Select the chains of the identity in the application.
+	qGetIdentityChains = fmt.Sprintf(`
+	SELECT
+		chain_id,
+		ids,
+		created_at_timestamp,
+		deleted_at
+	FROM %s
+	WHERE identity = ? AND application_id = ?
+	`, CollectionCardChain)
+
+	// Insert the application the identity has the chains in.
+	qInsertCardIdentityApplication = fmt.Sprintf(`
+	INSERT INTO %s (
+		identity,
+		application_id
+	) VALUES (?, ?)
+	`, CollectionIdentityApplications)
+
+	// Select the applications the identity has the chains in.
+	qGetCardIdentityApplications = fmt.Sprintf(`
+	SELECT application_id
+	FROM %s
+	WHERE identity = ?
+	`, CollectionIdentityApplications)
+
+	// Count the card of the application.
+	qIncrementApplicationCardCount = fmt.Sprintf(`
+	UPDATE %s
+		SET cards = cards + 1
+	WHERE application_id = ?
+	`, CollectionCardApplicationCounts)
+
+	// Select the card counts of all the applications, a row per application.
+	qGetApplicationCardCounts = fmt.Sprintf(`
+	SELECT
+		application_id,
+		cards
+	FROM %s
+	`, CollectionCardApplicationCounts)
+
+	// Select the cards the admin tables are backfilled from, the table is paged through.
+	// The card is saved at the server write time of its content snapshot in microseconds, the card row is never updated.
+	qGetBackfillCards = fmt.Sprintf(`
+	SELECT
+		identity,
+		application_id,
+		previous_card_id,
+		public_key,
+		WRITETIME(content_snapshot)
+	FROM %s
+	`, CollectionCardWithIDPrimary)
+
+	// Insert the backfilled card count of the application.
+	qInsertApplicationBackfillCardCount = fmt.Sprintf(`
+	INSERT INTO %s (
+		application_id,
+		cards
+	) VALUES (?, ?)
+	`, CollectionCardApplicationBackfill)
+
+	// Select the backfilled card counts of all the applications, a row per application.
+	qGetApplicationBackfillCardCounts = fmt.Sprintf(`
+	SELECT
+		application_id,
+		cards
+	FROM %s
+	`, CollectionCardApplicationBackfill)
+
+	// Insert the chain revocation record.
+	qInsertChainRevocation = fmt.Sprintf(`
+	INSERT INTO %s (
+		identity,
+		application_id,
+		chain_id,
+		card_id,
+		delete_card_id,
+		reason,
+		note,
+		revoked_at,
+		content_snapshot,
+		signature,
+		key_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, CollectionCardChainRevocation)
+
+	// Select the chain revocation records of the identity.
+	qGetIdentityChainRevocations = fmt.Sprintf(`
+	SELECT
+		identity,
+		chain_id,
+		card_id,
+		delete_card_id,
+		reason,
+		note,
+		revoked_at,
+		content_snapshot,
+		signature,
+		key_id
+	FROM %s
+	WHERE identity = ? AND application_id = ?
+	`, CollectionCardChainRevocation)
+
+	// Select the chain revocation record.
+	qGetChainRevocation = fmt.Sprintf(`
+	SELECT
+		card_id,
+		delete_card_id,
+		reason,
+		note,
+		revoked_at,
+		content_snapshot,
+		signature,
+		key_id
+	FROM %s
+	WHERE identity = ? AND application_id = ? AND chain_id = ?
+	`, CollectionCardChainRevocation)
+
 	// Select card by its ID query.
 	qGetCardByID = fmt.Sprintf(`
 	SELECT
@@ -66,6 +400,9 @@ var (
 		identity,
 		application_id,
 		chain_id,
+		version,
+		created_at_timestamp,
+		expires_at,
 		signatures
 	FROM %s
 	WHERE id = ?
@@ -79,6 +416,14 @@ var (
 	WHERE previous_card_id = ? AND application_id = ? LIMIT 1
 	`, CollectionCardPreviousIDs)
 
+	// Get the ID of the card that replaced the previous card query.
+	qGetSupersedingCardID = fmt.Sprintf(`
+	SELECT
+		card_id
+	FROM %s
+	WHERE previous_card_id = ? AND application_id = ? LIMIT 1
+	`, CollectionCardPreviousIDs)
+
 	qCreateCardInIdentityPKTable = fmt.Sprintf(`
 	INSERT INTO %s (
 		id,
@@ -90,106 +435,147 @@ var (
 		previous_card_id,
 		signatures,
 		created_at_timestamp,
-		chain_id
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		chain_id,
+		expires_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, CollectionCardWithIdentityPrimary)
 
 	qCreateCardInCardPKTable = fmt.Sprintf(InsertFormatFullCardInfo, CollectionCardWithIDPrimary)
 
-	// Update card previous IDs query.
-	qUpdatePreviousCardIDs = fmt.Sprintf(`
+	// Insert card previous IDs query. The lightweight transaction lets only one card replace the previous card.
+	qInsertPreviousCardIDIfNotExists = fmt.Sprintf(`
 	INSERT INTO
 		%s
-	(previous_card_id, application_id)
-	VALUES (?, ?);
+	(previous_card_id, application_id, card_id)
+	VALUES (?, ?, ?)
+	IF NOT EXISTS
+	`, CollectionCardPreviousIDs)
+
+	// Delete card previous IDs query. Reverts the replacement of the previous card by the card not saved.
+	qDeletePreviousCardIDIfCardID = fmt.Sprintf(`
+	DELETE FROM
+		%s
+	WHERE previous_card_id = ? AND application_id = ?
+	IF card_id = ?
 	`, CollectionCardPreviousIDs)
 )
 
 //
-// getCreateChainIdentitiesQuery returns a create cards chain identities query.
+// Database query statements with the bound list values.
+// The lists are bound to the IN relations and to the set additions and removals as a whole.
 //
-func getCreateChainIdentitiesQuery(id string) string {
-
-	return fmt.Sprintf(`
+// #nosec
+var (
+	// Add the card ID to the new chain card IDs set.
+	qCreateChainIdentities = fmt.Sprintf(`
 	UPDATE
 		%s
 	SET
-		ids = ids + {'%s'},
+		ids = ids + ?,
 		created_at_timestamp = ?,
 		deleted_at = 0
 	WHERE
 		identity = ? AND application_id = ? AND chain_id = ?
-	`, CollectionCardChain,
-		strings.Replace(id, "'", "''", -1),
-	)
-}
+	`, CollectionCardChain)
 
-//
-// getUpdateChainIdentitiesQuery returns an update cards chain identities query.
-//
-func getUpdateChainIdentitiesQuery(id string) string {
+	// Clear the chain deleted_at mark and remove the card ID from the chain card IDs set.
+	qClearChainDeletedAtAndRemoveCardIDs = fmt.Sprintf(`
+	UPDATE %s
+		SET deleted_at = 0, ids = ids - ?
+	WHERE identity = ? AND application_id = ? AND chain_id = ?
+		IF deleted_at = ?
+	`, CollectionCardChain)
 
-	return fmt.Sprintf(`
+	// Add the card ID to the chain card IDs set.
+	qUpdateChainIdentities = fmt.Sprintf(`
 	UPDATE
 		%s
 	SET
-		ids = ids + {'%s'}
+		ids = ids + ?
 	WHERE
-		identity = ? AND application_id = ? AND chain_id = ? `,
-		CollectionCardChain,
-		strings.Replace(id, "'", "''", -1))
-}
-
-//
-// joinCqlEscapedStrings returns a result of joining strings with single quote character inside escaped
-// and elements are separated by given separator.
-//
-func joinCqlEscapedStrings(literals []string, separator string) string {
-	joined := ""
-	for i, stringElement := range literals {
-		if i > 0 {
-			joined += separator
-		}
-		joined += strings.Replace(stringElement, "'", "''", -1)
-	}
-	return joined
-}
-
-//
-// getSearchByCardsIDs returns a query to search cards of set of identities.
-//
-func getSearchByCardsIDs(ids []string) string {
+		identity = ? AND application_id = ? AND chain_id = ?
+	`, CollectionCardChain)
 
-	// Select cards for ID list
-	qSelectCardsByIDs := fmt.Sprintf(`
+	// Select cards with their chains for ID list.
+	qSearchCardsByIDs = fmt.Sprintf(`
 	SELECT
+		identity,
+		application_id,
+		chain_id,
 		content_snapshot,
+		expires_at,
 		signatures
 	FROM
 		%s
-	WHERE`,
-		CollectionCardWithIDPrimary)
+	WHERE
+		id IN ?
+	`, CollectionCardWithIDPrimary)
 
-	qSelectCardsByIDs += " id IN ('" + joinCqlEscapedStrings(ids, "', '") + "')"
-	return qSelectCardsByIDs
-}
+	// Select cards with their scope for ID list.
+	qGetCardsByIDs = fmt.Sprintf(`
+	SELECT
+		id,
+		content_snapshot,
+		application_id,
+		expires_at,
+		signatures
+	FROM
+		%s
+	WHERE
+		id IN ?
+	`, CollectionCardWithIDPrimary)
 
-//
-// getSearchCardIDsByMultipleIdentitiesQuery returns a query to search cards of set of identities.
-//
-func getSearchCardIDsByMultipleIdentitiesQuery(identities []string) string {
+	// Select chain cards with their chain links for ID list.
+	qGetChainCardsByIDs = fmt.Sprintf(`
+	SELECT
+		id,
+		content_snapshot,
+		previous_card_id,
+		created_at_timestamp,
+		expires_at,
+		signatures
+	FROM
+		%s
+	WHERE
+		id IN ?
+	`, CollectionCardWithIDPrimary)
 
-	// Select NOT deleted chains by identities list
-	qSelectCardIDsForSetOfIdentitiesAndAppID := fmt.Sprintf(`
+	// Select chains with their card IDs by identities list.
+	qSearchChainsByIdentities = fmt.Sprintf(`
 	SELECT
-		deleted_at, 
+		identity,
+		chain_id,
+		deleted_at,
 		ids
 	FROM
 		%s
-	WHERE`,
-		CollectionCardChain)
+	WHERE
+		application_id = ? AND identity IN ?
+	`, CollectionCardChain)
+
+	// Select chains with their deleted state by identities list.
+	qSearchChainStatesByIdentities = fmt.Sprintf(`
+	SELECT
+		identity,
+		chain_id,
+		deleted_at
+	FROM
+		%s
+	WHERE
+		application_id = ? AND identity IN ?
+	`, CollectionCardChain)
 
-	qSelectCardIDsForSetOfIdentitiesAndAppID += " application_id = ?"
-	qSelectCardIDsForSetOfIdentitiesAndAppID += " AND identity IN ('" + joinCqlEscapedStrings(identities, "', '") + "')"
-	return qSelectCardIDsForSetOfIdentitiesAndAppID
-}
+	// Select cards with their chains by identities list.
+	qSearchCardsByIdentities = fmt.Sprintf(`
+	SELECT
+		identity,
+		chain_id,
+		content_snapshot,
+		expires_at,
+		signatures
+	FROM
+		%s
+	WHERE
+		application_id = ? AND identity IN ?
+	`, CollectionCardWithIdentityPrimary)
+)
@@ -0,0 +1,119 @@
+package dao
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// Testing variables.
+//
+var (
+	// statements with the bound list values.
+	testBoundListStatements = []string{
+		qCreateChainIdentities,
+		qUpdateChainIdentities,
+		qClearChainDeletedAtAndRemoveCardIDs,
+		qSearchCardsByIDs,
+		qGetCardsByIDs,
+		qGetChainCardsByIDs,
+		qSearchChainsByIdentities,
+		qSearchChainStatesByIdentities,
+		qSearchCardIDsByIdentities,
+		qSearchCardsByIdentities,
+	}
+
+	// statements selecting the cards the expired ones are filtered of.
+	testExpiringCardStatements = []string{
+		qGetCardByID,
+		qGetCardsByIDs,
+		qGetChainCardsByIDs,
+		qSearchCardsByIDs,
+		qSearchCardsByIdentities,
+	}
+
+	// statements searching by the identities list.
+	testSearchByIdentitiesStatements = []string{
+		qSearchChainsByIdentities,
+		qSearchChainStatesByIdentities,
+		qSearchCardIDsByIdentities,
+		qSearchCardsByIdentities,
+	}
+
+	// identities trying to break out of the CQL string literal.
+	testHostileIdentities = []string{
+		"'",
+		"''",
+		"alice'); DROP TABLE card_chain; --",
+		"bob') OR identity IN ('alice",
+		"алиса",
+		"身份",
+		"\U0001F600",
+		"SELECT",
+		"ALLOW FILTERING",
+		"?",
+		"\x00",
+	}
+)
+
+//
+// Bound list statements :: for the searches and chain updates :: have no literals and a single bound list.
+//
+func TestBoundListStatementsHaveNoLiterals(t *testing.T) {
+
+	for _, statement := range testBoundListStatements {
+		assert.NotContains(t, statement, "'")
+		assert.Equal(t, 1, strings.Count(statement, "IN ?")+strings.Count(statement, "ids + ?")+
+			strings.Count(statement, "ids - ?"), statement)
+	}
+}
+
+//
+// Card select statements :: for the expired cards filtering :: select the card expiration time.
+//
+func TestExpiringCardStatementsSelectTheExpiresAt(t *testing.T) {
+
+	for _, statement := range testExpiringCardStatements {
+		assert.Contains(t, statement, "expires_at,", statement)
+	}
+}
+
+//
+// searchByIdentitiesQuery :: for any identity :: binds it to the statement as is and passes it to the database as is.
+//
+func FuzzBoundIdentities(f *testing.F) {
+
+	for _, identity := range testHostileIdentities {
+		f.Add(identity)
+	}
+
+	d := &CardRepository{session: new(gocql.Session)}
+	listType := gocql.CollectionType{
+		NativeType: gocql.NewNativeType(4, gocql.TypeList, ""),
+		Elem:       gocql.NewNativeType(4, gocql.TypeText, ""),
+	}
+
+	f.Fuzz(func(t *testing.T, identity string) {
+
+		for _, statement := range testSearchByIdentitiesStatements {
+			query := d.searchByIdentitiesQuery(statement, testApplicationID, []string{identity, testIdentity})
+
+			assert.Equal(t, statement, query.Statement())
+			values := query.Values()
+			if !assert.Len(t, values, 2) {
+				continue
+			}
+			assert.Equal(t, testApplicationID, values[0])
+
+			data, err := gocql.Marshal(listType, values[1])
+			assert.NoError(t, err)
+
+			var identities []string
+			assert.NoError(t, gocql.Unmarshal(listType, data, &identities))
+			assert.Equal(t, []string{identity, testIdentity}, identities)
+		}
+	})
+}
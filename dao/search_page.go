@@ -0,0 +1,212 @@
+package dao
+
+import (
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// Paginated search constants.
+//
+const (
+	// searchPageConcurrency is a maximum number of identities which cards are requested simultaneously.
+	searchPageConcurrency = 16
+)
+
+//
+// identityCardIDsFetcher returns the card IDs of not deleted chains of the identity in a stable order.
+//
+type identityCardIDsFetcher func(span tracer.Span, identity string) ([]string, error)
+
+//
+// identityChainRevocationsFetcher returns the revocation records of the deleted chains of the identity.
+//
+type identityChainRevocationsFetcher func(span tracer.Span, identity string) ([]*model.CardRevocation, error)
+
+//
+// searchPageCardIDs walks through the identities starting from the cursor position and collects
+// up to limit card IDs. Identities are fetched by windows of parallel requests, and the walk stops
+// as soon as the page is full, so neither the amount of requests nor the memory depend on the
+// total amount of the identities. Returns the cursor of the next page or nil if there is no more pages.
+//
+func searchPageCardIDs(
+	span tracer.Span,
+	identities []string,
+	cursor *model.CardSearchCursor,
+	limit int,
+	fetch identityCardIDsFetcher,
+) ([]string, *model.CardSearchCursor, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	identities = uniqueStrings(identities)
+
+	var (
+		identityOffset = cursor.IdentityOffset
+		cardOffset     = cursor.CardOffset
+		ids            = make([]string, 0, limit)
+	)
+
+	for identityOffset < len(identities) {
+		window := identities[identityOffset:]
+		if searchPageConcurrency < len(window) {
+			window = window[:searchPageConcurrency]
+		}
+
+		results, err := fetchIdentitiesCardIDs(span, window, fetch)
+		if nil != err {
+			return nil, nil, tracer.SetSpanErrorAndReturn(span, err)
+		}
+
+		for _, cardIDs := range results {
+			if cardOffset < len(cardIDs) {
+				cardIDs = cardIDs[cardOffset:]
+			} else {
+				cardIDs = nil
+			}
+
+			if room := limit - len(ids); room < len(cardIDs) {
+				ids = append(ids, cardIDs[:room]...)
+
+				return ids, &model.CardSearchCursor{
+					IdentityOffset: identityOffset,
+					CardOffset:     cardOffset + room,
+				}, nil
+			}
+
+			ids = append(ids, cardIDs...)
+			identityOffset++
+			cardOffset = 0
+
+			if limit == len(ids) {
+				if identityOffset < len(identities) {
+					return ids, &model.CardSearchCursor{IdentityOffset: identityOffset}, nil
+				}
+
+				return ids, nil, nil
+			}
+		}
+	}
+
+	return ids, nil, nil
+}
+
+//
+// fetchIdentitiesCardIDs fetches the card IDs of every identity given in parallel.
+// Results are returned in order of the identities.
+//
+func fetchIdentitiesCardIDs(
+	span tracer.Span,
+	identities []string,
+	fetch identityCardIDsFetcher,
+) ([][]string, error) {
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]string, len(identities))
+		errs    = make([]error, len(identities))
+	)
+
+	for i, identity := range identities {
+		wg.Add(1)
+		go func(i int, identity string) {
+			defer wg.Done()
+			results[i], errs[i] = fetch(span, identity)
+		}(i, identity)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if nil != err {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+//
+// fetchIdentitiesChainRevocations fetches the chain revocations of every identity given once.
+// Identities are fetched by windows of parallel requests, so the amount of the simultaneous requests
+// does not depend on the amount of the identities. Revocations are returned in order of the identities.
+//
+func fetchIdentitiesChainRevocations(
+	span tracer.Span,
+	identities []string,
+	fetch identityChainRevocationsFetcher,
+) ([]*model.CardRevocation, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	identities = uniqueStrings(identities)
+	revocations := make([]*model.CardRevocation, 0)
+
+	for offset := 0; offset < len(identities); offset += searchPageConcurrency {
+		window := identities[offset:]
+		if searchPageConcurrency < len(window) {
+			window = window[:searchPageConcurrency]
+		}
+
+		var (
+			wg      sync.WaitGroup
+			results = make([][]*model.CardRevocation, len(window))
+			errs    = make([]error, len(window))
+		)
+
+		for i, identity := range window {
+			wg.Add(1)
+			go func(i int, identity string) {
+				defer wg.Done()
+				results[i], errs[i] = fetch(span, identity)
+			}(i, identity)
+		}
+		wg.Wait()
+
+		for i, err := range errs {
+			if nil != err {
+				return nil, tracer.SetSpanErrorAndReturn(span, err)
+			}
+			revocations = append(revocations, results[i]...)
+		}
+	}
+
+	return revocations, nil
+}
+
+//
+// uniqueStrings returns the list without duplicates keeping the order of the first occurrences.
+//
+func uniqueStrings(list []string) []string {
+
+	var (
+		unique = make([]string, 0, len(list))
+		seen   = make(map[string]struct{}, len(list))
+	)
+
+	for _, value := range list {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		unique = append(unique, value)
+	}
+
+	return unique
+}
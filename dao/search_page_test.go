@@ -0,0 +1,141 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// searchPageCardIDs :: for pages of different sizes :: returns every card ID once in order.
+//
+func TestSearchPageCardIDs(t *testing.T) {
+
+	var (
+		identities  []string
+		expectedIDs []string
+		identityIDs = make(map[string][]string)
+	)
+	for i := 0; i < 2*searchPageConcurrency+1; i++ {
+		identity := fmt.Sprintf("identity%d", i)
+		identities = append(identities, identity)
+		for j := 0; j < i%4; j++ {
+			id := fmt.Sprintf("%s-card%d", identity, j)
+			identityIDs[identity] = append(identityIDs[identity], id)
+			expectedIDs = append(expectedIDs, id)
+		}
+	}
+	fetch := func(span tracer.Span, identity string) ([]string, error) {
+		return identityIDs[identity], nil
+	}
+
+	for _, limit := range []int{1, 2, 3, 5, len(expectedIDs), len(expectedIDs) + 1} {
+		var (
+			ids    []string
+			cursor = &model.CardSearchCursor{}
+		)
+		for nil != cursor {
+			pageIDs, next, err := searchPageCardIDs(mock.StartNoopSpan(), identities, cursor, limit, fetch)
+
+			assert.NoError(t, err)
+			assert.True(t, len(pageIDs) <= limit)
+
+			ids = append(ids, pageIDs...)
+			cursor = next
+		}
+
+		assert.Equal(t, expectedIDs, ids, "limit %d", limit)
+	}
+}
+
+//
+// searchPageCardIDs :: for duplicated identities :: returns cards of the identity once.
+//
+func TestSearchPageCardIDsForDuplicatedIdentities(t *testing.T) {
+
+	ids, next, err := searchPageCardIDs(
+		mock.StartNoopSpan(),
+		[]string{testIdentity, testIdentity},
+		&model.CardSearchCursor{},
+		10,
+		func(span tracer.Span, identity string) ([]string, error) {
+			return []string{"card1"}, nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, []string{"card1"}, ids)
+}
+
+//
+// searchPageCardIDs :: for a failed identity request :: returns an error.
+//
+func TestSearchPageCardIDsForAFailedRequest(t *testing.T) {
+
+	errFetch := errors.New("fetch error")
+
+	_, _, err := searchPageCardIDs(
+		mock.StartNoopSpan(),
+		[]string{testIdentity},
+		&model.CardSearchCursor{},
+		10,
+		func(span tracer.Span, identity string) ([]string, error) {
+			return nil, errFetch
+		},
+	)
+
+	assert.Equal(t, errFetch, err)
+}
+
+//
+// fetchIdentitiesChainRevocations :: for many duplicated identities :: returns the revocations of every identity once.
+//
+func TestFetchIdentitiesChainRevocations(t *testing.T) {
+
+	var (
+		identities []string
+		expected   []*model.CardRevocation
+	)
+	for i := 0; i < 2*searchPageConcurrency+1; i++ {
+		identity := fmt.Sprintf("identity%d", i)
+		identities = append(identities, identity, identity)
+		expected = append(expected, &model.CardRevocation{Identity: identity})
+	}
+
+	revocations, err := fetchIdentitiesChainRevocations(
+		mock.StartNoopSpan(),
+		identities,
+		func(span tracer.Span, identity string) ([]*model.CardRevocation, error) {
+			return []*model.CardRevocation{{Identity: identity}}, nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, revocations)
+}
+
+//
+// fetchIdentitiesChainRevocations :: for a failed identity request :: returns an error.
+//
+func TestFetchIdentitiesChainRevocationsForAFailedRequest(t *testing.T) {
+
+	errFetch := errors.New("fetch error")
+
+	_, err := fetchIdentitiesChainRevocations(
+		mock.StartNoopSpan(),
+		[]string{testIdentity},
+		func(span tracer.Span, identity string) ([]*model.CardRevocation, error) {
+			return nil, errFetch
+		},
+	)
+
+	assert.Equal(t, errFetch, err)
+}
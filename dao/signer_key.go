@@ -0,0 +1,120 @@
+package dao
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+)
+
+//
+// SignerKeyRepositoryProvider provides an interface to work with the trusted CSR stamp signer public keys.
+//
+type SignerKeyRepositoryProvider interface {
+	//
+	// GetSignerPublicKey returns the public key of the signer registered for the application.
+	// Returns an empty key if the signer is not registered.
+	//
+	GetSignerPublicKey(span tracer.Span, applicationID, signer string) ([]byte, error)
+}
+
+//
+// memorySignerKey is a key of the signer public key entry.
+//
+type memorySignerKey struct {
+	applicationID string
+	signer        string
+}
+
+//
+// MemorySignerKeyRepository is an in-memory registry of the trusted CSR stamp signer public keys.
+//
+type MemorySignerKeyRepository struct {
+	mutex sync.RWMutex
+	keys  map[memorySignerKey][]byte
+}
+
+//
+// NewMemorySignerKeyRepository returns an empty instance of the MemorySignerKeyRepository.
+//
+func NewMemorySignerKeyRepository() *MemorySignerKeyRepository {
+
+	return &MemorySignerKeyRepository{
+		keys: make(map[memorySignerKey][]byte),
+	}
+}
+
+//
+// NewFileSignerKeyRepository returns an instance of the MemorySignerKeyRepository filled from the JSON file.
+// The file maps application IDs to their signers and base64 encoded public keys:
+//
+//	{"<application ID>": {"app": "<public key>", "<custom signer>": "<public key>"}}
+//
+func NewFileSignerKeyRepository(path string) (*MemorySignerKeyRepository, error) {
+
+	content, err := ioutil.ReadFile(path)
+	if nil != err {
+		return nil, errors.WithMessage(err, `signer keys file (%s) read error`, path)
+	}
+
+	var applications map[string]map[string]string
+	if err = json.Unmarshal(content, &applications); nil != err {
+		return nil, errors.WithMessage(err, `signer keys file (%s) unmarshal error`, path)
+	}
+
+	repository := NewMemorySignerKeyRepository()
+	for applicationID, signers := range applications {
+		for signer, encodedKey := range signers {
+			publicKey, err := base64.StdEncoding.DecodeString(encodedKey)
+			if nil != err {
+				return nil, errors.WithMessage(
+					err,
+					`public key of signer (%s) of application (%s) decode error`,
+					signer,
+					applicationID,
+				)
+			}
+			repository.SetSignerPublicKey(applicationID, signer, publicKey)
+		}
+	}
+
+	return repository, nil
+}
+
+//
+// SetSignerPublicKey registers the signer public key for the application.
+//
+func (d *MemorySignerKeyRepository) SetSignerPublicKey(applicationID, signer string, publicKey []byte) {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.keys[memorySignerKey{applicationID: applicationID, signer: signer}] = publicKey
+}
+
+//
+// GetSignerPublicKey returns the public key of the signer registered for the application.
+// Returns an empty key if the signer is not registered.
+//
+func (d *MemorySignerKeyRepository) GetSignerPublicKey(
+	span tracer.Span,
+	applicationID, signer string,
+) ([]byte, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.keys[memorySignerKey{applicationID: applicationID, signer: signer}], nil
+}
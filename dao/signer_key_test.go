@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// NewFileSignerKeyRepository :: for a valid keys file :: registers the signer keys per application.
+//
+func TestNewFileSignerKeyRepositoryForAValidFile(t *testing.T) {
+
+	path := writeDAOTestFile(t, `{"application": {"app": "cHVibGljIGtleQ=="}}`)
+	defer os.Remove(path)
+
+	repository, err := NewFileSignerKeyRepository(path)
+
+	assert.NoError(t, err)
+
+	publicKey, err := repository.GetSignerPublicKey(mock.StartNoopSpan(), testApplicationID, "app")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("public key"), publicKey)
+
+	publicKey, err = repository.GetSignerPublicKey(mock.StartNoopSpan(), "another application", "app")
+
+	assert.NoError(t, err)
+	assert.Empty(t, publicKey)
+}
+
+//
+// NewFileSignerKeyRepository :: for a not base64 encoded key :: returns an error.
+//
+func TestNewFileSignerKeyRepositoryForANotEncodedKey(t *testing.T) {
+
+	path := writeDAOTestFile(t, `{"application": {"app": "!@#$"}}`)
+	defer os.Remove(path)
+
+	repository, err := NewFileSignerKeyRepository(path)
+
+	assert.Error(t, err)
+	assert.Nil(t, repository)
+}
+
+//
+// writeDAOTestFile writes the test file and returns its path.
+//
+func writeDAOTestFile(t *testing.T, content string) string {
+
+	file, err := ioutil.TempFile("", "cards_dao")
+	assert.NoError(t, err)
+
+	_, err = file.WriteString(content)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	return file.Name()
+}
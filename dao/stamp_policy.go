@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// StampPolicyRepositoryProvider provides an interface to work with the application CSR stamp policies.
+//
+type StampPolicyRepositoryProvider interface {
+	//
+	// GetStampPolicy returns the CSR stamp policy of the application.
+	// Returns nil if the application has no policy.
+	//
+	GetStampPolicy(span tracer.Span, applicationID string) (*model.StampPolicy, error)
+}
+
+//
+// MemoryStampPolicyRepository is an in-memory registry of the application CSR stamp policies.
+//
+type MemoryStampPolicyRepository struct {
+	mutex    sync.RWMutex
+	policies map[string]*model.StampPolicy
+}
+
+//
+// NewMemoryStampPolicyRepository returns an empty instance of the MemoryStampPolicyRepository.
+//
+func NewMemoryStampPolicyRepository() *MemoryStampPolicyRepository {
+
+	return &MemoryStampPolicyRepository{
+		policies: make(map[string]*model.StampPolicy),
+	}
+}
+
+//
+// NewFileStampPolicyRepository returns an instance of the MemoryStampPolicyRepository filled from the JSON file.
+// The file maps application IDs to their policies:
+//
+//	{"<application ID>": {"required_signers": ["self", "app"], "allowed_signers": [], "max_stamps": 2}}
+//
+func NewFileStampPolicyRepository(path string) (*MemoryStampPolicyRepository, error) {
+
+	content, err := ioutil.ReadFile(path)
+	if nil != err {
+		return nil, errors.WithMessage(err, `stamp policies file (%s) read error`, path)
+	}
+
+	var policies map[string]*model.StampPolicy
+	if err = json.Unmarshal(content, &policies); nil != err {
+		return nil, errors.WithMessage(err, `stamp policies file (%s) unmarshal error`, path)
+	}
+
+	repository := NewMemoryStampPolicyRepository()
+	for applicationID, policy := range policies {
+		if nil == policy {
+			continue
+		}
+		if err = policy.Validate(); nil != err {
+			return nil, errors.WithMessage(err, `stamp policy of application (%s) is incorrect`, applicationID)
+		}
+		repository.SetStampPolicy(applicationID, policy)
+	}
+
+	return repository, nil
+}
+
+//
+// SetStampPolicy sets the CSR stamp policy of the application.
+//
+func (d *MemoryStampPolicyRepository) SetStampPolicy(applicationID string, policy *model.StampPolicy) {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.policies[applicationID] = policy
+}
+
+//
+// GetStampPolicy returns the CSR stamp policy of the application.
+// Returns nil if the application has no policy.
+//
+func (d *MemoryStampPolicyRepository) GetStampPolicy(span tracer.Span, applicationID string) (*model.StampPolicy, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.policies[applicationID], nil
+}
@@ -0,0 +1,51 @@
+package dao
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// NewFileStampPolicyRepository :: for a valid policies file :: registers the policies per application.
+//
+func TestNewFileStampPolicyRepositoryForAValidFile(t *testing.T) {
+
+	path := writeDAOTestFile(t, `{"application": {"required_signers": ["self", "app"], "max_stamps": 2}}`)
+	defer os.Remove(path)
+
+	repository, err := NewFileStampPolicyRepository(path)
+
+	assert.NoError(t, err)
+
+	policy, err := repository.GetStampPolicy(mock.StartNoopSpan(), testApplicationID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.StampPolicy{
+		RequiredSigners: []string{model.SelfSignatureType, model.ApplicationSignatureType},
+		MaxStamps:       2,
+	}, policy)
+
+	policy, err = repository.GetStampPolicy(mock.StartNoopSpan(), "another application")
+
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+//
+// NewFileStampPolicyRepository :: for an inconsistent policy :: returns an error.
+//
+func TestNewFileStampPolicyRepositoryForAnInconsistentPolicy(t *testing.T) {
+
+	path := writeDAOTestFile(t, `{"application": {"min_stamps": 3, "max_stamps": 2}}`)
+	defer os.Remove(path)
+
+	repository, err := NewFileStampPolicyRepository(path)
+
+	assert.Error(t, err)
+	assert.Nil(t, repository)
+}
@@ -0,0 +1,431 @@
+package dao
+
+import (
+	"github.com/gocql/gocql"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// Transparency log storage constants.
+// The leaves are partitioned into the buckets of the consecutive leaf indexes, so no partition grows unbounded.
+//
+const (
+	transparencyLogBucketSize = 10000
+	transparencyLogSizeID     = "leaves"
+
+	// transparencyLogLeafIndexReserved is the leaf index of the card reserved which leaf is not claimed yet.
+	transparencyLogLeafIndexReserved = -1
+)
+
+//
+// TransparencyLogRepositoryProvider is an interface to operate over the append-only transparency log leaves.
+//
+type TransparencyLogRepositoryProvider interface {
+	//
+	// AppendTransparencyLogEntry appends the leaf to the end of the log. The leaf index is set on append.
+	// The card appended again is not appended twice, its leaf index is set only.
+	//
+	AppendTransparencyLogEntry(span tracer.Span, entry *model.TransparencyLogEntry) error
+
+	//
+	// GetTransparencyLogEntryByCardID returns the leaf of the card.
+	// The method returns an error if the card is not in the log.
+	//
+	GetTransparencyLogEntryByCardID(span tracer.Span, cardID string) (*model.TransparencyLogEntry, error)
+
+	//
+	// GetTransparencyLogSize returns the amount of the leaves appended.
+	//
+	GetTransparencyLogSize(span tracer.Span) (int64, error)
+
+	//
+	// GetTransparencyLogNodeHash returns the hash of the complete subtree of 2^level leaves
+	// starting from the leaf index*2^level. The zero level subtree hash is the leaf hash.
+	// The method returns an error if the hash is not stored.
+	//
+	GetTransparencyLogNodeHash(span tracer.Span, level uint, index int64) ([]byte, error)
+
+	//
+	// SaveTransparencyLogNodeHash stores the hash of the complete subtree of the level and index given.
+	//
+	SaveTransparencyLogNodeHash(span tracer.Span, level uint, index int64, hash []byte) error
+
+	//
+	// SavePendingTransparencyLogEntry keeps the entry pending to be appended.
+	//
+	SavePendingTransparencyLogEntry(span tracer.Span, entry *model.TransparencyLogEntry) error
+
+	//
+	// GetPendingTransparencyLogEntries returns the entries pending to be appended.
+	//
+	GetPendingTransparencyLogEntries(span tracer.Span) ([]*model.TransparencyLogEntry, error)
+
+	//
+	// DeletePendingTransparencyLogEntry deletes the pending entry of the card.
+	//
+	DeletePendingTransparencyLogEntry(span tracer.Span, cardID string) error
+}
+
+//
+// TransparencyLogRepository keeps the transparency log leaves in the Cassandra tables.
+//
+type TransparencyLogRepository struct {
+	session *gocql.Session
+}
+
+//
+// NewTransparencyLogRepository returns an instance of the TransparencyLogRepository.
+//
+func NewTransparencyLogRepository(connector cassandra.GoCQLSessionProvider) *TransparencyLogRepository {
+	return &TransparencyLogRepository{session: connector.GetGoCQLSession()}
+}
+
+//
+// AppendTransparencyLogEntry appends the leaf to the end of the log. The leaf index is set on append.
+// The card is reserved first with the index the leaf is claimed from, then the leaf takes the first free index
+// with a lightweight transaction, so the concurrent appends never share an index and never leave a gap.
+// The card appended again takes its leaf claimed already, so the failed append is retried without a duplicate leaf.
+//
+func (d *TransparencyLogRepository) AppendTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var index int64
+	if err := d.session.Query(qGetTransparencyLogSize, transparencyLogSizeID).Scan(&index); nil != err &&
+		err != gocql.ErrNotFound {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(err, "error getting transparency log size"))
+	}
+
+	reservation := make(map[string]interface{})
+	applied, err := d.session.Query(
+		qReserveTransparencyLogCard,
+		entry.CardID,
+		transparencyLogLeafIndexReserved,
+		index,
+	).MapScanCAS(reservation)
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error reserving card's(%s) transparency log leaf", entry.CardID,
+		))
+	}
+	if !applied {
+		if leafIndex, _ := reservation["leaf_index"].(int64); transparencyLogLeafIndexReserved != leafIndex {
+			entry.LeafIndex = leafIndex
+
+			return nil
+		}
+
+		// the leaf claimed by the failed append is at the index it has been claimed from or after it.
+		index, _ = reservation["claim_from"].(int64)
+	}
+
+	for ; ; index++ {
+		leaf := make(map[string]interface{})
+		applied, err := d.session.Query(
+			qInsertTransparencyLogLeafIfNotExists,
+			index/transparencyLogBucketSize,
+			index,
+			entry.CardID,
+			entry.ApplicationID,
+			entry.LeafData,
+			entry.LeafHash,
+		).MapScanCAS(leaf)
+		if nil != err {
+			return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"error inserting card's(%s) transparency log leaf (%d)", entry.CardID, index,
+			))
+		}
+
+		if applied || entry.CardID == leaf["card_id"] {
+			break
+		}
+	}
+	entry.LeafIndex = index
+
+	if _, err := d.session.Query(
+		qUpdateTransparencyLogCardLeafIndex,
+		index,
+		entry.CardID,
+	).MapScanCAS(make(map[string]interface{})); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error updating card's(%s) transparency log leaf index (%d)", entry.CardID, index,
+		))
+	}
+
+	// The hint only shortens the search of the free index, a stale value is corrected by the next append.
+	if err := d.session.Query(qUpdateTransparencyLogSize, index+1, transparencyLogSizeID).Exec(); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error updating transparency log size (%d)", index+1,
+		))
+	}
+
+	return nil
+}
+
+//
+// GetTransparencyLogEntryByCardID returns the leaf of the card.
+// The method returns an error if the card is not in the log.
+//
+func (d *TransparencyLogRepository) GetTransparencyLogEntryByCardID(
+	span tracer.Span,
+	cardID string,
+) (*model.TransparencyLogEntry, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	entry := &model.TransparencyLogEntry{}
+	if err := d.session.Query(qGetTransparencyLogCardLeafIndex, cardID).Scan(&entry.LeafIndex); nil != err {
+		if err == gocql.ErrNotFound {
+			return nil, cassandra.ErrEntityNotFound
+		}
+
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting card's(%s) transparency log leaf index", cardID,
+		))
+	}
+
+	// the card reserved by the failed append is not in the log until its leaf index is set.
+	if transparencyLogLeafIndexReserved == entry.LeafIndex {
+		return nil, cassandra.ErrEntityNotFound
+	}
+
+	if err := d.session.Query(
+		qGetTransparencyLogLeaf,
+		entry.LeafIndex/transparencyLogBucketSize,
+		entry.LeafIndex,
+	).Scan(&entry.CardID, &entry.ApplicationID, &entry.LeafData, &entry.LeafHash); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting card's(%s) transparency log leaf (%d)", cardID, entry.LeafIndex,
+		))
+	}
+
+	return entry, nil
+}
+
+//
+// GetTransparencyLogSize returns the amount of the leaves appended.
+// The size hint may fall behind the leaves appended concurrently, so the leaves following it are counted.
+//
+func (d *TransparencyLogRepository) GetTransparencyLogSize(span tracer.Span) (int64, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	var size int64
+	if err := d.session.Query(qGetTransparencyLogSize, transparencyLogSizeID).Scan(&size); nil != err &&
+		err != gocql.ErrNotFound {
+		return 0, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(err, "error getting transparency log size"))
+	}
+
+	// the leaf indexes are taken one after another, so the first missing leaf is the size.
+	for ; ; size++ {
+		var leafHash []byte
+		err := d.session.Query(qGetTransparencyLogLeafHash, size/transparencyLogBucketSize, size).Scan(&leafHash)
+		if err == gocql.ErrNotFound {
+			return size, nil
+		}
+		if nil != err {
+			return 0, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+				err,
+				"error getting transparency log leaf (%d) hash", size,
+			))
+		}
+	}
+}
+
+//
+// GetTransparencyLogNodeHash returns the hash of the complete subtree of 2^level leaves
+// starting from the leaf index*2^level. The zero level subtree hash is the leaf hash.
+// The method returns an error if the hash is not stored.
+//
+func (d *TransparencyLogRepository) GetTransparencyLogNodeHash(
+	span tracer.Span,
+	level uint,
+	index int64,
+) ([]byte, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	query := d.session.Query(qGetTransparencyLogNode, int(level), index)
+	if 0 == level {
+		query = d.session.Query(qGetTransparencyLogLeafHash, index/transparencyLogBucketSize, index)
+	}
+
+	var hash []byte
+	if err := query.Scan(&hash); nil != err {
+		if err == gocql.ErrNotFound {
+			return nil, cassandra.ErrEntityNotFound
+		}
+
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting transparency log node (%d, %d) hash", level, index,
+		))
+	}
+
+	return hash, nil
+}
+
+//
+// SaveTransparencyLogNodeHash stores the hash of the complete subtree of the level and index given.
+// The complete subtree never changes, so the hash stored again is the same.
+//
+func (d *TransparencyLogRepository) SaveTransparencyLogNodeHash(
+	span tracer.Span,
+	level uint,
+	index int64,
+	hash []byte,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := d.session.Query(qInsertTransparencyLogNode, int(level), index, hash).Exec(); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error inserting transparency log node (%d, %d) hash", level, index,
+		))
+	}
+
+	return nil
+}
+
+//
+// SavePendingTransparencyLogEntry keeps the entry pending to be appended.
+//
+func (d *TransparencyLogRepository) SavePendingTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := d.session.Query(
+		qInsertTransparencyLogPending,
+		entry.CardID,
+		entry.ApplicationID,
+		entry.LeafData,
+		entry.LeafHash,
+		entry.CreatedAt,
+	).Exec(); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error inserting card's(%s) pending transparency log entry", entry.CardID,
+		))
+	}
+
+	return nil
+}
+
+//
+// GetPendingTransparencyLogEntries returns the entries pending to be appended.
+// The entries are deleted once appended, so the table is read through as a whole.
+//
+func (d *TransparencyLogRepository) GetPendingTransparencyLogEntries(
+	span tracer.Span,
+) ([]*model.TransparencyLogEntry, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	entries := make([]*model.TransparencyLogEntry, 0)
+
+	entry := new(model.TransparencyLogEntry)
+	iter := d.session.Query(qGetTransparencyLogPending).Iter()
+	for iter.Scan(&entry.CardID, &entry.ApplicationID, &entry.LeafData, &entry.LeafHash, &entry.CreatedAt) {
+		entries = append(entries, entry)
+		entry = new(model.TransparencyLogEntry)
+	}
+	if err := iter.Close(); nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error getting pending transparency log entries",
+		))
+	}
+
+	return entries, nil
+}
+
+//
+// DeletePendingTransparencyLogEntry deletes the pending entry of the card.
+//
+func (d *TransparencyLogRepository) DeletePendingTransparencyLogEntry(span tracer.Span, cardID string) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	if err := d.session.Query(qDeleteTransparencyLogPending, cardID).Exec(); nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"error deleting card's(%s) pending transparency log entry", cardID,
+		))
+	}
+
+	return nil
+}
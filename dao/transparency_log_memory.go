@@ -0,0 +1,273 @@
+package dao
+
+import (
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// MemoryTransparencyLogRepository keeps the transparency log leaves in memory.
+// It is intended for the memory cards storage.
+//
+type MemoryTransparencyLogRepository struct {
+	mutex   sync.RWMutex
+	entries []*model.TransparencyLogEntry
+	cards   map[string]int64
+	nodes   map[memoryTransparencyLogNode][]byte
+	pending map[string]*model.TransparencyLogEntry
+}
+
+//
+// memoryTransparencyLogNode is the key of the complete subtree hash.
+//
+type memoryTransparencyLogNode struct {
+	level uint
+	index int64
+}
+
+//
+// NewMemoryTransparencyLogRepository returns an empty instance of the MemoryTransparencyLogRepository.
+//
+func NewMemoryTransparencyLogRepository() *MemoryTransparencyLogRepository {
+
+	return &MemoryTransparencyLogRepository{
+		entries: make([]*model.TransparencyLogEntry, 0),
+		cards:   make(map[string]int64),
+		nodes:   make(map[memoryTransparencyLogNode][]byte),
+		pending: make(map[string]*model.TransparencyLogEntry),
+	}
+}
+
+//
+// AppendTransparencyLogEntry appends the leaf to the end of the log. The leaf index is set on append.
+// The card appended again is not appended twice.
+//
+func (d *MemoryTransparencyLogRepository) AppendTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// the card appended again takes its leaf appended already.
+	if index, ok := d.cards[entry.CardID]; ok {
+		entry.LeafIndex = index
+
+		return nil
+	}
+
+	entry.LeafIndex = int64(len(d.entries))
+
+	e := *entry
+	d.entries = append(d.entries, &e)
+	d.cards[entry.CardID] = entry.LeafIndex
+
+	return nil
+}
+
+//
+// GetTransparencyLogEntryByCardID returns the leaf of the card.
+// The method returns an error if the card is not in the log.
+//
+func (d *MemoryTransparencyLogRepository) GetTransparencyLogEntryByCardID(
+	span tracer.Span,
+	cardID string,
+) (*model.TransparencyLogEntry, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	index, ok := d.cards[cardID]
+	if !ok {
+		return nil, cassandra.ErrEntityNotFound
+	}
+
+	e := *d.entries[index]
+
+	return &e, nil
+}
+
+//
+// GetTransparencyLogSize returns the amount of the leaves appended.
+//
+func (d *MemoryTransparencyLogRepository) GetTransparencyLogSize(span tracer.Span) (int64, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return int64(len(d.entries)), nil
+}
+
+//
+// GetTransparencyLogNodeHash returns the hash of the complete subtree of 2^level leaves
+// starting from the leaf index*2^level. The zero level subtree hash is the leaf hash.
+// The method returns an error if the hash is not stored.
+//
+func (d *MemoryTransparencyLogRepository) GetTransparencyLogNodeHash(
+	span tracer.Span,
+	level uint,
+	index int64,
+) ([]byte, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if 0 == level {
+		if 0 > index || index >= int64(len(d.entries)) {
+			return nil, cassandra.ErrEntityNotFound
+		}
+
+		return d.entries[index].LeafHash, nil
+	}
+
+	hash, ok := d.nodes[memoryTransparencyLogNode{level: level, index: index}]
+	if !ok {
+		return nil, cassandra.ErrEntityNotFound
+	}
+
+	return hash, nil
+}
+
+//
+// SaveTransparencyLogNodeHash stores the hash of the complete subtree of the level and index given.
+//
+func (d *MemoryTransparencyLogRepository) SaveTransparencyLogNodeHash(
+	span tracer.Span,
+	level uint,
+	index int64,
+	hash []byte,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.nodes[memoryTransparencyLogNode{level: level, index: index}] = hash
+
+	return nil
+}
+
+//
+// SavePendingTransparencyLogEntry keeps the entry pending to be appended.
+//
+func (d *MemoryTransparencyLogRepository) SavePendingTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	e := *entry
+	d.pending[entry.CardID] = &e
+
+	return nil
+}
+
+//
+// GetPendingTransparencyLogEntries returns the entries pending to be appended.
+//
+func (d *MemoryTransparencyLogRepository) GetPendingTransparencyLogEntries(
+	span tracer.Span,
+) ([]*model.TransparencyLogEntry, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	entries := make([]*model.TransparencyLogEntry, 0, len(d.pending))
+	for _, entry := range d.pending {
+		e := *entry
+		entries = append(entries, &e)
+	}
+
+	return entries, nil
+}
+
+//
+// DeletePendingTransparencyLogEntry deletes the pending entry of the card.
+//
+func (d *MemoryTransparencyLogRepository) DeletePendingTransparencyLogEntry(span tracer.Span, cardID string) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentDAO,
+		},
+	)
+	defer span.Finish()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	delete(d.pending, cardID)
+
+	return nil
+}
@@ -25,9 +25,9 @@ type Provider interface {
 	Sign(data []byte, key PrivateKey) ([]byte, error)
 
 	//
-	// CalculateCardID returns the card ID for the content snapshot and extra snapshot values.
+	// CalculateCardID returns the card ID of the card version for the content snapshot.
 	//
-	CalculateCardID(contentSnapshot []byte) string
+	CalculateCardID(version string, contentSnapshot []byte) string
 
 	//
 	// CalculatePublicKeyID returns a public key id for key content.
@@ -93,11 +93,11 @@ func (c *Crypto) Sign(data []byte, privateKey PrivateKey) ([]byte, error) {
 }
 
 //
-// CalculateCardID returns a Virgil Card ID for the content snapshot.
+// CalculateCardID returns a Virgil Card ID of the card version for the content snapshot.
 //
-func (c *Crypto) CalculateCardID(snapshot []byte) string {
+func (c *Crypto) CalculateCardID(version string, snapshot []byte) string {
 
-	return c.idGenerator.VirgilCardID(snapshot)
+	return c.idGenerator.VirgilCardID(version, snapshot)
 }
 
 //
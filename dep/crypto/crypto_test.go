@@ -120,7 +120,7 @@ func TestCalculateCardIDWithEmptySnapshots(t *testing.T) {
 
 	crypto := getCryptoUnderTest()
 
-	fingerprint := crypto.CalculateCardID(emptyContentSnapshot)
+	fingerprint := crypto.CalculateCardID("", emptyContentSnapshot)
 
 	assert.NotEmpty(t, fingerprint)
 	assert.Len(t, fingerprint, models.IDLength)
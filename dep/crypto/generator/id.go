@@ -1,6 +1,10 @@
 package generator
 
 import (
+	"sync"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/hasher"
 )
@@ -10,9 +14,15 @@ import (
 //
 type IDProvider interface {
 	//
-	// VirgilCardID returns an ID for a Virgil Card by its content snapshot.
+	// VirgilCardID returns an ID for a Virgil Card of the version given by its content snapshot.
+	// The default ID scheme is used for the card versions without the ID scheme registered.
+	//
+	VirgilCardID(version string, snapshot []byte) string
+
+	//
+	// IsValidCardID returns true if the Virgil Card ID is of any registered ID scheme format.
 	//
-	VirgilCardID([]byte) string
+	IsValidCardID(id string) bool
 
 	//
 	// PublicKeyID returns a Public Key ID by its content.
@@ -28,33 +38,154 @@ const (
 	VirgilCardBytesInID = 32
 )
 
+//
+// Virgil Card ID scheme names.
+//
+const (
+	// IDSchemeSHA512 is the default ID scheme: SHA-512 hash truncated to 32 bytes.
+	IDSchemeSHA512 = "sha512"
+
+	// IDSchemeSHA256 is the SHA-256 hash ID scheme.
+	IDSchemeSHA256 = "sha256"
+)
+
+//
+// IDScheme describes an algorithm of the Virgil Card ID calculation.
+//
+type IDScheme struct {
+	Name   string
+	Hasher hasher.Provider
+	Bytes  int
+}
+
 //
 // IDGenerator is a default implementation for the Virgil Security IDs calculation.
 //
 type IDGenerator struct {
 	hasher  hasher.Provider
 	encoder encoder.Provider
+
+	mutex         sync.RWMutex
+	defaultScheme *IDScheme
+	schemes       map[string]*IDScheme
+	versions      map[string]*IDScheme
 }
 
 //
 // NewID returns a default ID generator instance.
+// The hasher given is used for the Public Key IDs and for the default Virgil Card ID scheme.
 //
 func NewID(hasher hasher.Provider, encoder encoder.Provider) *IDGenerator {
 
-	return &IDGenerator{hasher: hasher, encoder: encoder}
+	defaultScheme := &IDScheme{
+		Name:   IDSchemeSHA512,
+		Hasher: hasher,
+		Bytes:  VirgilCardBytesInID,
+	}
+
+	return &IDGenerator{
+		hasher:        hasher,
+		encoder:       encoder,
+		defaultScheme: defaultScheme,
+		schemes:       map[string]*IDScheme{defaultScheme.Name: defaultScheme},
+		versions:      make(map[string]*IDScheme),
+	}
 }
 
 //
-// VirgilCardID returns an ID for a Virgil Card by its content snapshot.
+// RegisterIDScheme registers the Virgil Card ID scheme and selects it for the card versions given.
+// The scheme replaces the registered one with the same name.
 //
-func (g *IDGenerator) VirgilCardID(snapshot []byte) string {
+func (g *IDGenerator) RegisterIDScheme(scheme *IDScheme, versions ...string) error {
+
+	if nil == scheme || "" == scheme.Name || nil == scheme.Hasher {
+		return errors.New("ID scheme is incomplete")
+	}
+	if 0 >= scheme.Bytes || len(scheme.Hasher.Hash(nil)) < scheme.Bytes {
+		return errors.New("ID scheme (%s) length %d is incorrect", scheme.Name, scheme.Bytes)
+	}
 
-	h := g.hasher.Hash(snapshot)
-	data := h[:VirgilCardBytesInID]
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.schemes[scheme.Name] = scheme
+	if IDSchemeSHA512 == scheme.Name {
+		g.defaultScheme = scheme
+	}
+
+	return g.selectIDScheme(scheme.Name, versions...)
+}
+
+//
+// SelectIDScheme selects the registered Virgil Card ID scheme for the card versions given.
+//
+func (g *IDGenerator) SelectIDScheme(name string, versions ...string) error {
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.selectIDScheme(name, versions...)
+}
+
+//
+// selectIDScheme selects the registered Virgil Card ID scheme for the card versions given.
+// The caller must hold the write lock.
+//
+func (g *IDGenerator) selectIDScheme(name string, versions ...string) error {
+
+	scheme, ok := g.schemes[name]
+	if !ok {
+		return errors.New("ID scheme (%s) is not registered", name)
+	}
+
+	for _, version := range versions {
+		g.versions[version] = scheme
+	}
+
+	return nil
+}
+
+//
+// VirgilCardID returns an ID for a Virgil Card of the version given by its content snapshot.
+// The default ID scheme is used for the card versions without the ID scheme registered.
+//
+func (g *IDGenerator) VirgilCardID(version string, snapshot []byte) string {
+
+	g.mutex.RLock()
+	scheme, ok := g.versions[version]
+	if !ok {
+		scheme = g.defaultScheme
+	}
+	g.mutex.RUnlock()
+
+	h := scheme.Hasher.Hash(snapshot)
+	data := h[:scheme.Bytes]
 
 	return g.encoder.EncodeToString(data)
 }
 
+//
+// IsValidCardID returns true if the Virgil Card ID is of any registered ID scheme format.
+//
+func (g *IDGenerator) IsValidCardID(id string) bool {
+
+	data, err := g.encoder.DecodeString(id)
+	if nil != err || g.encoder.EncodeToString(data) != id {
+		return false
+	}
+
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	for _, scheme := range g.schemes {
+		if scheme.Bytes == len(data) {
+			return true
+		}
+	}
+
+	return false
+}
+
 //
 // PublicKeyID returns a Public Key ID by its content.
 //
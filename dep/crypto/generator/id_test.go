@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/hasher"
+)
+
+//
+// Testing constants.
+//
+const (
+	testCardVersion = "test version"
+)
+
+var (
+	testSnapshot = []byte("content snapshot")
+)
+
+//
+// VirgilCardID :: for a card version without ID scheme :: uses the default ID scheme.
+//
+func TestVirgilCardIDForADefaultScheme(t *testing.T) {
+
+	g := getIDGeneratorUnderTest()
+	hash := hasher.NewSHA512().Hash(testSnapshot)
+
+	assert.Equal(t, hex.EncodeToString(hash[:VirgilCardBytesInID]), g.VirgilCardID(testCardVersion, testSnapshot))
+}
+
+//
+// VirgilCardID :: for a card version with registered ID scheme :: uses the scheme.
+//
+func TestVirgilCardIDForARegisteredScheme(t *testing.T) {
+
+	g := getIDGeneratorUnderTest()
+	err := g.RegisterIDScheme(&IDScheme{
+		Name:   IDSchemeSHA256,
+		Hasher: hasher.NewSHA256(),
+		Bytes:  VirgilCardBytesInID,
+	}, testCardVersion)
+
+	assert.NoError(t, err)
+
+	hash := hasher.NewSHA256().Hash(testSnapshot)
+
+	assert.Equal(t, hex.EncodeToString(hash), g.VirgilCardID(testCardVersion, testSnapshot))
+	assert.NotEqual(t, g.VirgilCardID("", testSnapshot), g.VirgilCardID(testCardVersion, testSnapshot))
+}
+
+//
+// RegisterIDScheme :: for incorrect schemes :: returns an error.
+//
+func TestRegisterIDSchemeForIncorrectSchemes(t *testing.T) {
+
+	g := getIDGeneratorUnderTest()
+
+	for _, scheme := range []*IDScheme{
+		nil,
+		{Hasher: hasher.NewSHA256(), Bytes: VirgilCardBytesInID},
+		{Name: IDSchemeSHA256, Bytes: VirgilCardBytesInID},
+		{Name: IDSchemeSHA256, Hasher: hasher.NewSHA256(), Bytes: 33},
+	} {
+		assert.Error(t, g.RegisterIDScheme(scheme))
+	}
+
+	assert.Error(t, g.SelectIDScheme("not registered scheme", testCardVersion))
+}
+
+//
+// IsValidCardID :: for IDs of registered and not registered formats :: behaves properly.
+//
+func TestIsValidCardID(t *testing.T) {
+
+	g := getIDGeneratorUnderTest()
+
+	assert.True(t, g.IsValidCardID(g.VirgilCardID("", testSnapshot)))
+	assert.False(t, g.IsValidCardID(strings.Repeat("a", 2*VirgilCardBytesInID-1)))
+	assert.False(t, g.IsValidCardID(strings.Repeat("z", 2*VirgilCardBytesInID)))
+	assert.False(t, g.IsValidCardID(strings.Repeat("A", 2*VirgilCardBytesInID)))
+	assert.False(t, g.IsValidCardID(strings.Repeat("a", 2*16)))
+
+	err := g.RegisterIDScheme(&IDScheme{Name: "short", Hasher: hasher.NewSHA256(), Bytes: 16})
+
+	assert.NoError(t, err)
+	assert.True(t, g.IsValidCardID(strings.Repeat("a", 2*16)))
+}
+
+//
+// getIDGeneratorUnderTest returns an ID generator under test.
+//
+func getIDGeneratorUnderTest() *IDGenerator {
+
+	return NewID(hasher.NewSHA512(), encoder.NewHex())
+}
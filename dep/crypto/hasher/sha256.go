@@ -0,0 +1,28 @@
+package hasher
+
+import (
+	"crypto/sha256"
+)
+
+//
+// SHA256 hasher object.
+//
+type SHA256 struct{}
+
+//
+// NewSHA256 returns an instance of SHA256 hasher.
+//
+func NewSHA256() *SHA256 {
+
+	return &SHA256{}
+}
+
+//
+// Hash calculates hash for a data.
+//
+func (h *SHA256) Hash(data []byte) []byte {
+
+	hash := sha256.Sum256(data)
+
+	return hash[:]
+}
@@ -0,0 +1,204 @@
+package keysource
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+)
+
+//
+// Signing agent operations.
+//
+const (
+	AgentOperationPublicKey = "public_key"
+	AgentOperationSign      = "sign"
+)
+
+//
+// AgentRequest is a request to the signing agent.
+// The requests and the responses are sent as JSON messages one after another over the same connection.
+// The sign operation expects the agent to sign the data SHA-512 hash the way the Virgil Cards are signed.
+//
+type AgentRequest struct {
+	Operation string `json:"operation"`
+	Data      []byte `json:"data,omitempty"`
+}
+
+//
+// AgentResponse is a response of the signing agent.
+//
+type AgentResponse struct {
+	PublicKey []byte `json:"public_key,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+//
+// AgentSource provides the service private key held by the signing agent listening on a local Unix socket.
+//
+type AgentSource struct {
+	socketPath string
+	timeout    time.Duration
+}
+
+//
+// NewAgentSource returns a new instance of the signing agent key source.
+//
+func NewAgentSource(socketPath string, timeout time.Duration) *AgentSource {
+
+	return &AgentSource{
+		socketPath: socketPath,
+		timeout:    timeout,
+	}
+}
+
+//
+// Signer returns the signer of the service private key held by the agent.
+//
+func (s *AgentSource) Signer() (Signer, error) {
+
+	signer := &AgentSigner{
+		socketPath: s.socketPath,
+		timeout:    s.timeout,
+	}
+
+	response, err := signer.call(&AgentRequest{Operation: AgentOperationPublicKey})
+	if nil != err {
+		return nil, errors.WithMessage(err, "signing agent public key request error")
+	}
+	if 0 == len(response.PublicKey) {
+		return nil, errors.New("signing agent (%s) returned an empty public key", s.socketPath)
+	}
+	signer.publicKey = response.PublicKey
+
+	return signer, nil
+}
+
+//
+// AgentSigner signs the Virgil Cards with the private key held by the signing agent.
+// The agent connection is kept open and reused by the requests one at a time.
+//
+type AgentSigner struct {
+	socketPath string
+	timeout    time.Duration
+	publicKey  []byte
+
+	mutex   sync.Mutex
+	conn    net.Conn
+	decoder *json.Decoder
+}
+
+//
+// SignVirgilCard signs the Virgil Card content snapshot and the extra snapshot.
+//
+func (s *AgentSigner) SignVirgilCard(csr, extraCSR []byte) ([]byte, error) {
+
+	data := make([]byte, 0, len(csr)+len(extraCSR))
+	data = append(append(data, csr...), extraCSR...)
+
+	response, err := s.call(&AgentRequest{Operation: AgentOperationSign, Data: data})
+	if nil != err {
+		return nil, errors.WithMessage(err, "signing agent sign request error")
+	}
+	if 0 == len(response.Signature) {
+		return nil, errors.New("signing agent (%s) returned an empty signature", s.socketPath)
+	}
+
+	return response.Signature, nil
+}
+
+//
+// PublicKey returns the encoded public key of the service private key.
+//
+func (s *AgentSigner) PublicKey() []byte {
+
+	return s.publicKey
+}
+
+//
+// Close closes the signing agent connection.
+//
+func (s *AgentSigner) Close() error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.closeConn()
+}
+
+//
+// call sends the request to the signing agent and reads its response.
+// The request is retried once over a new connection if the kept one has been closed by the agent.
+//
+func (s *AgentSigner) call(request *AgentRequest) (*AgentResponse, error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	reused := nil != s.conn
+	response, err := s.exchange(request)
+	if nil != err && reused {
+		response, err = s.exchange(request)
+	}
+	if nil != err {
+		return nil, err
+	}
+	if "" != response.Error {
+		return nil, errors.New("signing agent (%s) error: %s", s.socketPath, response.Error)
+	}
+
+	return response, nil
+}
+
+//
+// exchange writes the request to the agent connection and reads the response.
+// The connection is dialed if there is none and closed on any I/O error.
+//
+func (s *AgentSigner) exchange(request *AgentRequest) (*AgentResponse, error) {
+
+	if nil == s.conn {
+		conn, err := net.DialTimeout("unix", s.socketPath, s.timeout)
+		if nil != err {
+			return nil, errors.WithMessage(err, "signing agent (%s) dial error", s.socketPath)
+		}
+		s.conn, s.decoder = conn, json.NewDecoder(conn)
+	}
+
+	if 0 < s.timeout {
+		if err := s.conn.SetDeadline(time.Now().Add(s.timeout)); nil != err {
+			_ = s.closeConn()
+			return nil, errors.WithMessage(err, "signing agent (%s) deadline error", s.socketPath)
+		}
+	}
+
+	if err := json.NewEncoder(s.conn).Encode(request); nil != err {
+		_ = s.closeConn()
+		return nil, errors.WithMessage(err, "signing agent (%s) request write error", s.socketPath)
+	}
+
+	response := new(AgentResponse)
+	if err := s.decoder.Decode(response); nil != err {
+		_ = s.closeConn()
+		return nil, errors.WithMessage(err, "signing agent (%s) response read error", s.socketPath)
+	}
+
+	return response, nil
+}
+
+//
+// closeConn closes the kept agent connection if any.
+//
+func (s *AgentSigner) closeConn() error {
+
+	if nil == s.conn {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn, s.decoder = nil, nil
+
+	return err
+}
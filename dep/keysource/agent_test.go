@@ -0,0 +1,175 @@
+package keysource
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// Testing constants.
+//
+const (
+	testAgentTimeout = time.Second
+)
+
+//
+// Testing variables.
+//
+var (
+	testPublicKey = []byte("agent public key")
+	testSignature = []byte("agent signature")
+
+	// testAgentConnections counts the connections accepted by the last test agent started.
+	testAgentConnections int32
+)
+
+//
+// Signer :: for a running agent :: returns the signer of the agent key.
+//
+func TestAgentSourceSignerForARunningAgent(t *testing.T) {
+
+	socketPath, stop := startTestAgent(t, "")
+	defer stop()
+
+	signer, err := NewAgentSource(socketPath, testAgentTimeout).Signer()
+
+	assert.NoError(t, err)
+	assert.Equal(t, testPublicKey, signer.PublicKey())
+
+	signature, err := signer.SignVirgilCard([]byte("csr"), []byte("extra"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, testSignature, signature)
+}
+
+//
+// SignVirgilCard :: for several signatures :: reuses the agent connection.
+//
+func TestAgentSignerSignVirgilCardReusesTheConnection(t *testing.T) {
+
+	socketPath, stop := startTestAgent(t, "")
+	defer stop()
+
+	signer, err := NewAgentSource(socketPath, testAgentTimeout).Signer()
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		signature, err := signer.SignVirgilCard([]byte("csr"), []byte{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, testSignature, signature)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&testAgentConnections))
+}
+
+//
+// SignVirgilCard :: for the connection closed by the agent :: signs over a new connection.
+//
+func TestAgentSignerSignVirgilCardForAClosedConnection(t *testing.T) {
+
+	socketPath, stop := startTestAgent(t, "")
+	defer stop()
+
+	signer, err := NewAgentSource(socketPath, testAgentTimeout).Signer()
+	assert.NoError(t, err)
+
+	_ = signer.(*AgentSigner).conn.Close()
+	signature, err := signer.SignVirgilCard([]byte("csr"), []byte{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testSignature, signature)
+}
+
+//
+// SignVirgilCard :: for an agent error :: returns an error.
+//
+func TestAgentSignerSignVirgilCardForAnAgentError(t *testing.T) {
+
+	socketPath, stop := startTestAgent(t, "key is locked")
+	defer stop()
+
+	signer := &AgentSigner{socketPath: socketPath, timeout: testAgentTimeout}
+
+	signature, err := signer.SignVirgilCard([]byte("csr"), []byte{})
+
+	assert.Error(t, err)
+	assert.Nil(t, signature)
+}
+
+//
+// Signer :: for a not running agent :: returns an error.
+//
+func TestAgentSourceSignerForANotRunningAgent(t *testing.T) {
+
+	signer, err := NewAgentSource(filepath.Join(os.TempDir(), "not-existing-agent.sock"), testAgentTimeout).Signer()
+
+	assert.Error(t, err)
+	assert.Nil(t, signer)
+}
+
+//
+// startTestAgent starts the signing agent answering with the test key and signature or the error given.
+// It serves the requests one after another until the client closes the connection.
+//
+func startTestAgent(t *testing.T, agentError string) (socketPath string, stop func()) {
+
+	dir, err := ioutil.TempDir("", "keysource")
+	assert.NoError(t, err)
+
+	socketPath = filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socketPath)
+	assert.NoError(t, err)
+
+	atomic.StoreInt32(&testAgentConnections, 0)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			atomic.AddInt32(&testAgentConnections, 1)
+
+			go serveTestAgentConn(conn, agentError)
+		}
+	}()
+
+	return socketPath, func() {
+		_ = listener.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+//
+// serveTestAgentConn answers the agent requests of the connection until it is closed.
+//
+func serveTestAgentConn(conn net.Conn, agentError string) {
+
+	defer conn.Close()
+
+	decoder, encoder := json.NewDecoder(conn), json.NewEncoder(conn)
+	for {
+		request := new(AgentRequest)
+		if err := decoder.Decode(request); nil != err {
+			return
+		}
+
+		response := &AgentResponse{Error: agentError}
+		if "" == agentError && AgentOperationPublicKey == request.Operation {
+			response.PublicKey = testPublicKey
+		} else if "" == agentError && AgentOperationSign == request.Operation {
+			response.Signature = testSignature
+		}
+
+		if err := encoder.Encode(response); nil != err {
+			return
+		}
+	}
+}
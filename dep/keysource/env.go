@@ -0,0 +1,34 @@
+package keysource
+
+import (
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+)
+
+//
+// EnvSource provides the service private key passed through the configuration environment.
+//
+type EnvSource struct {
+	crypto     crypto.Provider
+	privateKey []byte
+	password   string
+}
+
+//
+// NewEnvSource returns a new instance of the environment key source.
+//
+func NewEnvSource(crypto crypto.Provider, privateKey []byte, password string) *EnvSource {
+
+	return &EnvSource{
+		crypto:     crypto,
+		privateKey: privateKey,
+		password:   password,
+	}
+}
+
+//
+// Signer returns the signer of the service private key.
+//
+func (s *EnvSource) Signer() (Signer, error) {
+
+	return NewCryptoSigner(s.crypto, s.privateKey, s.password)
+}
@@ -0,0 +1,66 @@
+package keysource
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+)
+
+//
+// FileSource provides the password encrypted service private key stored in a file.
+// The file holds the exported private key either as is or base64 encoded as configured.
+// The private key password is read from its own file, so it is not passed through the environment.
+//
+type FileSource struct {
+	crypto        crypto.Provider
+	path          string
+	passwordPath  string
+	base64Encoded bool
+}
+
+//
+// NewFileSource returns a new instance of the file key source.
+//
+func NewFileSource(crypto crypto.Provider, path, passwordPath string, base64Encoded bool) *FileSource {
+
+	return &FileSource{
+		crypto:        crypto,
+		path:          path,
+		passwordPath:  passwordPath,
+		base64Encoded: base64Encoded,
+	}
+}
+
+//
+// Signer returns the signer of the service private key.
+//
+func (s *FileSource) Signer() (Signer, error) {
+
+	content, err := ioutil.ReadFile(s.path)
+	if nil != err {
+		return nil, errors.WithMessage(err, "private key file (%s) read error", s.path)
+	}
+
+	privateKey := content
+	if s.base64Encoded {
+		if privateKey, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(content))); nil != err {
+			return nil, errors.WithMessage(err, "private key file (%s) base64 decode error", s.path)
+		}
+	}
+
+	password, err := ioutil.ReadFile(s.passwordPath)
+	if nil != err {
+		return nil, errors.WithMessage(err, "private key password file (%s) read error", s.passwordPath)
+	}
+	// The trailing line break is written by the editors and the secret stores, it is not a part of the password.
+	password = bytes.TrimRight(password, "\r\n")
+	if 0 == len(password) {
+		return nil, errors.New("private key password file (%s) is empty", s.passwordPath)
+	}
+
+	return NewCryptoSigner(s.crypto, privateKey, string(password))
+}
@@ -0,0 +1,84 @@
+package keysource
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+)
+
+//
+// Signer signs the Virgil Cards with the service private key without exposing the key itself.
+//
+type Signer interface {
+	//
+	// SignVirgilCard signs the Virgil Card content snapshot and the extra snapshot.
+	//
+	SignVirgilCard(csr, extraCSR []byte) ([]byte, error)
+
+	//
+	// PublicKey returns the encoded public key of the service private key.
+	//
+	PublicKey() []byte
+}
+
+//
+// Source provides the service private key signer.
+//
+type Source interface {
+	//
+	// Signer returns the signer of the service private key.
+	//
+	Signer() (Signer, error)
+}
+
+//
+// CryptoSigner signs the Virgil Cards with the imported private key.
+//
+type CryptoSigner struct {
+	crypto     crypto.Provider
+	privateKey crypto.PrivateKey
+	publicKey  []byte
+}
+
+//
+// NewCryptoSigner imports the password protected private key and returns the signer of it.
+//
+func NewCryptoSigner(crypto crypto.Provider, privateKey []byte, password string) (*CryptoSigner, error) {
+
+	key, err := crypto.ImportPrivateKey(privateKey, password)
+	if nil != err {
+		return nil, errors.WithMessage(err, "private key import error")
+	}
+
+	publicKey, err := key.ExtractPublicKey()
+	if nil != err {
+		return nil, errors.WithMessage(err, "extract public key error")
+	}
+
+	publicKeyBytes, err := publicKey.Encode()
+	if nil != err {
+		return nil, errors.WithMessage(err, "encode public key error")
+	}
+
+	return &CryptoSigner{
+		crypto:     crypto,
+		privateKey: key,
+		publicKey:  publicKeyBytes,
+	}, nil
+}
+
+//
+// SignVirgilCard signs the Virgil Card content snapshot and the extra snapshot.
+//
+func (s *CryptoSigner) SignVirgilCard(csr, extraCSR []byte) ([]byte, error) {
+
+	return s.crypto.SignVirgilCard(csr, extraCSR, s.privateKey)
+}
+
+//
+// PublicKey returns the encoded public key of the service private key.
+//
+func (s *CryptoSigner) PublicKey() []byte {
+
+	return s.publicKey
+}
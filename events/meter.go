@@ -5,6 +5,15 @@ import (
 	"github.com/VirgilSecurity/virgil-services-core-kit/metrics"
 )
 
+//
+// Service event action IDs which are not declared by the metrics package.
+//
+const (
+	CardExpiredHidden          = 10701
+	CardAuditAppendError       = 10702
+	TransparencyLogAppendError = 10703
+)
+
 //
 // EventProvider provides an interface to work with the service business metrics.
 //
@@ -53,6 +62,23 @@ type EventProvider interface {
 	// IncChainDeleteError increments Card delete error event.
 	//
 	IncChainDeleteError(accountID, applicationID string)
+
+	//
+	// IncCardExpiredHidden increments the event of the expired Card hidden from the response.
+	// It counts the responses the expired Cards are hidden from, not the Cards expired.
+	//
+	IncCardExpiredHidden(accountID, applicationID string)
+
+	//
+	// IncCardAuditAppendError increments the event of the Card audit record not appended after the Card is saved.
+	//
+	IncCardAuditAppendError(accountID, applicationID string)
+
+	//
+	// IncTransparencyLogAppendError increments the event of the Card not appended to the transparency log
+	// after the Card is saved.
+	//
+	IncTransparencyLogAppendError(accountID, applicationID string)
 }
 
 //
@@ -136,6 +162,28 @@ func (m EventMeter) IncChainDeleteError(accountID, applicationID string) {
 	m.pushServiceEvent(metrics.ChainDeleteError, accountID, applicationID)
 }
 
+//
+// IncCardExpiredHidden increments the event of the expired Card hidden from the response.
+//
+func (m EventMeter) IncCardExpiredHidden(accountID, applicationID string) {
+	m.pushServiceEvent(CardExpiredHidden, accountID, applicationID)
+}
+
+//
+// IncCardAuditAppendError increments the event of the Card audit record not appended after the Card is saved.
+//
+func (m EventMeter) IncCardAuditAppendError(accountID, applicationID string) {
+	m.pushServiceEvent(CardAuditAppendError, accountID, applicationID)
+}
+
+//
+// IncTransparencyLogAppendError increments the event of the Card not appended to the transparency log
+// after the Card is saved.
+//
+func (m EventMeter) IncTransparencyLogAppendError(accountID, applicationID string) {
+	m.pushServiceEvent(TransparencyLogAppendError, accountID, applicationID)
+}
+
 //
 // pushServiceEvent makes a push of service event to the old ES storage and to the Click House.
 //
@@ -1,14 +1,40 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	stdHTTP "net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/VirgilSecurity/virgil-services-core-kit/http"
 	"github.com/VirgilSecurity/virgil-services-core-kit/log"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
 
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/app/controller"
 	"github.com/VirgilSecurity/virgil-services-cards/src/cfg/config"
 	"github.com/VirgilSecurity/virgil-services-cards/src/cfg/di"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao/migration"
+	"github.com/VirgilSecurity/virgil-services-cards/src/translog"
+)
+
+//
+// Subcommands.
+//
+const (
+	commandMigrate  = "migrate"
+	commandRestore  = "restore"
+	commandBackfill = "backfill"
+	commandDrain    = "drain"
+
+	migrateActionUp     = "up"
+	migrateActionStatus = "status"
 )
 
 //
@@ -23,6 +49,33 @@ func main() {
 	// DI
 	diContainer := initDIContainer(c, l)
 
+	// Run Subcommand
+	if 1 < len(os.Args) && commandMigrate == os.Args[1] {
+		runMigrate(diContainer.GetSchemaMigrator(), os.Args[2:])
+
+		return
+	}
+	if 1 < len(os.Args) && commandRestore == os.Args[1] {
+		runRestore(diContainer.GetTracer(), diContainer.GetCardController(), os.Args[2:])
+
+		return
+	}
+	if 1 < len(os.Args) && commandBackfill == os.Args[1] {
+		runBackfill(diContainer.GetTracer(), diContainer.GetSchemaMigrator(), diContainer.GetCardRepository())
+
+		return
+	}
+	if 1 < len(os.Args) && commandDrain == os.Args[1] {
+		runDrain(diContainer.GetTracer(), diContainer.GetTransparencyLog(), os.Args[2:])
+
+		return
+	}
+
+	// Run Admin Service
+	if c.IsAdminEnabled() {
+		go runAdminServer(c, diContainer.GetAdminHTTPRouter().GetMuxRouter())
+	}
+
 	// Run Service
 	var h = diContainer.GetHTTPRouter().GetMuxRouter()
 	http.NewService(
@@ -74,6 +127,199 @@ func initDIContainer(c *config.Config, l log.Logger) *di.Container {
 	return diContainer
 }
 
+//
+// runMigrate runs the migrate subcommand: "migrate up [-dry-run]" or "migrate status".
+//
+func runMigrate(migrator *migration.Migrator, args []string) {
+
+	flags := flag.NewFlagSet(commandMigrate, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "print the pending migrations without applying them")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s %s|%s [-dry-run]\n", os.Args[0], commandMigrate,
+			migrateActionUp, migrateActionStatus)
+		flags.PrintDefaults()
+	}
+
+	if 0 == len(args) {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	action := args[0]
+	if err := flags.Parse(args[1:]); nil != err {
+		panicError("migrate arguments parse error", err)
+	}
+
+	switch action {
+	case migrateActionUp:
+		migrations, err := migrator.Up(*dryRun)
+		for _, m := range migrations {
+			if *dryRun {
+				fmt.Printf("pending %d: %s\n", m.Version, m.Description)
+				for _, statement := range m.Statements {
+					fmt.Printf("%s;\n", statement)
+				}
+
+				continue
+			}
+
+			fmt.Printf("applied %d: %s\n", m.Version, m.Description)
+		}
+		if nil != err {
+			panicError("migration error", err)
+		}
+		if 0 == len(migrations) {
+			fmt.Println("no pending migrations")
+		}
+	case migrateActionStatus:
+		statuses, err := migrator.Status()
+		if nil != err {
+			panicError("migration status error", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.IsApplied() {
+				state = "applied at " + time.Unix(status.AppliedAt, 0).UTC().Format(time.RFC3339)
+			}
+
+			fmt.Printf("%d: %s (%s)\n", status.Version, status.Description, state)
+		}
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+}
+
+//
+// runRestore runs the restore subcommand: "restore -card-id ID -operator OPERATOR -reason REASON".
+// It restores the deleted chain of the card and prints the restore audit record.
+//
+func runRestore(t tracer.Tracer, cardController controller.Provider, args []string) {
+
+	flags := flag.NewFlagSet(commandRestore, flag.ExitOnError)
+	request := new(api.ChainRestoreRequest)
+	flags.StringVar(&request.CardID, "card-id", "", "ID of any card of the deleted chain")
+	flags.StringVar(&request.OperatorID, "operator", "", "identity of the support operator restoring the chain")
+	flags.StringVar(&request.Reason, "reason", "", "reason the chain is restored for")
+
+	if err := flags.Parse(args); nil != err {
+		panicError("restore arguments parse error", err)
+	}
+	if "" == request.CardID {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	span := t.StartSpan(tracer.GetCallerInfo())
+	defer span.Finish()
+
+	record, err := cardController.ChainRestore(span, request)
+	if nil != err {
+		panicError("restore error", err)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(record); nil != err {
+		panicError("audit record encode error", err)
+	}
+}
+
+//
+// runBackfill runs the backfill subcommand: "backfill".
+// It fills the admin tables with the cards saved before the admin tables migration and prints the backfilled counts.
+//
+func runBackfill(t tracer.Tracer, migrator *migration.Migrator, repository dao.CardRepositoryProvider) {
+
+	statuses, err := migrator.Status()
+	if nil != err {
+		panicError("migration status error", err)
+	}
+
+	var savedBefore int64
+	for _, status := range statuses {
+		if migration.AdminTablesVersion == status.Version {
+			savedBefore = status.AppliedAt
+		}
+	}
+	if 0 == savedBefore {
+		panicError("backfill error", fmt.Errorf("migration %d is not applied", migration.AdminTablesVersion))
+	}
+
+	span := t.StartSpan(tracer.GetCallerInfo())
+	defer span.Finish()
+
+	counts, err := repository.BackfillAdminTables(span, savedBefore)
+	if nil != err {
+		panicError("backfill error", err)
+	}
+
+	for _, count := range counts {
+		fmt.Printf("%s: %d cards\n", count.ApplicationID, count.Cards)
+	}
+}
+
+//
+// runDrain runs the drain subcommand: "drain [-pending-for DURATION]".
+// It appends the saved cards kept pending in the transparency log which append has failed,
+// the pending cards which have not been saved are dropped.
+// It is meant to be run periodically by a single scheduled job.
+//
+func runDrain(t tracer.Tracer, transparencyLog translog.Provider, args []string) {
+
+	flags := flag.NewFlagSet(commandDrain, flag.ExitOnError)
+	pendingFor := flags.Duration("pending-for", time.Minute, "minimal time the card is pending for to be appended")
+
+	if err := flags.Parse(args); nil != err {
+		panicError("drain arguments parse error", err)
+	}
+
+	span := t.StartSpan(tracer.GetCallerInfo())
+	defer span.Finish()
+
+	appended, err := transparencyLog.DrainPendingCards(span, time.Now().Add(-*pendingFor).Unix())
+	fmt.Printf("appended %d pending cards\n", appended)
+	if nil != err {
+		panicError("drain error", err)
+	}
+}
+
+//
+// runAdminServer serves the admin API on its own address, so it is never exposed on the public listener.
+// The server is shut down gracefully on the interrupt or termination signal.
+//
+func runAdminServer(c *config.Config, h stdHTTP.Handler) {
+
+	server := &stdHTTP.Server{
+		Addr:         c.GetAdminHTTPAddress(),
+		Handler:      h,
+		ReadTimeout:  c.GetServerReadTimeout(),
+		WriteTimeout: c.GetServerWriteTimeout(),
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go shutdownAdminServer(server, signals, c.GetServerWriteTimeout())
+
+	if err := server.ListenAndServe(); nil != err && stdHTTP.ErrServerClosed != err {
+		panicError("admin server error", err)
+	}
+}
+
+//
+// shutdownAdminServer waits for the signal and shuts the admin server down.
+// The requests in progress are given the write timeout to complete.
+//
+func shutdownAdminServer(server *stdHTTP.Server, signals <-chan os.Signal, timeout time.Duration) {
+
+	<-signals
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); nil != err {
+		fmt.Fprintf(os.Stderr, "admin server shutdown error: %+v\n", err)
+	}
+}
+
 //
 // panicError panics with an error.
 //
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/http/response"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+)
+
+//
+// Admin token header.
+//
+const (
+	AdminTokenHTTPHeader = "Authorization"
+	AdminTokenPrefix     = "Bearer "
+)
+
+//
+// WithAdminToken passes the request to the callback if it carries the admin bearer token.
+//
+func WithAdminToken(
+	token string,
+	req *http.Request,
+	callback func(req *http.Request) response.Provider,
+) response.Provider {
+
+	if !isAdminTokenValid(token, req.Header.Get(AdminTokenHTTPHeader)) {
+		return response.New(api.ErrAdminTokenIsIncorrect)
+	}
+
+	return callback(req)
+}
+
+//
+// isAdminTokenValid compares the authorization header to the admin token in constant time.
+// An empty admin token matches nothing.
+//
+func isAdminTokenValid(token, header string) bool {
+
+	if "" == token || !strings.HasPrefix(header, AdminTokenPrefix) {
+		return false
+	}
+
+	return 1 == subtle.ConstantTimeCompare([]byte(token), []byte(strings.TrimPrefix(header, AdminTokenPrefix)))
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// isAdminTokenValid :: for the authorization headers :: accepts the bearer admin token only.
+//
+func TestIsAdminTokenValid(t *testing.T) {
+
+	assert.True(t, isAdminTokenValid("admin token", "Bearer admin token"))
+	assert.False(t, isAdminTokenValid("admin token", "Bearer another token"))
+	assert.False(t, isAdminTokenValid("admin token", "admin token"))
+	assert.False(t, isAdminTokenValid("admin token", ""))
+	assert.False(t, isAdminTokenValid("", "Bearer "))
+}
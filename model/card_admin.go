@@ -0,0 +1,38 @@
+package model
+
+//
+// AdminCard is the Virgil Card looked up by the admin API regardless of its application scope.
+//
+type AdminCard struct {
+	ID                 string   `json:"id"`
+	ApplicationID      string   `json:"application_id"`
+	Identity           string   `json:"identity"`
+	ChainID            string   `json:"chain_id"`
+	Version            string   `json:"version"`
+	CreatedAt          int64    `json:"created_at"`
+	ExpiresAt          int64    `json:"expires_at,omitempty"`
+	SuperseedingCardID string   `json:"superseeding_card_id,omitempty"`
+	ChainDeletedAt     int64    `json:"chain_deleted_at,omitempty"`
+	Card               *CardDTO `json:"card"`
+}
+
+//
+// CardChainState is the card chain entry of the identity in one of the applications.
+// Zero DeletedAt means the chain is not deleted.
+//
+type CardChainState struct {
+	Identity      string   `json:"identity"`
+	ApplicationID string   `json:"application_id"`
+	ChainID       string   `json:"chain_id"`
+	CardIDs       []string `json:"card_ids"`
+	CreatedAt     int64    `json:"created_at"`
+	DeletedAt     int64    `json:"deleted_at,omitempty"`
+}
+
+//
+// ApplicationCardCount is the amount of Virgil Cards registered for the application.
+//
+type ApplicationCardCount struct {
+	ApplicationID string `json:"application_id"`
+	Cards         int64  `json:"cards"`
+}
@@ -0,0 +1,33 @@
+package model
+
+//
+// Card audit actions.
+// The restore and force delete are made by the support operator over the card chain.
+//
+const (
+	CardAuditActionCreate      = "create"
+	CardAuditActionOverride    = "override"
+	CardAuditActionDelete      = "delete"
+	CardAuditActionRestore     = "restore"
+	CardAuditActionForceDelete = "force_delete"
+)
+
+//
+// CardAuditRecord is an append-only record of the Virgil Card mutation.
+// PreviousCardID is the card replaced by the override or deleted by the delete card.
+// The restore is recorded for the delete card unlinked from the chain, the force delete for the latest chain card.
+// OperatorID and Reason are set for the support operator actions only.
+//
+type CardAuditRecord struct {
+	ID             string `json:"id"`
+	CardID         string `json:"card_id"`
+	ChainID        string `json:"chain_id"`
+	Identity       string `json:"identity"`
+	ApplicationID  string `json:"application_id"`
+	AccountID      string `json:"account_id"`
+	PreviousCardID string `json:"previous_card_id,omitempty"`
+	Action         string `json:"action"`
+	OperatorID     string `json:"operator_id,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package model
+
+//
+// Card batch get error values.
+//
+const (
+	CardBatchGetErrorNotFound   = "not_found"
+	CardBatchGetErrorWrongScope = "wrong_scope"
+	CardBatchGetErrorExpired    = "expired"
+)
+
+//
+// CardBatchGetResult is a result of the get Virgil Cards by their IDs request.
+// The cards found are keyed by the requested card IDs, as the card itself is serialized without its ID.
+// Every other requested card ID gets an error, e.g. the not found card IDs get the not_found one.
+//
+type CardBatchGetResult struct {
+	Cards  map[string]*CardDTO  `json:"cards"`
+	Errors []*CardBatchGetError `json:"errors"`
+}
+
+//
+// CardBatchGetError describes why the requested card is not returned.
+//
+type CardBatchGetError struct {
+	CardID string `json:"card_id"`
+	Error  string `json:"error"`
+}
@@ -10,6 +10,7 @@ import (
 //
 const (
 	CardVersion5 = "5.0"
+	CardVersion6 = "6.0"
 )
 
 //
@@ -24,9 +25,27 @@ type CardDTO struct {
 	Version         string              `json:"-"`
 	ChainID         string              `json:"-"`
 	CreatedAt       int64               `json:"-"`
+	ExpiresAt       int64               `json:"-"`
 	Signatures      []*CardSignatureDTO `json:"signatures"`
 	PublicKey       []byte              `json:"-"`
 	IsSuperseeded   bool                `json:"-"`
+
+	SuperseedingCardID string          `json:"-"`
+	Chain              *CardChainInfo  `json:"chain,omitempty"`
+	Revocation         *CardRevocation `json:"revocation,omitempty"`
+
+	// RevocationReason and RevocationNote are the delete CSR revocation details.
+	RevocationReason string `json:"-"`
+	RevocationNote   string `json:"-"`
+}
+
+//
+// CardChainInfo describes the card chain the card belongs to and the card place in it.
+//
+type CardChainInfo struct {
+	ChainID        string `json:"chain_id"`
+	PreviousCardID string `json:"previous_card_id,omitempty"`
+	Length         int    `json:"length"`
 }
 
 //
@@ -131,6 +150,22 @@ func (c *CardDTO) SetCreatedAt(created time.Time) {
 	c.CreatedAt = created.Unix()
 }
 
+//
+// GetExpiresAt returns expiration UTC Unix timestamp. Zero means the card never expires.
+//
+func (c *CardDTO) GetExpiresAt() int64 {
+
+	return c.ExpiresAt
+}
+
+//
+// IsExpired returns true if the card has expired by the time given.
+//
+func (c *CardDTO) IsExpired(now time.Time) bool {
+
+	return 0 < c.ExpiresAt && c.ExpiresAt <= now.Unix()
+}
+
 //
 // GetChainID returns card's chain id.
 //
@@ -153,3 +188,11 @@ func (c *CardDTO) DoesScopeMatch(scopeID string) bool {
 
 	return scopeID == c.GetApplicationID()
 }
+
+//
+// IsDeleteCard returns true if the card is the delete card of its chain, the one with no public key.
+//
+func (c *CardDTO) IsDeleteCard() bool {
+
+	return "" != c.PreviousCardID && 0 == len(c.PublicKey)
+}
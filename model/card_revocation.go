@@ -0,0 +1,109 @@
+package model
+
+//
+// Card revocation reasons.
+//
+const (
+	RevocationReasonUnspecified          = "unspecified"
+	RevocationReasonKeyCompromise        = "key_compromise"
+	RevocationReasonSuperseded           = "superseded"
+	RevocationReasonCessationOfOperation = "cessation_of_operation"
+	RevocationReasonPrivilegeWithdrawn   = "privilege_withdrawn"
+)
+
+//
+// IsRevocationReasonValid returns true if the revocation reason is known.
+//
+func IsRevocationReasonValid(reason string) bool {
+
+	switch reason {
+	case RevocationReasonUnspecified,
+		RevocationReasonKeyCompromise,
+		RevocationReasonSuperseded,
+		RevocationReasonCessationOfOperation,
+		RevocationReasonPrivilegeWithdrawn:
+		return true
+	}
+
+	return false
+}
+
+//
+// CardRevocationContent is the revocation record content countersigned by the VirgilCards service.
+// CardID is the revoked card, DeleteCardID is the card made of the delete CSR.
+//
+type CardRevocationContent struct {
+	ChainID      string `json:"chain_id"`
+	Identity     string `json:"identity"`
+	CardID       string `json:"card_id"`
+	DeleteCardID string `json:"delete_card_id"`
+	Reason       string `json:"reason"`
+	Note         string `json:"note,omitempty"`
+	RevokedAt    int64  `json:"revoked_at"`
+}
+
+//
+// CardRevocation is the signed revocation record of the Virgil Card chain.
+// ContentSnapshot is the base64-encoded JSON of the revocation content the signature is made of,
+// so the record can be verified offline with the service key of the KeyID.
+//
+type CardRevocation struct {
+	CardRevocationContent
+
+	ApplicationID   string `json:"-"`
+	ContentSnapshot string `json:"content_snapshot"`
+	Signature       string `json:"signature"`
+	KeyID           string `json:"key_id"`
+}
+
+//
+// NewCardRevocation returns the unsigned revocation record of the card chain deleted with the delete card given.
+// An empty reason is recorded as unspecified.
+//
+func NewCardRevocation(deleteCard *CardDTO, revokedAt int64) *CardRevocation {
+
+	reason := deleteCard.RevocationReason
+	if "" == reason {
+		reason = RevocationReasonUnspecified
+	}
+
+	return &CardRevocation{
+		CardRevocationContent: CardRevocationContent{
+			ChainID:      deleteCard.GetChainID(),
+			Identity:     deleteCard.GetIdentity(),
+			CardID:       deleteCard.GetPreviousCardID(),
+			DeleteCardID: deleteCard.GetID(),
+			Reason:       reason,
+			Note:         deleteCard.RevocationNote,
+			RevokedAt:    revokedAt,
+		},
+		ApplicationID: deleteCard.GetApplicationID(),
+	}
+}
+
+//
+// NewForcedCardRevocation returns the unsigned revocation record of the card chain deleted without the delete card.
+// The card is the latest one of the chain, the note is the support operator reason.
+//
+func NewForcedCardRevocation(card *CardDTO, note string, revokedAt int64) *CardRevocation {
+
+	return &CardRevocation{
+		CardRevocationContent: CardRevocationContent{
+			ChainID:   card.GetChainID(),
+			Identity:  card.GetIdentity(),
+			CardID:    card.GetID(),
+			Reason:    RevocationReasonUnspecified,
+			Note:      note,
+			RevokedAt: revokedAt,
+		},
+		ApplicationID: card.GetApplicationID(),
+	}
+}
+
+//
+// GetContent returns the revocation content.
+//
+func (r *CardRevocation) GetContent() *CardRevocationContent {
+
+	return &r.CardRevocationContent
+}
@@ -0,0 +1,21 @@
+package model
+
+//
+// CardSearchCursor is a position of the paginated Virgil Cards search.
+// It points to the identity of the search request and to the count of its cards already returned.
+// IdentitiesHash binds the cursor to the identities of the search request it was issued for.
+//
+type CardSearchCursor struct {
+	IdentityOffset int    `json:"identity_offset"`
+	CardOffset     int    `json:"card_offset"`
+	IdentitiesHash string `json:"identities_hash"`
+}
+
+//
+// CardSearchPage is a page of the paginated Virgil Cards search.
+//
+type CardSearchPage struct {
+	Cards      []*CardDTO        `json:"cards"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	Next       *CardSearchCursor `json:"-"`
+}
@@ -16,6 +16,7 @@ type CardSignatureDTO struct {
 	Signer    string `json:"signer"`
 	Snapshot  string `json:"snapshot,omitempty"`
 	Signature string `json:"signature"`
+	KeyID     string `json:"key_id,omitempty"`
 }
 
 //
@@ -42,6 +43,14 @@ func (cs *CardSignatureDTO) GetSignature() string {
 	return cs.Signature
 }
 
+//
+// GetKeyID returns an ID of the key the signature was made with. It is set for the virgil signatures only.
+//
+func (cs *CardSignatureDTO) GetKeyID() string {
+
+	return cs.KeyID
+}
+
 //
 // IsApp returns true if signature is application one.
 //
@@ -2,11 +2,13 @@ package model
 
 import (
 	"encoding/base64"
+	"encoding/json"
 
 	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
 	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
 
 	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/keysource"
 )
 
 //
@@ -17,15 +19,66 @@ type CardSigner interface {
 	// SignCardByCardsService signs the Virgil Card with VirgilCards service.
 	//
 	SignCardByCardsService(tracer.Span, *CardDTO) error
+
+	//
+	// SignRevocation countersigns the Virgil Card chain revocation record with VirgilCards service.
+	//
+	SignRevocation(tracer.Span, *CardRevocation) error
+
+	//
+	// SignTreeHead signs the transparency log tree head with VirgilCards service.
+	//
+	SignTreeHead(tracer.Span, *TreeHead) error
+
+	//
+	// GetServiceKeys returns the active and retired VirgilCards service public keys.
+	//
+	GetServiceKeys() []*ServiceKey
+}
+
+//
+// Service key statuses.
+//
+const (
+	ServiceKeyStatusActive  = "active"
+	ServiceKeyStatusRetired = "retired"
+)
+
+//
+// ServiceKey is a VirgilCards service public key the virgil signatures are verified with.
+//
+type ServiceKey struct {
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"`
+	Status    string `json:"status"`
+}
+
+//
+// Signature context prefixes of the records signed by the VirgilCards service besides the cards.
+// The record content snapshot is signed after its prefix, so the signature of one record type
+// is never valid for another one nor for a card, the card content snapshots are signed as is.
+//
+const (
+	RevocationSignaturePrefix = "virgil-cards-revocation:"
+	TreeHeadSignaturePrefix   = "virgil-cards-tree-head:"
+)
+
+//
+// SignedData returns the data the VirgilCards service signs for the record content snapshot of the prefix given.
+//
+func SignedData(prefix string, snapshot []byte) []byte {
+
+	return append([]byte(prefix), snapshot...)
 }
 
 //
 // DefaultSigner is a default Virgil Cards signer interface.
+// It signs the cards with the active key and keeps the retired public keys to verify the cards signed before.
 //
 type DefaultSigner struct {
-	crypto           crypto.Provider
-	cards5PrivateKey crypto.PrivateKey
-	cards5SignerID   string
+	cards5KeySigner keysource.Signer
+	cards5SignerID  string
+	serviceKeys     []*ServiceKey
 }
 
 //
@@ -34,16 +87,38 @@ type DefaultSigner struct {
 func NewSigner(
 	crypto crypto.Provider,
 	cards5SignerID string,
-	cards5PrivateKey crypto.PrivateKey,
+	cards5KeySigner keysource.Signer,
+	retiredPublicKeys [][]byte,
 ) DefaultSigner {
 
+	serviceKeys := []*ServiceKey{{
+		KeyID:     cards5SignerID,
+		PublicKey: base64.StdEncoding.EncodeToString(cards5KeySigner.PublicKey()),
+		Status:    ServiceKeyStatusActive,
+	}}
+	for _, publicKey := range retiredPublicKeys {
+		serviceKeys = append(serviceKeys, &ServiceKey{
+			KeyID:     crypto.CalculatePublicKeyID(publicKey),
+			PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+			Status:    ServiceKeyStatusRetired,
+		})
+	}
+
 	return DefaultSigner{
-		crypto:           crypto,
-		cards5SignerID:   cards5SignerID,
-		cards5PrivateKey: cards5PrivateKey,
+		cards5KeySigner: cards5KeySigner,
+		cards5SignerID:  cards5SignerID,
+		serviceKeys:     serviceKeys,
 	}
 }
 
+//
+// GetServiceKeys returns the active and retired VirgilCards service public keys.
+//
+func (cs DefaultSigner) GetServiceKeys() []*ServiceKey {
+
+	return cs.serviceKeys
+}
+
 //
 // SignCardByCardsService signs the Virgil Card with VirgilCards service.
 //
@@ -65,7 +140,7 @@ func (cs DefaultSigner) SignCardByCardsService(span tracer.Span, c *CardDTO) (er
 		))
 	}
 
-	signature, err := cs.crypto.SignVirgilCard(cardContentSnapshotBytes, []byte{}, cs.cards5PrivateKey)
+	signature, err := cs.cards5KeySigner.SignVirgilCard(cardContentSnapshotBytes, []byte{})
 	if nil != err {
 		return tracer.SetSpanErrorAndReturn(span, errors.Wrap(err, errors.New(
 			"virgil card content snapshot (%s) sign error", c.GetContentSnapshot()),
@@ -75,7 +150,81 @@ func (cs DefaultSigner) SignCardByCardsService(span tracer.Span, c *CardDTO) (er
 	c.AppendSignature(&CardSignatureDTO{
 		Signer:    VirgilSignatureType,
 		Signature: base64.StdEncoding.EncodeToString(signature),
+		KeyID:     cs.cards5SignerID,
 	})
 
 	return nil
 }
+
+//
+// SignRevocation countersigns the Virgil Card chain revocation record with VirgilCards service.
+// The record content snapshot is signed after the revocation prefix with the service key,
+// so the record is verified with the service keys the virgil signatures are verified with.
+//
+func (cs DefaultSigner) SignRevocation(span tracer.Span, r *CardRevocation) (err error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	snapshot, err := json.Marshal(r.GetContent())
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.Wrap(err, errors.New(
+			"chain (%s) revocation content marshal error", r.ChainID),
+		))
+	}
+
+	signature, err := cs.cards5KeySigner.SignVirgilCard(SignedData(RevocationSignaturePrefix, snapshot), []byte{})
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.Wrap(err, errors.New(
+			"chain (%s) revocation content sign error", r.ChainID),
+		))
+	}
+
+	r.ContentSnapshot = base64.StdEncoding.EncodeToString(snapshot)
+	r.Signature = base64.StdEncoding.EncodeToString(signature)
+	r.KeyID = cs.cards5SignerID
+
+	return nil
+}
+
+//
+// SignTreeHead signs the transparency log tree head with VirgilCards service.
+// The tree head content snapshot is signed after the tree head prefix with the service key.
+//
+func (cs DefaultSigner) SignTreeHead(span tracer.Span, h *TreeHead) (err error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	snapshot, err := json.Marshal(h.GetContent())
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.Wrap(err, errors.New(
+			"tree head (%d) content marshal error", h.TreeSize),
+		))
+	}
+
+	signature, err := cs.cards5KeySigner.SignVirgilCard(SignedData(TreeHeadSignaturePrefix, snapshot), []byte{})
+	if nil != err {
+		return tracer.SetSpanErrorAndReturn(span, errors.Wrap(err, errors.New(
+			"tree head (%d) content sign error", h.TreeSize),
+		))
+	}
+
+	h.ContentSnapshot = base64.StdEncoding.EncodeToString(snapshot)
+	h.Signature = base64.StdEncoding.EncodeToString(signature)
+	h.KeyID = cs.cards5SignerID
+
+	return nil
+}
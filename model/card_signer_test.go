@@ -0,0 +1,86 @@
+package model
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/generator"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/hasher"
+)
+
+//
+// NewSigner :: for the active and retired keys :: lists the active key first and the retired ones by their IDs.
+//
+func TestNewSignerListsTheServiceKeys(t *testing.T) {
+
+	c := getCryptoUnderTest()
+	retiredPublicKeys := [][]byte{[]byte("retired public key 1"), []byte("retired public key 2")}
+
+	signer := NewSigner(c, "active key id", testKeySigner{}, retiredPublicKeys)
+
+	assert.Equal(t, []*ServiceKey{
+		{
+			KeyID:     "active key id",
+			PublicKey: base64.StdEncoding.EncodeToString([]byte("active public key")),
+			Status:    ServiceKeyStatusActive,
+		},
+		{
+			KeyID:     c.CalculatePublicKeyID(retiredPublicKeys[0]),
+			PublicKey: base64.StdEncoding.EncodeToString(retiredPublicKeys[0]),
+			Status:    ServiceKeyStatusRetired,
+		},
+		{
+			KeyID:     c.CalculatePublicKeyID(retiredPublicKeys[1]),
+			PublicKey: base64.StdEncoding.EncodeToString(retiredPublicKeys[1]),
+			Status:    ServiceKeyStatusRetired,
+		},
+	}, signer.GetServiceKeys())
+}
+
+//
+// GetServiceKeys :: for no retired keys :: returns the active key only.
+//
+func TestGetServiceKeysWithoutRetiredKeys(t *testing.T) {
+
+	signer := NewSigner(getCryptoUnderTest(), "active key id", testKeySigner{}, nil)
+
+	keys := signer.GetServiceKeys()
+
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, "active key id", keys[0].KeyID)
+		assert.Equal(t, ServiceKeyStatusActive, keys[0].Status)
+	}
+}
+
+//
+// testKeySigner signs with the fake service key.
+//
+type testKeySigner struct{}
+
+//
+// SignVirgilCard returns the fake signature of the snapshot.
+//
+func (testKeySigner) SignVirgilCard(csr, extraCSR []byte) ([]byte, error) {
+
+	return append([]byte("signature of "), csr...), nil
+}
+
+//
+// PublicKey returns the fake service public key.
+//
+func (testKeySigner) PublicKey() []byte {
+
+	return []byte("active public key")
+}
+
+//
+// getCryptoUnderTest returns the crypto the service key IDs are calculated with.
+//
+func getCryptoUnderTest() crypto.Provider {
+
+	return crypto.NewCrypto(generator.NewID(hasher.NewSHA512(), encoder.NewHex()))
+}
@@ -0,0 +1,40 @@
+package model
+
+//
+// Card signature verification statuses.
+//
+const (
+	CardSignatureStatusValid      = "valid"
+	CardSignatureStatusInvalid    = "invalid"
+	CardSignatureStatusUnverified = "unverified"
+)
+
+//
+// Card verification error values.
+//
+const (
+	CardVerifyErrorIDMismatch               = "card_id_mismatch"
+	CardVerifyErrorSelfSignatureIsMissing   = "self_signature_missing"
+	CardVerifyErrorVirgilSignatureIsMissing = "virgil_signature_missing"
+)
+
+//
+// CardVerifyResult is a result of the Virgil Card verification.
+// The card is valid if it has no errors and none of its signatures is invalid.
+//
+type CardVerifyResult struct {
+	CardID     string                       `json:"card_id"`
+	Valid      bool                         `json:"valid"`
+	Errors     []string                     `json:"errors,omitempty"`
+	Signatures []*CardSignatureVerifyResult `json:"signatures"`
+}
+
+//
+// CardSignatureVerifyResult describes the verification result of the Virgil Card signature.
+//
+type CardSignatureVerifyResult struct {
+	Signer string `json:"signer"`
+	KeyID  string `json:"key_id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+)
+
+//
+// StampPolicy represents the CSR stamps requirements of an application.
+// Zero stamps count limits mean the card version limits are applied only.
+//
+type StampPolicy struct {
+	RequiredSigners         []string `json:"required_signers"`
+	AllowedSigners          []string `json:"allowed_signers"`
+	MinStamps               int      `json:"min_stamps"`
+	MaxStamps               int      `json:"max_stamps"`
+	ExtraSnapshotsForbidden bool     `json:"extra_snapshots_forbidden"`
+}
+
+//
+// IsSignerAllowed returns true if the signer stamp is accepted by the policy.
+// The self and required signers are always allowed, an empty allowed signers list allows any signer.
+//
+func (p *StampPolicy) IsSignerAllowed(signer string) bool {
+
+	if SelfSignatureType == signer || 0 == len(p.AllowedSigners) {
+		return true
+	}
+
+	for _, allowedSigner := range p.AllowedSigners {
+		if allowedSigner == signer {
+			return true
+		}
+	}
+	for _, requiredSigner := range p.RequiredSigners {
+		if requiredSigner == signer {
+			return true
+		}
+	}
+
+	return false
+}
+
+//
+// Validate checks the policy is consistent.
+//
+func (p *StampPolicy) Validate() error {
+
+	if 0 > p.MinStamps || 0 > p.MaxStamps {
+		return fmt.Errorf("stamps count limits (%d, %d) must not be negative", p.MinStamps, p.MaxStamps)
+	}
+	if 0 < p.MaxStamps && p.MinStamps > p.MaxStamps {
+		return fmt.Errorf("min stamps count (%d) exceeds max stamps count (%d)", p.MinStamps, p.MaxStamps)
+	}
+	if 0 < p.MaxStamps && len(p.RequiredSigners) > p.MaxStamps {
+		return fmt.Errorf("required signers count (%d) exceeds max stamps count (%d)", len(p.RequiredSigners), p.MaxStamps)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+)
+
+//
+// TransparencyLogAppender appends the cards signed by the VirgilCards service to the transparency log.
+//
+type TransparencyLogAppender interface {
+	//
+	// AppendCard appends the card signed by the VirgilCards service to the transparency log.
+	//
+	AppendCard(span tracer.Span, card *CardDTO) error
+}
+
+//
+// TransparencyLogLeaf is the transparency log leaf of the card signed by the VirgilCards service.
+// The leaf hash is the RFC 6962 leaf hash of the leaf JSON, Signature is the virgil signature of the card.
+//
+type TransparencyLogLeaf struct {
+	CardID          string `json:"card_id"`
+	Identity        string `json:"identity"`
+	ContentSnapshot string `json:"content_snapshot"`
+	Signature       string `json:"signature"`
+}
+
+//
+// TransparencyLogEntry is the stored transparency log leaf. LeafData is the leaf JSON the leaf hash is made of.
+// CreatedAt is the UTC Unix timestamp the entry is kept pending to be appended since.
+//
+type TransparencyLogEntry struct {
+	LeafIndex     int64
+	CardID        string
+	ApplicationID string
+	LeafData      []byte
+	LeafHash      []byte
+	CreatedAt     int64
+}
+
+//
+// TreeHeadContent is the transparency log tree head content signed by the VirgilCards service.
+//
+type TreeHeadContent struct {
+	TreeSize  int64  `json:"tree_size"`
+	RootHash  string `json:"root_hash"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+//
+// TreeHead is the signed transparency log tree head. ContentSnapshot is the base64-encoded JSON
+// of the tree head content the signature is made of, it is verified with the service key of the KeyID.
+//
+type TreeHead struct {
+	TreeHeadContent
+
+	ContentSnapshot string `json:"content_snapshot"`
+	Signature       string `json:"signature"`
+	KeyID           string `json:"key_id"`
+}
+
+//
+// GetContent returns the tree head content.
+//
+func (h *TreeHead) GetContent() *TreeHeadContent {
+
+	return &h.TreeHeadContent
+}
+
+//
+// InclusionProof proves the card leaf is in the transparency log tree of the size given.
+// The hashes are base64-encoded.
+//
+type InclusionProof struct {
+	CardID        string               `json:"card_id"`
+	ApplicationID string               `json:"-"`
+	LeafIndex     int64                `json:"leaf_index"`
+	TreeSize      int64                `json:"tree_size"`
+	Leaf          *TransparencyLogLeaf `json:"leaf"`
+	LeafHash      string               `json:"leaf_hash"`
+	AuditPath     []string             `json:"audit_path"`
+}
+
+//
+// ConsistencyProof proves the transparency log tree of the first size is a prefix of the tree of the second size.
+// The hashes are base64-encoded.
+//
+type ConsistencyProof struct {
+	FirstTreeSize   int64    `json:"first_tree_size"`
+	SecondTreeSize  int64    `json:"second_tree_size"`
+	ConsistencyPath []string `json:"consistency_path"`
+}
@@ -0,0 +1,106 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	kitHTTP "github.com/VirgilSecurity/virgil-services-core-kit/http"
+	"github.com/VirgilSecurity/virgil-services-core-kit/http/response"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/middleware"
+	"github.com/VirgilSecurity/virgil-services-cards/src/transport"
+)
+
+const (
+
+	//
+	// AdminRoutePrefix base Admin API routing prefix.
+	//
+	AdminRoutePrefix = "/admin"
+
+	//
+	// RouteAdminCardGet GET /admin/card/{card_id} route.
+	//
+	RouteAdminCardGet = AdminRoutePrefix + "/card/" + CardIDPlaceholder
+
+	//
+	// RouteAdminIdentityChains GET /admin/chains?identity= route.
+	//
+	RouteAdminIdentityChains = AdminRoutePrefix + "/chains"
+
+	//
+	// RouteAdminChainForceDelete POST /admin/chain/actions/delete route.
+	//
+	RouteAdminChainForceDelete = AdminRoutePrefix + "/chain/actions/delete"
+
+	//
+	// RouteAdminChainRestore POST /admin/chain/actions/restore route.
+	//
+	RouteAdminChainRestore = AdminRoutePrefix + "/chain/actions/restore"
+
+	//
+	// RouteAdminApplicationCardCounts GET /admin/applications/card-counts route.
+	//
+	RouteAdminApplicationCardCounts = AdminRoutePrefix + "/applications/card-counts"
+
+	//
+	// RouteAdminCardAuditRecords GET /admin/audit?identity=|chain_id= route.
+	//
+	RouteAdminCardAuditRecords = AdminRoutePrefix + "/audit"
+)
+
+//
+// InitAdminRouteList makes an initialization of Admin API routes. Every route requires the admin token.
+//
+func InitAdminRouteList(t tracer.Tracer, r kitHTTP.RouterProvider, token string, h *transport.AdminHandler) {
+
+	r.Get(RouteAdminCardGet, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return middleware.WithAdminToken(token, req, func(req *http.Request) response.Provider {
+				return h.CardGet(req, mux.Vars(req)["card_id"])
+			})
+		})
+	})
+
+	r.Get(RouteAdminIdentityChains, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return middleware.WithAdminToken(token, req, func(req *http.Request) response.Provider {
+				return h.IdentityChains(req)
+			})
+		})
+	})
+
+	r.Post(RouteAdminChainForceDelete, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return middleware.WithAdminToken(token, req, func(req *http.Request) response.Provider {
+				return h.ChainForceDelete(req)
+			})
+		})
+	})
+
+	r.Post(RouteAdminChainRestore, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return middleware.WithAdminToken(token, req, func(req *http.Request) response.Provider {
+				return h.ChainRestore(req)
+			})
+		})
+	})
+
+	r.Get(RouteAdminApplicationCardCounts, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return middleware.WithAdminToken(token, req, func(req *http.Request) response.Provider {
+				return h.ApplicationCardCounts(req)
+			})
+		})
+	})
+
+	r.Get(RouteAdminCardAuditRecords, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return middleware.WithAdminToken(token, req, func(req *http.Request) response.Provider {
+				return h.CardAuditRecords(req)
+			})
+		})
+	})
+}
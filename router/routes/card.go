@@ -35,15 +35,50 @@ const (
 	//
 	RouteCardGet = RoutePrefix + "/" + CardIDPlaceholder
 
+	//
+	// RouteCardChain GET /card/{card_id}/chain route.
+	//
+	RouteCardChain = RouteCardGet + "/chain"
+
 	//
 	// RouteCardSearch POST /card/actions/search route.
 	//
 	RouteCardSearch = RoutePrefix + "/actions/search"
 
+	//
+	// RouteCardBatchGet POST /card/actions/get route.
+	//
+	RouteCardBatchGet = RoutePrefix + "/actions/get"
+
 	//
 	// RouteCardDelete POST /card/actions/delete route.
 	//
 	RouteCardDelete = RoutePrefix + "/actions/delete"
+
+	//
+	// RouteCardVerify POST /card/actions/verify route.
+	//
+	RouteCardVerify = RoutePrefix + "/actions/verify"
+
+	//
+	// RouteCardServiceKeys GET /card/service-keys route.
+	//
+	RouteCardServiceKeys = RoutePrefix + "/service-keys"
+
+	//
+	// RouteCardTransparencyTreeHead GET /card/transparency/tree-head route.
+	//
+	RouteCardTransparencyTreeHead = RoutePrefix + "/transparency/tree-head"
+
+	//
+	// RouteCardTransparencyConsistency GET /card/transparency/consistency route.
+	//
+	RouteCardTransparencyConsistency = RoutePrefix + "/transparency/consistency"
+
+	//
+	// RouteCardTransparencyInclusion GET /card/{card_id}/transparency/inclusion route.
+	//
+	RouteCardTransparencyInclusion = RouteCardGet + "/transparency/inclusion"
 )
 
 //
@@ -57,21 +92,64 @@ func InitCardsRouteList(t tracer.Tracer, r kitHTTP.RouterProvider, h *transport.
 		})
 	})
 
+	// The route goes before the get card one, otherwise the service-keys is matched as a card ID.
+	r.Get(RouteCardServiceKeys, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.CardServiceKeys(req)
+		})
+	})
+
 	r.Get(RouteCardGet, func(req *http.Request) response.Provider {
 		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
 			return h.CardGet(req, mux.Vars(req)["card_id"])
 		})
 	})
 
+	r.Get(RouteCardChain, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.CardChain(req, mux.Vars(req)["card_id"])
+		})
+	})
+
 	r.Post(RouteCardSearch, func(req *http.Request) response.Provider {
 		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
 			return h.CardSearch(req)
 		})
 	})
 
+	r.Post(RouteCardBatchGet, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.CardBatchGet(req)
+		})
+	})
+
 	r.Post(RouteCardDelete, func(req *http.Request) response.Provider {
 		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
 			return h.CardDelete(req)
 		})
 	})
+
+	r.Post(RouteCardVerify, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.CardVerify(req)
+		})
+	})
+
+	r.Get(RouteCardTransparencyTreeHead, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.TransparencyTreeHead(req)
+		})
+	})
+
+	r.Get(RouteCardTransparencyConsistency, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.TransparencyConsistencyProof(req)
+		})
+	})
+
+	r.Get(RouteCardTransparencyInclusion, func(req *http.Request) response.Provider {
+		return middleware.WithTracer(t, req, func(req *http.Request) response.Provider {
+			return h.TransparencyInclusionProof(req, mux.Vars(req)["card_id"])
+		})
+	})
 }
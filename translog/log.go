@@ -0,0 +1,416 @@
+package translog
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// Provider is the append-only Merkle tree transparency log of the cards signed by the VirgilCards service.
+//
+type Provider interface {
+	model.TransparencyLogAppender
+
+	//
+	// KeepCardPending keeps the card pending to be appended until it is appended.
+	// The card is kept pending before it is saved, so the saved card is appended by DrainPendingCards for sure.
+	//
+	KeepCardPending(span tracer.Span, card *model.CardDTO) error
+
+	//
+	// TreeHead returns the unsigned head of the current tree.
+	//
+	TreeHead(span tracer.Span) (*model.TreeHeadContent, error)
+
+	//
+	// InclusionProof returns the proof of the card leaf inclusion into the tree of the size given.
+	// Zero tree size means the current tree.
+	//
+	InclusionProof(span tracer.Span, cardID string, treeSize int64) (*model.InclusionProof, error)
+
+	//
+	// ConsistencyProof returns the proof the tree of the first size is a prefix of the tree of the second size.
+	// Zero second tree size means the current tree.
+	//
+	ConsistencyProof(span tracer.Span, firstTreeSize, secondTreeSize int64) (*model.ConsistencyProof, error)
+
+	//
+	// DrainPendingCards appends the saved cards kept pending since before the UTC Unix timestamp given.
+	// Returns the amount of the cards appended.
+	//
+	DrainPendingCards(span tracer.Span, pendingBefore int64) (int, error)
+}
+
+//
+// Log is the RFC 6962 transparency log kept in the transparency log repository.
+// The proofs are made of the complete subtree hashes stored on the leaf append.
+//
+type Log struct {
+	repository     dao.TransparencyLogRepositoryProvider
+	cardRepository dao.CardRepositoryProvider
+}
+
+//
+// New returns an instance of the transparency log.
+// The card repository tells the pending cards which have been saved from the ones which have not.
+//
+func New(repository dao.TransparencyLogRepositoryProvider, cardRepository dao.CardRepositoryProvider) *Log {
+
+	return &Log{
+		repository:     repository,
+		cardRepository: cardRepository,
+	}
+}
+
+//
+// KeepCardPending keeps the card pending to be appended until it is appended.
+// The card is kept pending before it is saved, so the saved card is appended by DrainPendingCards for sure.
+//
+func (l *Log) KeepCardPending(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	entry, err := newEntry(span, card)
+	if nil != err {
+		return err
+	}
+	entry.CreatedAt = time.Now().Unix()
+
+	return l.repository.SavePendingTransparencyLogEntry(span, entry)
+}
+
+//
+// AppendCard appends the card signed by the VirgilCards service to the transparency log.
+// The card kept pending is appended once it is saved, so the log never holds a card which has not been issued.
+// The card failed to be appended is left pending to be appended by DrainPendingCards.
+//
+func (l *Log) AppendCard(span tracer.Span, card *model.CardDTO) error {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	entry, err := newEntry(span, card)
+	if nil != err {
+		return err
+	}
+
+	return l.appendEntry(span, entry)
+}
+
+//
+// DrainPendingCards appends the saved cards kept pending since before the UTC Unix timestamp given,
+// so the cards being saved right now are not taken for the ones which have not been saved.
+// The entry of the card which has not been saved is deleted, the card appended already is not appended again.
+// Returns the amount of the cards appended.
+//
+func (l *Log) DrainPendingCards(span tracer.Span, pendingBefore int64) (int, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	entries, err := l.repository.GetPendingTransparencyLogEntries(span)
+	if nil != err {
+		return 0, err
+	}
+
+	appended := 0
+	for _, entry := range entries {
+		if entry.CreatedAt >= pendingBefore {
+			continue
+		}
+
+		isAppendable, err := l.isPendingEntryAppendable(span, entry)
+		if nil != err {
+			return appended, err
+		}
+		if !isAppendable {
+			if err := l.repository.DeletePendingTransparencyLogEntry(span, entry.CardID); nil != err {
+				return appended, err
+			}
+
+			continue
+		}
+
+		if err := l.appendEntry(span, entry); nil != err {
+			return appended, err
+		}
+		appended++
+	}
+
+	return appended, nil
+}
+
+//
+// isPendingEntryAppendable returns true if the card of the pending entry is saved and not appended yet.
+//
+func (l *Log) isPendingEntryAppendable(span tracer.Span, entry *model.TransparencyLogEntry) (bool, error) {
+
+	if _, err := l.cardRepository.GetCardByID(span, entry.CardID); nil != err {
+		if err == cassandra.ErrEntityNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if _, err := l.repository.GetTransparencyLogEntryByCardID(span, entry.CardID); nil != err {
+		if err == cassandra.ErrEntityNotFound {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return false, nil
+}
+
+//
+// newEntry returns the transparency log entry of the card.
+// The leaf holds the content snapshot and the virgil signature, so the issued card is seen in the log as is.
+//
+func newEntry(span tracer.Span, card *model.CardDTO) (*model.TransparencyLogEntry, error) {
+
+	leaf := &model.TransparencyLogLeaf{
+		CardID:          card.GetID(),
+		Identity:        card.GetIdentity(),
+		ContentSnapshot: card.GetContentSnapshot(),
+	}
+	for _, signature := range card.GetSignatures() {
+		if model.VirgilSignatureType == signature.GetSigner() {
+			leaf.Signature = signature.GetSignature()
+		}
+	}
+
+	leafData, err := json.Marshal(leaf)
+	if nil != err {
+		return nil, tracer.SetSpanErrorAndReturn(span, errors.WithMessage(
+			err,
+			"card's(%s) transparency log leaf marshal error", card.GetID(),
+		))
+	}
+
+	return &model.TransparencyLogEntry{
+		CardID:        card.GetID(),
+		ApplicationID: card.GetApplicationID(),
+		LeafData:      leafData,
+		LeafHash:      LeafHash(leafData),
+	}, nil
+}
+
+//
+// appendEntry appends the pending entry and deletes it once appended.
+// The complete subtrees the leaf completes are stored right away, so the proofs are not made of the leaves.
+// The subtree hashes and pending entry delete errors are set to the span only: the missing subtree hash
+// is made on the next read, the pending entry of the card appended is deleted by DrainPendingCards.
+//
+func (l *Log) appendEntry(span tracer.Span, entry *model.TransparencyLogEntry) error {
+
+	if err := l.repository.AppendTransparencyLogEntry(span, entry); nil != err {
+		return err
+	}
+
+	if err := newTree(span, l.repository).completeSubtrees(entry.LeafIndex); nil != err {
+		_ = tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	if err := l.repository.DeletePendingTransparencyLogEntry(span, entry.CardID); nil != err {
+		_ = tracer.SetSpanErrorAndReturn(span, err)
+	}
+
+	return nil
+}
+
+//
+// TreeHead returns the unsigned head of the current tree.
+//
+func (l *Log) TreeHead(span tracer.Span) (*model.TreeHeadContent, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	treeSize, err := l.getTreeSize(span, 0)
+	if nil != err {
+		return nil, err
+	}
+
+	rootHash, err := newTree(span, l.repository).rangeHash(0, treeSize)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage("error getting transparency log root hash: %+v", err)
+	}
+
+	return &model.TreeHeadContent{
+		TreeSize: treeSize,
+		RootHash: base64.StdEncoding.EncodeToString(rootHash),
+	}, nil
+}
+
+//
+// InclusionProof returns the proof of the card leaf inclusion into the tree of the size given.
+// Zero tree size means the current tree.
+//
+func (l *Log) InclusionProof(span tracer.Span, cardID string, treeSize int64) (*model.InclusionProof, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	entry, err := l.repository.GetTransparencyLogEntryByCardID(span, cardID)
+	if nil != err {
+		if err == cassandra.ErrEntityNotFound {
+			return nil, tracer.SetSpanErrorAndReturn(span, api.ErrTransparencyLogLeafIsNotFound)
+		}
+
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting card's(%s) transparency log leaf: %+v",
+			cardID, err,
+		)
+	}
+
+	treeSize, err = l.getTreeSize(span, treeSize)
+	if nil != err {
+		return nil, err
+	}
+
+	if entry.LeafIndex >= treeSize {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrTransparencyTreeSizeIsIncorrect)
+	}
+
+	leaf := new(model.TransparencyLogLeaf)
+	if err := json.Unmarshal(entry.LeafData, leaf); nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"card's(%s) transparency log leaf unmarshal error: %+v",
+			cardID, err,
+		)
+	}
+
+	auditPath, err := newTree(span, l.repository).inclusionPath(entry.LeafIndex, 0, treeSize)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage(
+			"error getting card's(%s) transparency log audit path: %+v",
+			cardID, err,
+		)
+	}
+
+	return &model.InclusionProof{
+		CardID:        entry.CardID,
+		ApplicationID: entry.ApplicationID,
+		LeafIndex:     entry.LeafIndex,
+		TreeSize:      treeSize,
+		Leaf:          leaf,
+		LeafHash:      base64.StdEncoding.EncodeToString(entry.LeafHash),
+		AuditPath:     encodeHashes(auditPath),
+	}, nil
+}
+
+//
+// ConsistencyProof returns the proof the tree of the first size is a prefix of the tree of the second size.
+// Zero second tree size means the current tree.
+//
+func (l *Log) ConsistencyProof(
+	span tracer.Span,
+	firstTreeSize int64,
+	secondTreeSize int64,
+) (*model.ConsistencyProof, error) {
+
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentModel,
+		},
+	)
+	defer span.Finish()
+
+	secondTreeSize, err := l.getTreeSize(span, secondTreeSize)
+	if nil != err {
+		return nil, err
+	}
+
+	if 0 >= firstTreeSize || firstTreeSize > secondTreeSize {
+		return nil, tracer.SetSpanErrorAndReturn(span, api.ErrTransparencyTreeSizeIsIncorrect)
+	}
+
+	consistencyPath, err := newTree(span, l.repository).consistencyPath(firstTreeSize, 0, secondTreeSize, true)
+	if nil != err {
+		return nil, api.ErrInternalError.WithMessage("error getting transparency log consistency path: %+v", err)
+	}
+
+	return &model.ConsistencyProof{
+		FirstTreeSize:   firstTreeSize,
+		SecondTreeSize:  secondTreeSize,
+		ConsistencyPath: encodeHashes(consistencyPath),
+	}, nil
+}
+
+//
+// getTreeSize returns the tree size given if the tree has grown up to it. Zero size means the current tree.
+//
+func (l *Log) getTreeSize(span tracer.Span, treeSize int64) (int64, error) {
+
+	size, err := l.repository.GetTransparencyLogSize(span)
+	if nil != err {
+		return 0, api.ErrInternalError.WithMessage("error getting transparency log size: %+v", err)
+	}
+
+	if 0 == treeSize {
+		return size, nil
+	}
+
+	if 0 > treeSize || treeSize > size {
+		return 0, tracer.SetSpanErrorAndReturn(span, api.ErrTransparencyTreeSizeIsIncorrect)
+	}
+
+	return treeSize, nil
+}
+
+//
+// encodeHashes returns the base64-encoded hashes.
+//
+func encodeHashes(hashes [][]byte) []string {
+
+	encoded := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		encoded = append(encoded, base64.StdEncoding.EncodeToString(hash))
+	}
+
+	return encoded
+}
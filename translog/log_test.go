@@ -0,0 +1,340 @@
+package translog
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/test/helper"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// InclusionProof :: for the cards appended :: is verified against the tree head of the tree size requested.
+//
+func TestInclusionProofForTheCardsAppended(t *testing.T) {
+
+	log := getTestLog(t, 5)
+
+	treeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), treeHead.TreeSize)
+
+	for i := 0; i < 5; i++ {
+		proof, err := log.InclusionProof(mock.StartNoopSpan(), fmt.Sprintf("card %d", i), 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "application", proof.ApplicationID)
+		assert.Equal(t, fmt.Sprintf("snapshot %d", i), proof.Leaf.ContentSnapshot)
+		assert.Equal(t, "virgil signature", proof.Leaf.Signature)
+		assert.True(t, VerifyInclusion(
+			proof.LeafIndex,
+			proof.TreeSize,
+			decodeTestHash(t, proof.LeafHash),
+			decodeTestHashes(t, proof.AuditPath),
+			decodeTestHash(t, treeHead.RootHash),
+		))
+	}
+
+	_, err = log.InclusionProof(mock.StartNoopSpan(), "card 4", 4)
+
+	assert.Equal(t, api.ErrTransparencyTreeSizeIsIncorrect, helper.ExtractHTTPError(err))
+
+	_, err = log.InclusionProof(mock.StartNoopSpan(), "unknown card", 0)
+
+	assert.Equal(t, api.ErrTransparencyLogLeafIsNotFound, helper.ExtractHTTPError(err))
+}
+
+//
+// ConsistencyProof :: for the tree grown since the tree head seen :: is verified against both tree heads.
+//
+func TestConsistencyProofForTheTreeGrown(t *testing.T) {
+
+	log := getTestLog(t, 3)
+
+	firstTreeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+
+	appendTestCards(t, log, 3, 4)
+
+	secondTreeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+
+	proof, err := log.ConsistencyProof(mock.StartNoopSpan(), firstTreeHead.TreeSize, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, secondTreeHead.TreeSize, proof.SecondTreeSize)
+	assert.True(t, VerifyConsistency(
+		proof.FirstTreeSize,
+		proof.SecondTreeSize,
+		decodeTestHash(t, firstTreeHead.RootHash),
+		decodeTestHash(t, secondTreeHead.RootHash),
+		decodeTestHashes(t, proof.ConsistencyPath),
+	))
+
+	_, err = log.ConsistencyProof(mock.StartNoopSpan(), 8, 0)
+
+	assert.Equal(t, api.ErrTransparencyTreeSizeIsIncorrect, helper.ExtractHTTPError(err))
+}
+
+//
+// DrainPendingCards :: for the card which append has failed :: appends the card kept pending.
+//
+func TestDrainPendingCardsAppendsTheFailedAppend(t *testing.T) {
+
+	repository := newTestTransparencyLogRepository()
+	log := New(repository, dao.NewMemoryCardRepository())
+
+	card := saveTestCard(t, log, 0)
+	repository.appendErr = errors.New("append error")
+	assert.Error(t, log.AppendCard(mock.StartNoopSpan(), card))
+
+	treeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), treeHead.TreeSize)
+
+	repository.appendErr = nil
+
+	appended, err := log.DrainPendingCards(mock.StartNoopSpan(), time.Now().Add(-time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, appended)
+
+	appended, err = log.DrainPendingCards(mock.StartNoopSpan(), time.Now().Add(time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, appended)
+
+	proof, err := log.InclusionProof(mock.StartNoopSpan(), "card 0", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), proof.LeafIndex)
+
+	entries, err := repository.GetPendingTransparencyLogEntries(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+//
+// DrainPendingCards :: for the card appended with its pending entry left :: deletes the entry only.
+//
+func TestDrainPendingCardsDoesNotAppendTheCardAppended(t *testing.T) {
+
+	repository := dao.NewMemoryTransparencyLogRepository()
+	log := New(repository, dao.NewMemoryCardRepository())
+	appendTestCards(t, log, 0, 1)
+
+	entry, err := repository.GetTransparencyLogEntryByCardID(mock.StartNoopSpan(), "card 0")
+	assert.NoError(t, err)
+	assert.NoError(t, repository.SavePendingTransparencyLogEntry(mock.StartNoopSpan(), entry))
+
+	appended, err := log.DrainPendingCards(mock.StartNoopSpan(), time.Now().Add(time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, appended)
+
+	treeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), treeHead.TreeSize)
+
+	entries, err := repository.GetPendingTransparencyLogEntries(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+//
+// DrainPendingCards :: for the card kept pending which has not been saved :: deletes the entry only.
+//
+func TestDrainPendingCardsDoesNotAppendTheCardNotSaved(t *testing.T) {
+
+	repository := dao.NewMemoryTransparencyLogRepository()
+	log := New(repository, dao.NewMemoryCardRepository())
+
+	assert.NoError(t, log.KeepCardPending(mock.StartNoopSpan(), newTestCard(0)))
+
+	appended, err := log.DrainPendingCards(mock.StartNoopSpan(), time.Now().Add(time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, appended)
+
+	treeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), treeHead.TreeSize)
+
+	entries, err := repository.GetPendingTransparencyLogEntries(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+//
+// DrainPendingCards :: for the leaf appended without the leaf index of its card :: does not append the leaf twice.
+//
+func TestDrainPendingCardsDoesNotAppendTheCardLeafTwice(t *testing.T) {
+
+	repository := newTestTransparencyLogRepository()
+	log := New(repository, dao.NewMemoryCardRepository())
+
+	card := saveTestCard(t, log, 0)
+	repository.indexErr = errors.New("index error")
+	assert.Error(t, log.AppendCard(mock.StartNoopSpan(), card))
+
+	repository.indexErr = nil
+
+	_, err := log.InclusionProof(mock.StartNoopSpan(), "card 0", 0)
+	assert.Equal(t, api.ErrTransparencyLogLeafIsNotFound, helper.ExtractHTTPError(err))
+
+	appended, err := log.DrainPendingCards(mock.StartNoopSpan(), time.Now().Add(time.Minute).Unix())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, appended)
+
+	treeHead, err := log.TreeHead(mock.StartNoopSpan())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), treeHead.TreeSize)
+
+	proof, err := log.InclusionProof(mock.StartNoopSpan(), "card 0", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), proof.LeafIndex)
+}
+
+//
+// testTransparencyLogRepository is the memory transparency log repository failing the append with the error given.
+// The index error fails the append once the leaf is appended, the leaf index of the card is not found then
+// the same way the Cassandra repository does not find the card which leaf index has not been saved.
+//
+type testTransparencyLogRepository struct {
+	*dao.MemoryTransparencyLogRepository
+
+	appendErr error
+	indexErr  error
+	unindexed map[string]bool
+}
+
+//
+// newTestTransparencyLogRepository returns the empty test transparency log repository.
+//
+func newTestTransparencyLogRepository() *testTransparencyLogRepository {
+
+	return &testTransparencyLogRepository{
+		MemoryTransparencyLogRepository: dao.NewMemoryTransparencyLogRepository(),
+		unindexed:                       make(map[string]bool),
+	}
+}
+
+//
+// AppendTransparencyLogEntry appends the entry unless the error is set.
+//
+func (r *testTransparencyLogRepository) AppendTransparencyLogEntry(
+	span tracer.Span,
+	entry *model.TransparencyLogEntry,
+) error {
+
+	if nil != r.appendErr {
+		return r.appendErr
+	}
+
+	if err := r.MemoryTransparencyLogRepository.AppendTransparencyLogEntry(span, entry); nil != err {
+		return err
+	}
+
+	if nil != r.indexErr {
+		r.unindexed[entry.CardID] = true
+
+		return r.indexErr
+	}
+	delete(r.unindexed, entry.CardID)
+
+	return nil
+}
+
+//
+// GetTransparencyLogEntryByCardID returns the entry of the card unless its leaf index has not been saved.
+//
+func (r *testTransparencyLogRepository) GetTransparencyLogEntryByCardID(
+	span tracer.Span,
+	cardID string,
+) (*model.TransparencyLogEntry, error) {
+
+	if r.unindexed[cardID] {
+		return nil, cassandra.ErrEntityNotFound
+	}
+
+	return r.MemoryTransparencyLogRepository.GetTransparencyLogEntryByCardID(span, cardID)
+}
+
+//
+// getTestLog returns the memory transparency log with the test cards appended.
+//
+func getTestLog(t *testing.T, size int) *Log {
+
+	log := New(dao.NewMemoryTransparencyLogRepository(), dao.NewMemoryCardRepository())
+	appendTestCards(t, log, 0, size)
+
+	return log
+}
+
+//
+// appendTestCards saves and appends the test cards of the indexes given.
+//
+func appendTestCards(t *testing.T, log *Log, from, count int) {
+
+	for i := from; i < from+count; i++ {
+		assert.NoError(t, log.AppendCard(mock.StartNoopSpan(), saveTestCard(t, log, i)))
+	}
+}
+
+//
+// saveTestCard keeps the test card of the index given pending and saves it the way the controller does.
+//
+func saveTestCard(t *testing.T, log *Log, i int) *model.CardDTO {
+
+	card := newTestCard(i)
+	assert.NoError(t, log.KeepCardPending(mock.StartNoopSpan(), card))
+	assert.NoError(t, log.cardRepository.SaveCard(mock.StartNoopSpan(), card))
+
+	return card
+}
+
+//
+// newTestCard returns the test card of the index given signed by the VirgilCards service.
+//
+func newTestCard(i int) *model.CardDTO {
+
+	card := model.NewCardDTO()
+	card.ID = fmt.Sprintf("card %d", i)
+	card.Identity = "alice"
+	card.ApplicationID = "application"
+	card.ContentSnapshot = fmt.Sprintf("snapshot %d", i)
+	card.AppendSignature(&model.CardSignatureDTO{Signer: model.SelfSignatureType, Signature: "self signature"})
+	card.AppendSignature(&model.CardSignatureDTO{Signer: model.VirgilSignatureType, Signature: "virgil signature"})
+
+	return card
+}
+
+//
+// decodeTestHash decodes the base64-encoded hash.
+//
+func decodeTestHash(t *testing.T, encoded string) []byte {
+
+	hash, err := base64.StdEncoding.DecodeString(encoded)
+	assert.NoError(t, err)
+
+	return hash
+}
+
+//
+// decodeTestHashes decodes the base64-encoded hashes.
+//
+func decodeTestHashes(t *testing.T, encoded []string) [][]byte {
+
+	hashes := make([][]byte, 0, len(encoded))
+	for _, e := range encoded {
+		hashes = append(hashes, decodeTestHash(t, e))
+	}
+
+	return hashes
+}
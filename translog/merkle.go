@@ -0,0 +1,192 @@
+package translog
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+//
+// RFC 6962 hash prefixes, so a leaf hash can never be taken for a node hash.
+//
+const (
+	leafHashPrefix = 0x00
+	nodeHashPrefix = 0x01
+)
+
+//
+// LeafHash returns the RFC 6962 hash of the leaf data.
+//
+func LeafHash(data []byte) []byte {
+
+	h := sha256.New()
+	h.Write([]byte{leafHashPrefix})
+	h.Write(data)
+
+	return h.Sum(nil)
+}
+
+//
+// nodeHash returns the RFC 6962 hash of the interior node.
+//
+func nodeHash(left, right []byte) []byte {
+
+	h := sha256.New()
+	h.Write([]byte{nodeHashPrefix})
+	h.Write(left)
+	h.Write(right)
+
+	return h.Sum(nil)
+}
+
+//
+// RootHash returns the Merkle tree hash of the leaf hashes.
+//
+func RootHash(leafHashes [][]byte) []byte {
+
+	switch len(leafHashes) {
+	case 0:
+		empty := sha256.Sum256(nil)
+
+		return empty[:]
+	case 1:
+		return leafHashes[0]
+	}
+
+	k := splitPoint(len(leafHashes))
+
+	return nodeHash(RootHash(leafHashes[:k]), RootHash(leafHashes[k:]))
+}
+
+//
+// InclusionPath returns the RFC 6962 audit path of the leaf in the tree of the leaf hashes.
+//
+func InclusionPath(index int, leafHashes [][]byte) [][]byte {
+
+	if 1 >= len(leafHashes) {
+		return [][]byte{}
+	}
+
+	k := splitPoint(len(leafHashes))
+	if index < k {
+		return append(InclusionPath(index, leafHashes[:k]), RootHash(leafHashes[k:]))
+	}
+
+	return append(InclusionPath(index-k, leafHashes[k:]), RootHash(leafHashes[:k]))
+}
+
+//
+// ConsistencyPath returns the RFC 6962 consistency proof of the tree of the first leaf hashes
+// and the tree of all the leaf hashes.
+//
+func ConsistencyPath(first int, leafHashes [][]byte) [][]byte {
+
+	return consistencySubPath(first, leafHashes, true)
+}
+
+//
+// consistencySubPath is the RFC 6962 SUBPROOF. The complete flag tells the subtree is the whole first tree.
+//
+func consistencySubPath(first int, leafHashes [][]byte, complete bool) [][]byte {
+
+	if first == len(leafHashes) {
+		if complete {
+			return [][]byte{}
+		}
+
+		return [][]byte{RootHash(leafHashes)}
+	}
+
+	k := splitPoint(len(leafHashes))
+	if first <= k {
+		return append(consistencySubPath(first, leafHashes[:k], complete), RootHash(leafHashes[k:]))
+	}
+
+	return append(consistencySubPath(first-k, leafHashes[k:], false), RootHash(leafHashes[:k]))
+}
+
+//
+// VerifyInclusion returns true if the audit path proves the leaf is in the tree of the size and root hash given.
+//
+func VerifyInclusion(index, size int64, leafHash []byte, path [][]byte, rootHash []byte) bool {
+
+	if 0 > index || index >= size {
+		return false
+	}
+
+	fn, sn, r := index, size-1, leafHash
+	for _, p := range path {
+		if 0 == sn {
+			return false
+		}
+
+		if 1 == fn&1 || fn == sn {
+			r = nodeHash(p, r)
+			for 0 == fn&1 && 0 != fn {
+				fn, sn = fn>>1, sn>>1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn, sn = fn>>1, sn>>1
+	}
+
+	return 0 == sn && bytes.Equal(r, rootHash)
+}
+
+//
+// VerifyConsistency returns true if the consistency proof proves the tree of the first size and root hash
+// is a prefix of the tree of the second size and root hash.
+//
+func VerifyConsistency(first, second int64, firstRootHash, secondRootHash []byte, path [][]byte) bool {
+
+	switch {
+	case 0 >= first || first > second:
+		return false
+	case first == second:
+		return 0 == len(path) && bytes.Equal(firstRootHash, secondRootHash)
+	case 0 == len(path):
+		return false
+	}
+
+	// the first tree is a complete subtree, so its root is the starting node of the proof.
+	if 0 == first&(first-1) {
+		path = append([][]byte{firstRootHash}, path...)
+	}
+
+	fn, sn := first-1, second-1
+	for 1 == fn&1 {
+		fn, sn = fn>>1, sn>>1
+	}
+
+	fr, sr := path[0], path[0]
+	for _, c := range path[1:] {
+		if 0 == sn {
+			return false
+		}
+
+		if 1 == fn&1 || fn == sn {
+			fr, sr = nodeHash(c, fr), nodeHash(c, sr)
+			for 0 == fn&1 && 0 != fn {
+				fn, sn = fn>>1, sn>>1
+			}
+		} else {
+			sr = nodeHash(sr, c)
+		}
+		fn, sn = fn>>1, sn>>1
+	}
+
+	return 0 == sn && bytes.Equal(fr, firstRootHash) && bytes.Equal(sr, secondRootHash)
+}
+
+//
+// splitPoint returns the largest power of two less than n.
+//
+func splitPoint(n int) int {
+
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+
+	return k
+}
@@ -0,0 +1,93 @@
+package translog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// RootHash :: for the empty tree and a single leaf :: returns the RFC 6962 hashes.
+//
+func TestRootHashForTheEmptyTreeAndASingleLeaf(t *testing.T) {
+
+	assert.Equal(t,
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		hex.EncodeToString(RootHash(nil)),
+	)
+	assert.Equal(t,
+		"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+		hex.EncodeToString(RootHash([][]byte{LeafHash(nil)})),
+	)
+}
+
+//
+// InclusionPath :: for every leaf of the trees up to 17 leaves :: is verified against the tree root.
+//
+func TestInclusionPathIsVerified(t *testing.T) {
+
+	leafHashes := getTestLeafHashes(17)
+
+	for size := 1; size <= len(leafHashes); size++ {
+		rootHash := RootHash(leafHashes[:size])
+
+		for index := 0; index < size; index++ {
+			path := InclusionPath(index, leafHashes[:size])
+
+			assert.True(t, VerifyInclusion(int64(index), int64(size), leafHashes[index], path, rootHash),
+				"index %d, size %d", index, size)
+			assert.False(t, VerifyInclusion(int64(index), int64(size), LeafHash([]byte("forged")), path, rootHash),
+				"index %d, size %d", index, size)
+		}
+	}
+}
+
+//
+// ConsistencyPath :: for every pair of the trees up to 17 leaves :: is verified against both tree roots.
+//
+func TestConsistencyPathIsVerified(t *testing.T) {
+
+	leafHashes := getTestLeafHashes(17)
+
+	for second := 1; second <= len(leafHashes); second++ {
+		secondRootHash := RootHash(leafHashes[:second])
+
+		for first := 1; first <= second; first++ {
+			firstRootHash := RootHash(leafHashes[:first])
+			path := ConsistencyPath(first, leafHashes[:second])
+
+			assert.True(t,
+				VerifyConsistency(int64(first), int64(second), firstRootHash, secondRootHash, path),
+				"first %d, second %d", first, second,
+			)
+		}
+	}
+}
+
+//
+// VerifyConsistency :: for a forked tree :: returns false.
+//
+func TestVerifyConsistencyForAForkedTree(t *testing.T) {
+
+	leafHashes := getTestLeafHashes(8)
+	forkedLeafHashes := append(getTestLeafHashes(5)[:4], LeafHash([]byte("forked")))
+
+	path := ConsistencyPath(5, leafHashes)
+
+	assert.False(t, VerifyConsistency(5, 8, RootHash(forkedLeafHashes), RootHash(leafHashes), path))
+}
+
+//
+// getTestLeafHashes returns the leaf hashes of the test tree.
+//
+func getTestLeafHashes(size int) [][]byte {
+
+	leafHashes := make([][]byte, 0, size)
+	for i := 0; i < size; i++ {
+		leafHashes = append(leafHashes, LeafHash([]byte(fmt.Sprintf("leaf %d", i))))
+	}
+
+	return leafHashes
+}
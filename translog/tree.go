@@ -0,0 +1,197 @@
+package translog
+
+import (
+	"crypto/sha256"
+	"math/bits"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/db/cassandra"
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+)
+
+//
+// tree is the RFC 6962 tree of the transparency log made of the complete subtree hashes kept in the repository.
+// Any tree hash and proof is made of O(log N) complete subtree hashes, so the leaves are never read all.
+// The complete subtree never changes, so the hash which is not stored yet is made of its children and stored.
+//
+type tree struct {
+	span       tracer.Span
+	repository dao.TransparencyLogRepositoryProvider
+	hashes     map[treeNode][]byte
+}
+
+//
+// treeNode is the complete subtree of 2^level leaves starting from the leaf index*2^level.
+//
+type treeNode struct {
+	level uint
+	index int64
+}
+
+//
+// newTree returns the tree of the repository given. The subtree hashes are kept by the tree once read.
+//
+func newTree(span tracer.Span, repository dao.TransparencyLogRepositoryProvider) *tree {
+
+	return &tree{
+		span:       span,
+		repository: repository,
+		hashes:     make(map[treeNode][]byte),
+	}
+}
+
+//
+// completeSubtrees stores the hashes of the complete subtrees the leaf of the index given is the last leaf of.
+// The largest one is made of the smaller ones, so all of them are stored.
+//
+func (t *tree) completeSubtrees(index int64) error {
+
+	level := uint(bits.TrailingZeros64(uint64(index + 1)))
+	if 0 == level {
+		return nil
+	}
+
+	_, err := t.subtreeHash(level, (index+1)>>level-1)
+
+	return err
+}
+
+//
+// subtreeHash returns the hash of the complete subtree of the level and index given.
+// The hash which is not stored is made of its children hashes and stored, the store error is set to the span only.
+//
+func (t *tree) subtreeHash(level uint, index int64) ([]byte, error) {
+
+	node := treeNode{level: level, index: index}
+	if hash, ok := t.hashes[node]; ok {
+		return hash, nil
+	}
+
+	hash, err := t.repository.GetTransparencyLogNodeHash(t.span, level, index)
+	if err == cassandra.ErrEntityNotFound && 0 < level {
+		left, err := t.subtreeHash(level-1, index<<1)
+		if nil != err {
+			return nil, err
+		}
+
+		right, err := t.subtreeHash(level-1, index<<1+1)
+		if nil != err {
+			return nil, err
+		}
+
+		hash = nodeHash(left, right)
+		if err := t.repository.SaveTransparencyLogNodeHash(t.span, level, index, hash); nil != err {
+			_ = tracer.SetSpanErrorAndReturn(t.span, err)
+		}
+	} else if nil != err {
+		return nil, errors.WithMessage(err, "transparency log node (%d, %d) hash error", level, index)
+	}
+
+	t.hashes[node] = hash
+
+	return hash, nil
+}
+
+//
+// rangeHash returns the Merkle tree hash of the leaves from the first index given up to the second one.
+// The first index is the start of the subtree the RFC 6962 tree is split into.
+//
+func (t *tree) rangeHash(from, to int64) ([]byte, error) {
+
+	size := to - from
+	switch {
+	case 0 == size:
+		empty := sha256.Sum256(nil)
+
+		return empty[:], nil
+	case 0 == size&(size-1):
+		level := uint(bits.TrailingZeros64(uint64(size)))
+
+		return t.subtreeHash(level, from>>level)
+	}
+
+	k := int64(splitPoint(int(size)))
+
+	left, err := t.rangeHash(from, from+k)
+	if nil != err {
+		return nil, err
+	}
+
+	right, err := t.rangeHash(from+k, to)
+	if nil != err {
+		return nil, err
+	}
+
+	return nodeHash(left, right), nil
+}
+
+//
+// inclusionPath returns the RFC 6962 audit path of the leaf of the index given in the subtree of the leaves range.
+//
+func (t *tree) inclusionPath(index, from, to int64) ([][]byte, error) {
+
+	if 1 >= to-from {
+		return [][]byte{}, nil
+	}
+
+	k := int64(splitPoint(int(to - from)))
+
+	pathFrom, pathTo, siblingFrom, siblingTo := from, from+k, from+k, to
+	if index >= from+k {
+		pathFrom, pathTo, siblingFrom, siblingTo = from+k, to, from, from+k
+	}
+
+	path, err := t.inclusionPath(index, pathFrom, pathTo)
+	if nil != err {
+		return nil, err
+	}
+
+	sibling, err := t.rangeHash(siblingFrom, siblingTo)
+	if nil != err {
+		return nil, err
+	}
+
+	return append(path, sibling), nil
+}
+
+//
+// consistencyPath is the RFC 6962 SUBPROOF of the first tree of the size given in the subtree of the leaves range.
+// The complete flag tells the subtree is the whole first tree.
+//
+func (t *tree) consistencyPath(first, from, to int64, complete bool) ([][]byte, error) {
+
+	if first == to {
+		if complete {
+			return [][]byte{}, nil
+		}
+
+		hash, err := t.rangeHash(from, to)
+		if nil != err {
+			return nil, err
+		}
+
+		return [][]byte{hash}, nil
+	}
+
+	k := int64(splitPoint(int(to - from)))
+
+	pathFrom, pathTo, siblingFrom, siblingTo := from, from+k, from+k, to
+	if first > from+k {
+		pathFrom, pathTo, siblingFrom, siblingTo = from+k, to, from, from+k
+		complete = false
+	}
+
+	path, err := t.consistencyPath(first, pathFrom, pathTo, complete)
+	if nil != err {
+		return nil, err
+	}
+
+	sibling, err := t.rangeHash(siblingFrom, siblingTo)
+	if nil != err {
+		return nil, err
+	}
+
+	return append(path, sibling), nil
+}
@@ -0,0 +1,72 @@
+package translog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/dao"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// tree :: for every tree up to 17 leaves :: makes the root hashes and paths of the leaf hashes.
+//
+func TestTreeMakesTheHashesOfTheLeafHashes(t *testing.T) {
+
+	leafHashes := getTestLeafHashes(17)
+	repository := getTestTreeRepository(t, leafHashes)
+
+	for size := 0; size <= len(leafHashes); size++ {
+		rootHash, err := newTree(mock.StartNoopSpan(), repository).rangeHash(0, int64(size))
+		assert.NoError(t, err)
+		assert.Equal(t, RootHash(leafHashes[:size]), rootHash, "size %d", size)
+
+		for index := 0; index < size; index++ {
+			path, err := newTree(mock.StartNoopSpan(), repository).inclusionPath(int64(index), 0, int64(size))
+			assert.NoError(t, err)
+			assert.Equal(t, InclusionPath(index, leafHashes[:size]), path, "index %d, size %d", index, size)
+		}
+
+		for first := 1; first <= size; first++ {
+			path, err := newTree(mock.StartNoopSpan(), repository).consistencyPath(int64(first), 0, int64(size), true)
+			assert.NoError(t, err)
+			assert.Equal(t, ConsistencyPath(first, leafHashes[:size]), path, "first %d, size %d", first, size)
+		}
+	}
+}
+
+//
+// completeSubtrees :: for the leaves appended :: stores the hashes of the complete subtrees.
+//
+func TestTreeCompleteSubtreesStoresTheSubtreeHashes(t *testing.T) {
+
+	leafHashes := getTestLeafHashes(8)
+	repository := getTestTreeRepository(t, leafHashes)
+
+	hash, err := repository.GetTransparencyLogNodeHash(mock.StartNoopSpan(), 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, RootHash(leafHashes), hash)
+
+	hash, err = repository.GetTransparencyLogNodeHash(mock.StartNoopSpan(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, RootHash(leafHashes[4:6]), hash)
+}
+
+//
+// getTestTreeRepository returns the memory repository of the leaf hashes given with the complete subtrees stored.
+//
+func getTestTreeRepository(t *testing.T, leafHashes [][]byte) *dao.MemoryTransparencyLogRepository {
+
+	repository := dao.NewMemoryTransparencyLogRepository()
+	for i, leafHash := range leafHashes {
+		entry := &model.TransparencyLogEntry{CardID: fmt.Sprintf("card %d", i), LeafHash: leafHash}
+
+		assert.NoError(t, repository.AppendTransparencyLogEntry(mock.StartNoopSpan(), entry))
+		assert.NoError(t, newTree(mock.StartNoopSpan(), repository).completeSubtrees(entry.LeafIndex))
+	}
+
+	return repository
+}
@@ -0,0 +1,179 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/http/response"
+	"github.com/VirgilSecurity/virgil-services-core-kit/tracer"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/app/admin"
+	"github.com/VirgilSecurity/virgil-services-cards/src/app/controller"
+)
+
+//
+// AdminHandler provides an abstraction on transport layer for the Admin API.
+//
+type AdminHandler struct {
+	adminController admin.Provider
+	cardsController controller.Provider
+}
+
+//
+// NewAdminHandler return Admin handler instance.
+//
+func NewAdminHandler(adminController admin.Provider, cardsController controller.Provider) *AdminHandler {
+
+	return &AdminHandler{
+		adminController: adminController,
+		cardsController: cardsController,
+	}
+}
+
+//
+// CardGet handles GET /admin/card/:card_id endpoint.
+//
+func (h *AdminHandler) CardGet(req *http.Request, cardID string) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	card, err := h.adminController.CardGet(span, cardID)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(card)
+}
+
+//
+// IdentityChains handles GET /admin/chains?identity= endpoint.
+//
+func (h *AdminHandler) IdentityChains(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	chains, err := h.adminController.IdentityChains(span, req.URL.Query().Get("identity"))
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(chains)
+}
+
+//
+// ChainForceDelete handles POST /admin/chain/actions/delete endpoint.
+//
+func (h *AdminHandler) ChainForceDelete(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewChainForceDeleteRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	record, err := h.adminController.ChainForceDelete(span, request)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(record)
+}
+
+//
+// ChainRestore handles POST /admin/chain/actions/restore endpoint.
+//
+func (h *AdminHandler) ChainRestore(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewChainRestoreRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	record, err := h.cardsController.ChainRestore(span, request)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(record)
+}
+
+//
+// ApplicationCardCounts handles GET /admin/applications/card-counts endpoint.
+//
+func (h *AdminHandler) ApplicationCardCounts(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	counts, err := h.adminController.ApplicationCardCounts(span)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(counts)
+}
+
+//
+// CardAuditRecords handles GET /admin/audit?identity=|chain_id= endpoint.
+//
+func (h *AdminHandler) CardAuditRecords(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	query := req.URL.Query()
+	records, err := h.adminController.CardAuditRecords(span, query.Get("identity"), query.Get("chain_id"))
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(records)
+}
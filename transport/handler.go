@@ -14,6 +14,8 @@ import (
 const (
 	// nolint
 	SuperseededCardIDHTTPHeader = "X-Virgil-Is-Superseeded"
+	// nolint
+	SuperseedingCardIDHTTPHeader = "X-Virgil-Superseeded-By"
 )
 
 //
@@ -85,7 +87,7 @@ func (h *CardsHandler) CardGet(req *http.Request, cardID string) response.Provid
 	)
 	defer span.Finish()
 
-	request, err := NewGetRequest(req)
+	request, err := NewCardGetRequest(req)
 	if err != nil {
 		return response.New(tracer.SetSpanErrorAndReturn(span, err))
 	}
@@ -101,10 +103,73 @@ func (h *CardsHandler) CardGet(req *http.Request, cardID string) response.Provid
 	if card.IsSuperseeded {
 		resp.SetHeader(SuperseededCardIDHTTPHeader, "true")
 	}
+	if card.SuperseedingCardID != "" {
+		resp.SetHeader(SuperseedingCardIDHTTPHeader, card.SuperseedingCardID)
+	}
 
 	return resp
 }
 
+//
+// CardBatchGet handles POST /card/actions/get endpoint.
+//
+func (h *CardsHandler) CardBatchGet(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewCardBatchGetRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	result, err := h.cardsController.CardBatchGet(span, request)
+	if err != nil {
+		h.eventMeter.IncCardGetError(request.AccountID, request.ApplicationID)
+		return response.New(err)
+	}
+	h.eventMeter.IncCardGetSuccess(request.AccountID, request.ApplicationID)
+
+	return response.New(result)
+}
+
+//
+// CardChain handles GET /card/:card_id/chain endpoint.
+//
+func (h *CardsHandler) CardChain(req *http.Request, cardID string) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewCardChainRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	cards, err := h.cardsController.CardChain(span, request, cardID)
+	if err != nil {
+		h.eventMeter.IncCardGetError(request.AccountID, request.ApplicationID)
+		return response.New(err)
+	}
+	h.eventMeter.IncCardGetSuccess(request.AccountID, request.ApplicationID)
+
+	return response.New(cards)
+}
+
 //
 // CardSearch handles POST /card/actions/search endpoint.
 //
@@ -125,6 +190,17 @@ func (h *CardsHandler) CardSearch(req *http.Request) response.Provider {
 		return response.New(tracer.SetSpanErrorAndReturn(span, err))
 	}
 
+	if request.IsPaginated() {
+		page, err := h.cardsController.CardSearchPage(span, request)
+		if err != nil {
+			h.eventMeter.IncCardSearchError(request.AccountID, request.ApplicationID)
+			return response.New(err)
+		}
+		h.eventMeter.IncCardSearchSuccess(request.AccountID, request.ApplicationID)
+
+		return response.New(page)
+	}
+
 	cards, err := h.cardsController.CardSearch(span, request)
 	if err != nil {
 		h.eventMeter.IncCardSearchError(request.AccountID, request.ApplicationID)
@@ -164,3 +240,133 @@ func (h *CardsHandler) CardDelete(req *http.Request) response.Provider {
 
 	return response.New(card)
 }
+
+//
+// CardServiceKeys handles GET /card/service-keys endpoint.
+//
+func (h *CardsHandler) CardServiceKeys(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	serviceKeys, err := h.cardsController.CardServiceKeys(span)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(serviceKeys)
+}
+
+//
+// CardVerify handles POST /card/actions/verify endpoint.
+//
+func (h *CardsHandler) CardVerify(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewCardVerifyRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	result, err := h.cardsController.CardVerify(span, request)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(result)
+}
+
+//
+// TransparencyTreeHead handles GET /card/transparency/tree-head endpoint.
+//
+func (h *CardsHandler) TransparencyTreeHead(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	treeHead, err := h.cardsController.TransparencyTreeHead(span)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(treeHead)
+}
+
+//
+// TransparencyInclusionProof handles GET /card/:card_id/transparency/inclusion endpoint.
+//
+func (h *CardsHandler) TransparencyInclusionProof(req *http.Request, cardID string) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewTransparencyInclusionRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	proof, err := h.cardsController.TransparencyInclusionProof(span, request, cardID)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(proof)
+}
+
+//
+// TransparencyConsistencyProof handles GET /card/transparency/consistency endpoint.
+//
+func (h *CardsHandler) TransparencyConsistencyProof(req *http.Request) response.Provider {
+
+	span := tracer.SpanFromContext(req.Context())
+	span = span.Tracer().StartSpan(
+		tracer.GetCallerInfo(),
+		tracer.ChildOf(span.Context()),
+		tracer.Tags{
+			tracer.TagComponent: tracer.ComponentTransport,
+		},
+	)
+	defer span.Finish()
+
+	request, err := NewTransparencyConsistencyRequest(req)
+	if err != nil {
+		return response.New(tracer.SetSpanErrorAndReturn(span, err))
+	}
+
+	proof, err := h.cardsController.TransparencyConsistencyProof(span, request)
+	if err != nil {
+		return response.New(err)
+	}
+
+	return response.New(proof)
+}
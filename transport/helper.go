@@ -5,6 +5,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	kitHTTP "github.com/VirgilSecurity/virgil-services-core-kit/http"
 
@@ -50,6 +51,71 @@ func NewGetRequest(req *http.Request) (*api.CardBaseRequest, error) {
 	return &request, nil
 }
 
+//
+// NewCardGetRequest constructs CardGetRequest structure.
+//
+func NewCardGetRequest(req *http.Request) (*api.CardGetRequest, error) {
+
+	h, err := NewHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := api.CardGetRequest{
+		Headers: h,
+		Follow:  req.URL.Query().Get("follow"),
+	}
+
+	if request.Follow != "" && !request.IsFollowLatest() {
+		return nil, api.ErrFollowParameterIsIncorrect
+	}
+
+	if request.IncludeExpired, err = parseIncludeExpired(req); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// NewCardChainRequest constructs CardChainRequest structure.
+//
+func NewCardChainRequest(req *http.Request) (*api.CardChainRequest, error) {
+
+	h, err := NewHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := api.CardChainRequest{
+		Headers: h,
+	}
+
+	if request.IncludeExpired, err = parseIncludeExpired(req); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// parseIncludeExpired returns the "include_expired" query parameter value. It is false if the parameter is not set.
+//
+func parseIncludeExpired(req *http.Request) (bool, error) {
+
+	includeExpired := req.URL.Query().Get("include_expired")
+	if includeExpired == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(includeExpired)
+	if err != nil {
+		return false, api.ErrIncludeExpiredParameterIsIncorrect
+	}
+
+	return value, nil
+}
+
 //
 // NewBaseRequest constructs CardBaseRequest structure.
 //
@@ -92,6 +158,138 @@ func NewCardSearchRequest(req *http.Request) (*api.CardSearchRequest, error) {
 	return &request, nil
 }
 
+//
+// NewCardBatchGetRequest constructs CardBatchGetRequest structure.
+//
+func NewCardBatchGetRequest(req *http.Request) (*api.CardBatchGetRequest, error) {
+
+	h, err := NewHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := api.CardBatchGetRequest{
+		Headers: h,
+	}
+
+	if err := unmarshal(req.Body, &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// NewCardVerifyRequest constructs CardVerifyRequest structure.
+//
+func NewCardVerifyRequest(req *http.Request) (*api.CardVerifyRequest, error) {
+
+	h, err := NewHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := api.CardVerifyRequest{
+		Headers: h,
+	}
+
+	if err := unmarshal(req.Body, &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// NewChainRestoreRequest constructs ChainRestoreRequest structure.
+// The admin requests carry no application headers.
+//
+func NewChainRestoreRequest(req *http.Request) (*api.ChainRestoreRequest, error) {
+
+	request := api.ChainRestoreRequest{}
+
+	if err := unmarshal(req.Body, &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// NewChainForceDeleteRequest constructs ChainForceDeleteRequest structure.
+// The admin requests carry no application headers.
+//
+func NewChainForceDeleteRequest(req *http.Request) (*api.ChainForceDeleteRequest, error) {
+
+	request := api.ChainForceDeleteRequest{}
+
+	if err := unmarshal(req.Body, &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// NewTransparencyInclusionRequest constructs TransparencyInclusionRequest structure.
+//
+func NewTransparencyInclusionRequest(req *http.Request) (*api.TransparencyInclusionRequest, error) {
+
+	h, err := NewHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := api.TransparencyInclusionRequest{
+		Headers: h,
+	}
+
+	if request.TreeSize, err = parseTreeSize(req.URL.Query().Get("tree_size")); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// NewTransparencyConsistencyRequest constructs TransparencyConsistencyRequest structure.
+// The transparency log is not scoped to an application, so the request has no headers.
+//
+func NewTransparencyConsistencyRequest(req *http.Request) (*api.TransparencyConsistencyRequest, error) {
+
+	var (
+		request api.TransparencyConsistencyRequest
+		err     error
+	)
+
+	if request.FirstTreeSize, err = parseTreeSize(req.URL.Query().Get("first")); err != nil {
+		return nil, err
+	}
+
+	if request.SecondTreeSize, err = parseTreeSize(req.URL.Query().Get("second")); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+//
+// parseTreeSize parses the optional tree size query parameter. An empty value means zero.
+//
+func parseTreeSize(value string) (int64, error) {
+
+	if value == "" {
+		return 0, nil
+	}
+
+	treeSize, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || treeSize < 0 {
+		return 0, api.ErrTransparencyTreeSizeIsIncorrect
+	}
+
+	return treeSize, nil
+}
+
 //
 // unmarshal makes unmarshal request body according request structure.
 //
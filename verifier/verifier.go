@@ -0,0 +1,356 @@
+package verifier
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/errors"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/generator"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+)
+
+//
+// Signature verification error messages.
+//
+const (
+	signatureIsEmptyError      = "signature is empty"
+	signatureDecodeError       = "signature decode error"
+	extraSnapshotDecodeError   = "extra snapshot decode error"
+	signatureVerificationError = "signature verification failed"
+	serviceKeyIsUnknownError   = "service key is unknown"
+	publicKeyIsMissingError    = "card public key is missing"
+	signerIsNotVerifiableError = "signer key is not known to the verifier"
+	revocationMismatchError    = "revocation content doesn't match the signed snapshot"
+	treeHeadMismatchError      = "tree head content doesn't match the signed snapshot"
+)
+
+//
+// Provider verifies the Virgil Cards offline.
+//
+type Provider interface {
+	//
+	// Verify recalculates the Virgil Card ID and verifies the card signatures.
+	//
+	Verify(card *model.CardDTO) (*model.CardVerifyResult, error)
+
+	//
+	// VerifyRevocation verifies the chain revocation record countersigned by the VirgilCards service.
+	//
+	VerifyRevocation(revocation *model.CardRevocation) (*model.CardSignatureVerifyResult, error)
+
+	//
+	// VerifyTreeHead verifies the transparency log tree head signed by the VirgilCards service.
+	//
+	VerifyTreeHead(treeHead *model.TreeHead) (*model.CardSignatureVerifyResult, error)
+}
+
+//
+// serviceKey is a decoded VirgilCards service public key.
+//
+type serviceKey struct {
+	keyID     string
+	publicKey []byte
+}
+
+//
+// Verifier verifies the Virgil Cards with the VirgilCards service keyring.
+// The self signature is verified with the card public key, the virgil signature is verified with the service key
+// it was made with. The other signatures are reported as unverified.
+//
+type Verifier struct {
+	crypto      crypto.Provider
+	idGenerator generator.IDProvider
+	serviceKeys []*serviceKey
+}
+
+//
+// New returns an instance of the Virgil Cards verifier with the service keys given.
+//
+func New(
+	crypto crypto.Provider,
+	idGenerator generator.IDProvider,
+	serviceKeys []*model.ServiceKey,
+) (*Verifier, error) {
+
+	keys := make([]*serviceKey, 0, len(serviceKeys))
+	for _, key := range serviceKeys {
+		publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+		if nil != err {
+			return nil, errors.WithMessage(err, "service key (%s) decode error", key.KeyID)
+		}
+
+		keys = append(keys, &serviceKey{
+			keyID:     key.KeyID,
+			publicKey: publicKey,
+		})
+	}
+
+	return &Verifier{
+		crypto:      crypto,
+		idGenerator: idGenerator,
+		serviceKeys: keys,
+	}, nil
+}
+
+//
+// Verify recalculates the Virgil Card ID and verifies the card signatures.
+// An error is returned if the card content snapshot can not be parsed.
+//
+func (v *Verifier) Verify(card *model.CardDTO) (*model.CardVerifyResult, error) {
+
+	if "" == card.GetContentSnapshot() {
+		return nil, api.ErrCSRIsEmpty
+	}
+
+	snapshot, err := base64.StdEncoding.DecodeString(card.GetContentSnapshot())
+	if nil != err {
+		return nil, api.ErrContentSnapshotIsNotABase64EncodedString.WithMessage(
+			"content_snapshot (%s) decode error", card.GetContentSnapshot(),
+		)
+	}
+
+	csr := new(api.CSR)
+	if err = json.Unmarshal(snapshot, csr); nil != err {
+		return nil, api.ErrContentSnapshotIsNotAJSONMessage.WithMessage(
+			"unmarshal decoded content_snapshot (%s)", card.GetContentSnapshot(),
+		)
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(csr.GetPublicKey())
+	if nil != err {
+		return nil, api.ErrCSRPublicKeyDecoding.WithMessage(
+			"public key value (%s) decode error", csr.GetPublicKey(),
+		)
+	}
+
+	result := &model.CardVerifyResult{
+		CardID:     v.idGenerator.VirgilCardID(csr.GetVersion(), snapshot),
+		Signatures: make([]*model.CardSignatureVerifyResult, 0, len(card.GetSignatures())),
+	}
+	if "" != card.GetID() && card.GetID() != result.CardID {
+		result.Errors = append(result.Errors, model.CardVerifyErrorIDMismatch)
+	}
+
+	hasSelfSignature, hasVirgilSignature := false, false
+	for _, signature := range card.GetSignatures() {
+		var signatureResult *model.CardSignatureVerifyResult
+
+		switch signature.GetSigner() {
+		case model.SelfSignatureType:
+			hasSelfSignature = true
+			signatureResult = v.verifySelfSignature(snapshot, signature, publicKey)
+		case model.VirgilSignatureType:
+			hasVirgilSignature = true
+			signatureResult = v.verifyVirgilSignature(snapshot, signature)
+		default:
+			signatureResult = &model.CardSignatureVerifyResult{
+				Signer: signature.GetSigner(),
+				Status: model.CardSignatureStatusUnverified,
+				Error:  signerIsNotVerifiableError,
+			}
+		}
+
+		result.Signatures = append(result.Signatures, signatureResult)
+	}
+
+	if !hasSelfSignature {
+		result.Errors = append(result.Errors, model.CardVerifyErrorSelfSignatureIsMissing)
+	}
+	if !hasVirgilSignature {
+		result.Errors = append(result.Errors, model.CardVerifyErrorVirgilSignatureIsMissing)
+	}
+
+	result.Valid = 0 == len(result.Errors)
+	for _, signatureResult := range result.Signatures {
+		if model.CardSignatureStatusInvalid == signatureResult.Status {
+			result.Valid = false
+		}
+	}
+
+	return result, nil
+}
+
+//
+// VerifyRevocation verifies the chain revocation record countersigned by the VirgilCards service.
+// The record content must match the signed content snapshot, so the signature covers the details returned.
+// An error is returned if the content snapshot can not be parsed.
+//
+func (v *Verifier) VerifyRevocation(revocation *model.CardRevocation) (*model.CardSignatureVerifyResult, error) {
+
+	snapshot, err := base64.StdEncoding.DecodeString(revocation.ContentSnapshot)
+	if nil != err {
+		return nil, api.ErrContentSnapshotIsNotABase64EncodedString.WithMessage(
+			"revocation content_snapshot (%s) decode error", revocation.ContentSnapshot,
+		)
+	}
+
+	content := new(model.CardRevocationContent)
+	if err = json.Unmarshal(snapshot, content); nil != err {
+		return nil, api.ErrContentSnapshotIsNotAJSONMessage.WithMessage(
+			"unmarshal decoded revocation content_snapshot (%s)", revocation.ContentSnapshot,
+		)
+	}
+
+	if *content != *revocation.GetContent() {
+		return &model.CardSignatureVerifyResult{
+			Signer: model.VirgilSignatureType,
+			KeyID:  revocation.KeyID,
+			Status: model.CardSignatureStatusInvalid,
+			Error:  revocationMismatchError,
+		}, nil
+	}
+
+	return v.verifyVirgilSignature(model.SignedData(model.RevocationSignaturePrefix, snapshot), &model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: revocation.Signature,
+		KeyID:     revocation.KeyID,
+	}), nil
+}
+
+//
+// VerifyTreeHead verifies the transparency log tree head signed by the VirgilCards service.
+// The tree head content must match the signed content snapshot, so the signature covers the root hash returned.
+// An error is returned if the content snapshot can not be parsed.
+//
+func (v *Verifier) VerifyTreeHead(treeHead *model.TreeHead) (*model.CardSignatureVerifyResult, error) {
+
+	snapshot, err := base64.StdEncoding.DecodeString(treeHead.ContentSnapshot)
+	if nil != err {
+		return nil, api.ErrContentSnapshotIsNotABase64EncodedString.WithMessage(
+			"tree head content_snapshot (%s) decode error", treeHead.ContentSnapshot,
+		)
+	}
+
+	content := new(model.TreeHeadContent)
+	if err = json.Unmarshal(snapshot, content); nil != err {
+		return nil, api.ErrContentSnapshotIsNotAJSONMessage.WithMessage(
+			"unmarshal decoded tree head content_snapshot (%s)", treeHead.ContentSnapshot,
+		)
+	}
+
+	if *content != *treeHead.GetContent() {
+		return &model.CardSignatureVerifyResult{
+			Signer: model.VirgilSignatureType,
+			KeyID:  treeHead.KeyID,
+			Status: model.CardSignatureStatusInvalid,
+			Error:  treeHeadMismatchError,
+		}, nil
+	}
+
+	return v.verifyVirgilSignature(model.SignedData(model.TreeHeadSignaturePrefix, snapshot), &model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: treeHead.Signature,
+		KeyID:     treeHead.KeyID,
+	}), nil
+}
+
+//
+// verifySelfSignature verifies the self signature with the card public key.
+// The self signature can not be verified without the public key or the signature itself, so it is invalid then.
+//
+func (v *Verifier) verifySelfSignature(
+	snapshot []byte,
+	signature *model.CardSignatureDTO,
+	publicKey []byte,
+) *model.CardSignatureVerifyResult {
+
+	if 0 == len(publicKey) {
+		return &model.CardSignatureVerifyResult{
+			Signer: signature.GetSigner(),
+			Status: model.CardSignatureStatusInvalid,
+			Error:  publicKeyIsMissingError,
+		}
+	}
+	if "" == signature.GetSignature() {
+		return &model.CardSignatureVerifyResult{
+			Signer: signature.GetSigner(),
+			Status: model.CardSignatureStatusInvalid,
+			Error:  signatureIsEmptyError,
+		}
+	}
+
+	return v.verifySignature(snapshot, signature, publicKey)
+}
+
+//
+// verifyVirgilSignature verifies the virgil signature with the service key of the signature key ID.
+// The signatures made before the key IDs were stamped are verified with every service key.
+//
+func (v *Verifier) verifyVirgilSignature(
+	snapshot []byte,
+	signature *model.CardSignatureDTO,
+) *model.CardSignatureVerifyResult {
+
+	if "" != signature.GetKeyID() {
+		for _, key := range v.serviceKeys {
+			if key.keyID == signature.GetKeyID() {
+				return v.verifySignature(snapshot, signature, key.publicKey)
+			}
+		}
+
+		return &model.CardSignatureVerifyResult{
+			Signer: signature.GetSigner(),
+			KeyID:  signature.GetKeyID(),
+			Status: model.CardSignatureStatusInvalid,
+			Error:  serviceKeyIsUnknownError,
+		}
+	}
+
+	result := &model.CardSignatureVerifyResult{
+		Signer: signature.GetSigner(),
+		Status: model.CardSignatureStatusInvalid,
+		Error:  serviceKeyIsUnknownError,
+	}
+	for _, key := range v.serviceKeys {
+		if result = v.verifySignature(snapshot, signature, key.publicKey); model.CardSignatureStatusValid == result.Status {
+			result.KeyID = key.keyID
+
+			break
+		}
+	}
+
+	return result
+}
+
+//
+// verifySignature verifies the signature of the content snapshot and the signature extra snapshot.
+//
+func (v *Verifier) verifySignature(
+	snapshot []byte,
+	signature *model.CardSignatureDTO,
+	publicKey []byte,
+) *model.CardSignatureVerifyResult {
+
+	result := &model.CardSignatureVerifyResult{
+		Signer: signature.GetSigner(),
+		KeyID:  signature.GetKeyID(),
+		Status: model.CardSignatureStatusInvalid,
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature.GetSignature())
+	if nil != err || 0 == len(signatureBytes) {
+		result.Error = signatureDecodeError
+
+		return result
+	}
+
+	extraSnapshot, err := base64.StdEncoding.DecodeString(signature.GetExtraContent())
+	if nil != err {
+		result.Error = extraSnapshotDecodeError
+
+		return result
+	}
+
+	if err = v.crypto.ValidateVirgilCardSignature(snapshot, extraSnapshot, publicKey, signatureBytes); nil != err {
+		result.Error = signatureVerificationError
+
+		return result
+	}
+
+	result.Status = model.CardSignatureStatusValid
+
+	return result
+}
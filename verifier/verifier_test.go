@@ -0,0 +1,350 @@
+package verifier
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VirgilSecurity/virgil-services-core-kit/test/helper"
+
+	"github.com/VirgilSecurity/virgil-services-cards/src/api"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/encoder"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/generator"
+	"github.com/VirgilSecurity/virgil-services-cards/src/dep/crypto/hasher"
+	"github.com/VirgilSecurity/virgil-services-cards/src/model"
+	"github.com/VirgilSecurity/virgil-services-cards/test/mock"
+)
+
+//
+// Testing variables.
+//
+var (
+	testCardPublicKey     = []byte("card public key")
+	testActiveServiceKey  = []byte("active service key")
+	testRetiredServiceKey = []byte("retired service key")
+
+	testSnapshot = []byte(`{"identity":"alice","public_key":"` +
+		base64.StdEncoding.EncodeToString(testCardPublicKey) + `","version":"5.0","created_at":1515686245}`)
+	testSelfSignature   = []byte("self signature")
+	testVirgilSignature = []byte("virgil signature")
+)
+
+//
+// Verify :: for a valid card :: returns the valid result with the calculated card ID.
+//
+func TestVerifyForAValidCard(t *testing.T) {
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testCardPublicKey, testSelfSignature).Return(nil)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testActiveServiceKey, testVirgilSignature).
+		Return(nil)
+	verifier, idGenerator := getVerifierUnderTest(t, crypto)
+
+	result, err := verifier.Verify(getTestCard(&model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: base64.StdEncoding.EncodeToString(testVirgilSignature),
+		KeyID:     "active",
+	}))
+
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, idGenerator.VirgilCardID(model.CardVersion5, testSnapshot), result.CardID)
+	assert.Equal(t, []*model.CardSignatureVerifyResult{
+		{Signer: model.SelfSignatureType, Status: model.CardSignatureStatusValid},
+		{Signer: model.VirgilSignatureType, KeyID: "active", Status: model.CardSignatureStatusValid},
+	}, result.Signatures)
+}
+
+//
+// Verify :: for a virgil signature without key ID :: verifies it with every service key.
+//
+func TestVerifyForAVirgilSignatureWithoutKeyID(t *testing.T) {
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testCardPublicKey, testSelfSignature).Return(nil)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testActiveServiceKey, testVirgilSignature).
+		Return(errors.New("signature is incorrect"))
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testRetiredServiceKey, testVirgilSignature).
+		Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	result, err := verifier.Verify(getTestCard(&model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: base64.StdEncoding.EncodeToString(testVirgilSignature),
+	}))
+
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "retired", result.Signatures[1].KeyID)
+	assert.Equal(t, model.CardSignatureStatusValid, result.Signatures[1].Status)
+}
+
+//
+// Verify :: for a virgil signature of an unknown key :: returns the invalid result.
+//
+func TestVerifyForAVirgilSignatureOfAnUnknownKey(t *testing.T) {
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testCardPublicKey, testSelfSignature).Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	result, err := verifier.Verify(getTestCard(&model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: base64.StdEncoding.EncodeToString(testVirgilSignature),
+		KeyID:     "unknown",
+	}))
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, model.CardSignatureStatusInvalid, result.Signatures[1].Status)
+	assert.Equal(t, serviceKeyIsUnknownError, result.Signatures[1].Error)
+}
+
+//
+// Verify :: for a wrong card ID and a missing virgil signature :: returns the result with errors.
+//
+func TestVerifyForAWrongCardIDAndAMissingVirgilSignature(t *testing.T) {
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testCardPublicKey, testSelfSignature).Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	card := getTestCard(&model.CardSignatureDTO{
+		Signer:    model.ApplicationSignatureType,
+		Signature: base64.StdEncoding.EncodeToString([]byte("app signature")),
+	})
+	card.ID = "wrong card id"
+
+	result, err := verifier.Verify(card)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, []string{
+		model.CardVerifyErrorIDMismatch,
+		model.CardVerifyErrorVirgilSignatureIsMissing,
+	}, result.Errors)
+	assert.Equal(t, model.CardSignatureStatusUnverified, result.Signatures[1].Status)
+}
+
+//
+// Verify :: for a card without a public key :: reports the self signature invalid and the card not valid.
+//
+func TestVerifyForACardWithoutAPublicKey(t *testing.T) {
+
+	snapshot := []byte(`{"identity":"alice","version":"5.0","created_at":1515686245}`)
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature", snapshot, []byte{}, testActiveServiceKey, testVirgilSignature).Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	card := getTestCard(&model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: base64.StdEncoding.EncodeToString(testVirgilSignature),
+		KeyID:     "active",
+	})
+	card.ContentSnapshot = base64.StdEncoding.EncodeToString(snapshot)
+
+	result, err := verifier.Verify(card)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, &model.CardSignatureVerifyResult{
+		Signer: model.SelfSignatureType,
+		Status: model.CardSignatureStatusInvalid,
+		Error:  publicKeyIsMissingError,
+	}, result.Signatures[0])
+}
+
+//
+// Verify :: for an empty self signature :: reports the self signature invalid and the card not valid.
+//
+func TestVerifyForAnEmptySelfSignature(t *testing.T) {
+
+	crypto := new(mock.Crypto)
+	crypto.On("ValidateVirgilCardSignature", testSnapshot, []byte{}, testActiveServiceKey, testVirgilSignature).
+		Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	card := getTestCard(&model.CardSignatureDTO{
+		Signer:    model.VirgilSignatureType,
+		Signature: base64.StdEncoding.EncodeToString(testVirgilSignature),
+		KeyID:     "active",
+	})
+	card.GetSignatures()[0].Signature = ""
+
+	result, err := verifier.Verify(card)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, &model.CardSignatureVerifyResult{
+		Signer: model.SelfSignatureType,
+		Status: model.CardSignatureStatusInvalid,
+		Error:  signatureIsEmptyError,
+	}, result.Signatures[0])
+}
+
+//
+// Verify :: for not a base64 content snapshot :: returns an error.
+//
+func TestVerifyForNotABase64ContentSnapshot(t *testing.T) {
+
+	verifier, _ := getVerifierUnderTest(t, new(mock.Crypto))
+
+	card := model.NewCardDTO()
+	card.ContentSnapshot = "not a base64 snapshot"
+
+	result, err := verifier.Verify(card)
+
+	assert.Error(t, err)
+	assert.Equal(t, api.ErrContentSnapshotIsNotABase64EncodedString, helper.ExtractHTTPError(err))
+	assert.Nil(t, result)
+}
+
+//
+// VerifyRevocation :: for a valid revocation record :: returns the valid result.
+//
+func TestVerifyRevocationForAValidRevocationRecord(t *testing.T) {
+
+	revocation, snapshot := getTestRevocation(t)
+
+	crypto := new(mock.Crypto)
+	crypto.On(
+		"ValidateVirgilCardSignature",
+		model.SignedData(model.RevocationSignaturePrefix, snapshot),
+		[]byte{},
+		testActiveServiceKey,
+		testVirgilSignature,
+	).Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	result, err := verifier.VerifyRevocation(revocation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.CardSignatureVerifyResult{
+		Signer: model.VirgilSignatureType,
+		KeyID:  "active",
+		Status: model.CardSignatureStatusValid,
+	}, result)
+}
+
+//
+// VerifyRevocation :: for a revocation reason not matching the snapshot :: returns the invalid result.
+//
+func TestVerifyRevocationForAReasonNotMatchingTheSnapshot(t *testing.T) {
+
+	revocation, _ := getTestRevocation(t)
+	revocation.Reason = model.RevocationReasonSuperseded
+	verifier, _ := getVerifierUnderTest(t, new(mock.Crypto))
+
+	result, err := verifier.VerifyRevocation(revocation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardSignatureStatusInvalid, result.Status)
+	assert.Equal(t, revocationMismatchError, result.Error)
+}
+
+//
+// VerifyTreeHead :: for a root hash not matching the snapshot :: returns the invalid result.
+//
+func TestVerifyTreeHeadForARootHashNotMatchingTheSnapshot(t *testing.T) {
+
+	treeHead := &model.TreeHead{
+		TreeHeadContent: model.TreeHeadContent{TreeSize: 2, RootHash: "cm9vdA==", Timestamp: 1515686245},
+		Signature:       base64.StdEncoding.EncodeToString(testVirgilSignature),
+		KeyID:           "active",
+	}
+	snapshot, err := json.Marshal(treeHead.GetContent())
+	assert.NoError(t, err)
+	treeHead.ContentSnapshot = base64.StdEncoding.EncodeToString(snapshot)
+
+	crypto := new(mock.Crypto)
+	crypto.On(
+		"ValidateVirgilCardSignature",
+		model.SignedData(model.TreeHeadSignaturePrefix, snapshot),
+		[]byte{},
+		testActiveServiceKey,
+		testVirgilSignature,
+	).Return(nil)
+	verifier, _ := getVerifierUnderTest(t, crypto)
+
+	result, err := verifier.VerifyTreeHead(treeHead)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardSignatureStatusValid, result.Status)
+
+	treeHead.RootHash = "Zm9ya2Vk"
+	result, err = verifier.VerifyTreeHead(treeHead)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardSignatureStatusInvalid, result.Status)
+	assert.Equal(t, treeHeadMismatchError, result.Error)
+}
+
+//
+// getVerifierUnderTest returns the verifier with the active and retired service keys and its ID generator.
+//
+func getVerifierUnderTest(t *testing.T, crypto *mock.Crypto) (*Verifier, generator.IDProvider) {
+
+	idGenerator := generator.NewID(hasher.NewSHA512(), encoder.NewHex())
+
+	verifier, err := New(crypto, idGenerator, []*model.ServiceKey{
+		{
+			KeyID:     "active",
+			PublicKey: base64.StdEncoding.EncodeToString(testActiveServiceKey),
+			Status:    model.ServiceKeyStatusActive,
+		},
+		{
+			KeyID:     "retired",
+			PublicKey: base64.StdEncoding.EncodeToString(testRetiredServiceKey),
+			Status:    model.ServiceKeyStatusRetired,
+		},
+	})
+	assert.NoError(t, err)
+
+	return verifier, idGenerator
+}
+
+//
+// getTestCard returns the self signed test card with the signature given.
+//
+func getTestCard(signature *model.CardSignatureDTO) *model.CardDTO {
+
+	card := model.NewCardDTO()
+	card.ContentSnapshot = base64.StdEncoding.EncodeToString(testSnapshot)
+	card.AppendSignature(&model.CardSignatureDTO{
+		Signer:    model.SelfSignatureType,
+		Signature: base64.StdEncoding.EncodeToString(testSelfSignature),
+	})
+	card.AppendSignature(signature)
+
+	return card
+}
+
+//
+// getTestRevocation returns the revocation record signed with the active service key and its content snapshot.
+//
+func getTestRevocation(t *testing.T) (*model.CardRevocation, []byte) {
+
+	revocation := &model.CardRevocation{
+		CardRevocationContent: model.CardRevocationContent{
+			ChainID:      "chain",
+			Identity:     "alice",
+			CardID:       "card",
+			DeleteCardID: "delete card",
+			Reason:       model.RevocationReasonKeyCompromise,
+			Note:         "laptop stolen",
+			RevokedAt:    1515686245,
+		},
+		Signature: base64.StdEncoding.EncodeToString(testVirgilSignature),
+		KeyID:     "active",
+	}
+
+	snapshot, err := json.Marshal(revocation.GetContent())
+	assert.NoError(t, err)
+	revocation.ContentSnapshot = base64.StdEncoding.EncodeToString(snapshot)
+
+	return revocation, snapshot
+}